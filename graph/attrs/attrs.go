@@ -0,0 +1,173 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package attrs
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+var _ encoding.AttributeStore = (*Store)(nil)
+
+// Store holds attributes for nodes and edges keyed by node ID and
+// by edge end point IDs.
+type Store struct {
+	undirected bool
+
+	nodes map[int64]map[string]interface{}
+	edges map[[2]int64]map[string]interface{}
+}
+
+// NewStore returns a new Store for a directed graph. Edge attributes
+// are keyed by the ordered pair of edge end point IDs.
+func NewStore() *Store {
+	return &Store{
+		nodes: make(map[int64]map[string]interface{}),
+		edges: make(map[[2]int64]map[string]interface{}),
+	}
+}
+
+// NewUndirectedStore returns a new Store for an undirected graph.
+// Edge attributes are keyed by the unordered pair of edge end point
+// IDs, so the edge from u to v shares attributes with the edge from
+// v to u.
+func NewUndirectedStore() *Store {
+	s := NewStore()
+	s.undirected = true
+	return s
+}
+
+// edgeKey returns the key for the edge between uid and vid.
+func (s *Store) edgeKey(uid, vid int64) [2]int64 {
+	if s.undirected && vid < uid {
+		uid, vid = vid, uid
+	}
+	return [2]int64{uid, vid}
+}
+
+// SetNodeAttr sets the attribute key of the node with the given ID to value.
+func (s *Store) SetNodeAttr(id int64, key string, value interface{}) {
+	a, ok := s.nodes[id]
+	if !ok {
+		a = make(map[string]interface{})
+		s.nodes[id] = a
+	}
+	a[key] = value
+}
+
+// NodeAttr returns the value of the attribute key of the node with the given
+// ID and whether the attribute exists.
+func (s *Store) NodeAttr(id int64, key string) (value interface{}, ok bool) {
+	value, ok = s.nodes[id][key]
+	return value, ok
+}
+
+// NodeAttrs returns the attribute map of the node with the given ID. The
+// returned map is owned by the Store and must not be modified. NodeAttrs
+// returns nil if the node has no attributes.
+func (s *Store) NodeAttrs(id int64) map[string]interface{} {
+	return s.nodes[id]
+}
+
+// DeleteNodeAttr deletes the attribute key of the node with the given ID.
+func (s *Store) DeleteNodeAttr(id int64, key string) {
+	a, ok := s.nodes[id]
+	if !ok {
+		return
+	}
+	delete(a, key)
+	if len(a) == 0 {
+		delete(s.nodes, id)
+	}
+}
+
+// SetEdgeAttr sets the attribute key of the edge from u to v with IDs uid
+// and vid to value.
+func (s *Store) SetEdgeAttr(uid, vid int64, key string, value interface{}) {
+	k := s.edgeKey(uid, vid)
+	a, ok := s.edges[k]
+	if !ok {
+		a = make(map[string]interface{})
+		s.edges[k] = a
+	}
+	a[key] = value
+}
+
+// EdgeAttr returns the value of the attribute key of the edge from u to v
+// with IDs uid and vid, and whether the attribute exists.
+func (s *Store) EdgeAttr(uid, vid int64, key string) (value interface{}, ok bool) {
+	value, ok = s.edges[s.edgeKey(uid, vid)][key]
+	return value, ok
+}
+
+// EdgeAttrs returns the attribute map of the edge from u to v with IDs uid
+// and vid. The returned map is owned by the Store and must not be modified.
+// EdgeAttrs returns nil if the edge has no attributes.
+func (s *Store) EdgeAttrs(uid, vid int64) map[string]interface{} {
+	return s.edges[s.edgeKey(uid, vid)]
+}
+
+// DeleteEdgeAttr deletes the attribute key of the edge from u to v with IDs
+// uid and vid.
+func (s *Store) DeleteEdgeAttr(uid, vid int64, key string) {
+	k := s.edgeKey(uid, vid)
+	a, ok := s.edges[k]
+	if !ok {
+		return
+	}
+	delete(a, key)
+	if len(a) == 0 {
+		delete(s.edges, k)
+	}
+}
+
+// ClearNode deletes all attributes of the node with the given ID. Attributes
+// of edges attached to the node are not deleted.
+func (s *Store) ClearNode(id int64) {
+	delete(s.nodes, id)
+}
+
+// ClearEdge deletes all attributes of the edge from u to v with IDs uid and
+// vid.
+func (s *Store) ClearEdge(uid, vid int64) {
+	delete(s.edges, s.edgeKey(uid, vid))
+}
+
+// NodeAttributes returns the attributes of the node with the given ID as
+// encoding.Attribute values sorted by key. Attribute values are formatted
+// using the fmt package's %v verb. NodeAttributes satisfies the
+// encoding.AttributeStore interface.
+func (s *Store) NodeAttributes(id int64) []encoding.Attribute {
+	return attributes(s.nodes[id])
+}
+
+// EdgeAttributes returns the attributes of the edge from u to v with IDs uid
+// and vid as encoding.Attribute values sorted by key. Attribute values are
+// formatted using the fmt package's %v verb. EdgeAttributes satisfies the
+// encoding.AttributeStore interface.
+func (s *Store) EdgeAttributes(uid, vid int64) []encoding.Attribute {
+	return attributes(s.edges[s.edgeKey(uid, vid)])
+}
+
+func attributes(m map[string]interface{}) []encoding.Attribute {
+	if len(m) == 0 {
+		return nil
+	}
+	attrs := make([]encoding.Attribute, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, encoding.Attribute{Key: k, Value: fmt.Sprint(v)})
+	}
+	sort.Sort(byKey(attrs))
+	return attrs
+}
+
+// byKey sorts a slice of encoding.Attribute by key.
+type byKey []encoding.Attribute
+
+func (a byKey) Len() int           { return len(a) }
+func (a byKey) Less(i, j int) bool { return a[i].Key < a[j].Key }
+func (a byKey) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
@@ -0,0 +1,76 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package attrs
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+func TestStoreNodes(t *testing.T) {
+	s := NewStore()
+	s.SetNodeAttr(1, "label", "a")
+	s.SetNodeAttr(1, "weight", 2.5)
+	s.SetNodeAttr(2, "label", "b")
+
+	if v, ok := s.NodeAttr(1, "weight"); !ok || v != 2.5 {
+		t.Errorf("unexpected node attribute: got:%v,%t want:2.5,true", v, ok)
+	}
+	if _, ok := s.NodeAttr(3, "label"); ok {
+		t.Error("unexpected attribute for absent node")
+	}
+
+	got := s.NodeAttributes(1)
+	want := []encoding.Attribute{{Key: "label", Value: "a"}, {Key: "weight", Value: "2.5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected node attributes: got:%v want:%v", got, want)
+	}
+
+	s.DeleteNodeAttr(1, "label")
+	s.DeleteNodeAttr(1, "weight")
+	if s.NodeAttrs(1) != nil {
+		t.Errorf("expected no attributes after deletion: got:%v", s.NodeAttrs(1))
+	}
+	s.ClearNode(2)
+	if got := s.NodeAttributes(2); got != nil {
+		t.Errorf("expected no attributes after clear: got:%v", got)
+	}
+}
+
+func TestStoreEdges(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		store      *Store
+		undirected bool
+	}{
+		{name: "directed", store: NewStore()},
+		{name: "undirected", store: NewUndirectedStore(), undirected: true},
+	} {
+		s := test.store
+		s.SetEdgeAttr(1, 2, "color", "red")
+
+		_, ok := s.EdgeAttr(1, 2, "color")
+		if !ok {
+			t.Errorf("missing edge attribute for %s store", test.name)
+		}
+		_, ok = s.EdgeAttr(2, 1, "color")
+		if ok != test.undirected {
+			t.Errorf("unexpected reverse edge attribute presence for %s store: got:%t want:%t", test.name, ok, test.undirected)
+		}
+
+		got := s.EdgeAttributes(1, 2)
+		want := []encoding.Attribute{{Key: "color", Value: "red"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected edge attributes for %s store: got:%v want:%v", test.name, got, want)
+		}
+
+		s.ClearEdge(1, 2)
+		if s.EdgeAttrs(1, 2) != nil {
+			t.Errorf("expected no edge attributes after clear for %s store", test.name)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package attrs provides storage for node and edge attributes that is
+// decoupled from graph structure.
+//
+// A Store allows arbitrary data to be associated with the nodes and edges
+// of a graph without defining node and edge types that carry the data.
+// Embedding a *Store together with a graph in a struct gives a value that
+// satisfies encoding.AttributeStore, allowing graph encoders to include the
+// stored attributes in their output.
+//
+//  g := struct {
+//  	graph.Directed
+//  	*attrs.Store
+//  }{dg, attrs.NewStore()}
+//  g.SetNodeAttr(1, "label", "start")
+//  b, err := dot.Marshal(g, "", "", "\t")
+//
+// Store values are not safe for concurrent use if any goroutine is
+// modifying the Store. Concurrent calls to the Store's read methods are
+// safe when no writes are being made.
+package attrs // import "gonum.org/v1/gonum/graph/attrs"
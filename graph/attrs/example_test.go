@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package attrs_test
+
+import (
+	"fmt"
+	"log"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/attrs"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func ExampleStore() {
+	dg := simple.NewDirectedGraph()
+	dg.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	dg.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+	// Combine the graph and a Store so that the
+	// attributes are available to the DOT encoder.
+	g := struct {
+		graph.Directed
+		*attrs.Store
+	}{dg, attrs.NewStore()}
+	g.SetNodeAttr(0, "label", "start")
+	g.SetNodeAttr(2, "label", "end")
+	g.SetEdgeAttr(1, 2, "weight", 3)
+
+	b, err := dot.Marshal(g, "", "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+
+	// Output:
+	// strict digraph {
+	//   // Node definitions.
+	//   0 [label=start];
+	//   1;
+	//   2 [label=end];
+	//
+	//   // Edge definitions.
+	//   0 -> 1;
+	//   1 -> 2 [weight=3];
+	// }
+}
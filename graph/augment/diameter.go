@@ -0,0 +1,177 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package augment
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// BestEdgesToAddForDiameter returns up to budget edges that are not in g
+// and whose addition to g reduces the diameter of g, the greatest shortest
+// path length in hops between any pair of nodes.
+//
+// The edges are chosen greedily: at each step every absent edge is evaluated
+// and the edge that most reduces the diameter of g, with the previously
+// suggested edges added, is selected. If g is not connected its diameter is
+// infinite, and candidates are instead compared by the number of pairs of
+// nodes that remain mutually unreachable. Ties are broken by the smallest
+// sum of shortest path lengths over all pairs of nodes and then by the IDs
+// of the edge's end points. The search stops early if no absent edge reduces
+// the diameter or the number of unreachable pairs. The greedy selection does
+// not guarantee that the returned set of edges is optimal.
+//
+// The all pairs shortest path lengths are computed once by breadth-first
+// search and updated incrementally as edges are selected. Evaluating a
+// candidate edge takes O(n^2) time for a graph with n nodes, so each step
+// takes O(n^4) time and the total cost is O(n(n+m) + budget*n^4) time and
+// O(n^2) space, where m is the number of edges in g.
+//
+// The returned edges are simple.Edge values holding the nodes of g with the
+// lower ID as the From node.
+func BestEdgesToAddForDiameter(g graph.Undirected, budget int) []graph.Edge {
+	if budget <= 0 {
+		return nil
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	if n < 2 {
+		return nil
+	}
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// The distance between unreachable pairs is n,
+	// which is greater than any shortest path length.
+	dist := make([][]int, n)
+	queue := make([]int, 0, n)
+	for i := range nodes {
+		d := make([]int, n)
+		for j := range d {
+			d[j] = n
+		}
+		d[i] = 0
+		queue = append(queue[:0], i)
+		for len(queue) != 0 {
+			k := queue[0]
+			queue = queue[1:]
+			to := g.From(nodes[k].ID())
+			for to.Next() {
+				j := indexOf[to.Node().ID()]
+				if d[j] == n {
+					d[j] = d[k] + 1
+					queue = append(queue, j)
+				}
+			}
+		}
+		dist[i] = d
+	}
+
+	var edges []graph.Edge
+	current := evaluate(dist, -1, -1)
+	for len(edges) < budget {
+		best := current
+		bi, bj := -1, -1
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if dist[i][j] == 1 {
+					// The nodes are already adjacent.
+					continue
+				}
+				s := evaluate(dist, i, j)
+				if s.less(best) {
+					best = s
+					bi, bj = i, j
+				}
+			}
+		}
+		if bi < 0 || !best.reduces(current) {
+			break
+		}
+		edges = append(edges, simple.Edge{F: nodes[bi], T: nodes[bj]})
+		addEdge(dist, bi, bj)
+		current = best
+	}
+	return edges
+}
+
+// score is the quality of a set of all pairs shortest path lengths.
+type score struct {
+	diameter    int
+	unreachable int
+	sum         int
+}
+
+// less returns whether s is a better score than t.
+func (s score) less(t score) bool {
+	if s.unreachable != t.unreachable {
+		return s.unreachable < t.unreachable
+	}
+	if s.diameter != t.diameter {
+		return s.diameter < t.diameter
+	}
+	return s.sum < t.sum
+}
+
+// reduces returns whether s has a smaller diameter or fewer unreachable
+// pairs than t.
+func (s score) reduces(t score) bool {
+	return s.unreachable < t.unreachable || (s.unreachable == t.unreachable && s.diameter < t.diameter)
+}
+
+// evaluate returns the score of the distances in dist after the addition
+// of an edge between nodes u and v. If u is negative, the score of dist
+// itself is returned.
+func evaluate(dist [][]int, u, v int) score {
+	n := len(dist)
+	var s score
+	for x := 0; x < n; x++ {
+		for y := x + 1; y < n; y++ {
+			d := dist[x][y]
+			if u >= 0 {
+				d = min(d, min(dist[x][u]+1+dist[v][y], dist[x][v]+1+dist[u][y]))
+			}
+			if d >= n {
+				s.unreachable++
+				continue
+			}
+			s.sum += d
+			if d > s.diameter {
+				s.diameter = d
+			}
+		}
+	}
+	return s
+}
+
+// addEdge updates the distances in dist to include an edge between
+// nodes u and v.
+func addEdge(dist [][]int, u, v int) {
+	n := len(dist)
+	du := append([]int(nil), dist[u]...)
+	dv := append([]int(nil), dist[v]...)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			d := min(dist[x][y], min(du[x]+1+dv[y], dv[x]+1+du[y]))
+			if d > n {
+				d = n
+			}
+			dist[x][y] = d
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package augment
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var bestEdgesToAddForDiameterTests = []struct {
+	name   string
+	n      int
+	edges  [][2]int64
+	budget int
+	want   [][2]int64
+}{
+	{
+		name:   "empty",
+		budget: 2,
+	},
+	{
+		name:   "path zero budget",
+		n:      5,
+		edges:  [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}},
+		budget: 0,
+	},
+	{
+		name:   "path",
+		n:      5,
+		edges:  [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}},
+		budget: 1,
+		want:   [][2]int64{{0, 4}},
+	},
+	{
+		name:   "long path",
+		n:      9,
+		edges:  [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 7}, {7, 8}},
+		budget: 2,
+		want:   [][2]int64{{1, 7}},
+	},
+	{
+		name:   "complete",
+		n:      4,
+		edges:  [][2]int64{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+		budget: 3,
+	},
+	{
+		name:   "star",
+		n:      4,
+		edges:  [][2]int64{{0, 1}, {0, 2}, {0, 3}},
+		budget: 3,
+	},
+	{
+		name:   "disconnected",
+		n:      6,
+		edges:  [][2]int64{{0, 1}, {1, 2}, {3, 4}},
+		budget: 5,
+		want:   [][2]int64{{1, 3}, {1, 5}, {1, 4}},
+	},
+}
+
+func TestBestEdgesToAddForDiameter(t *testing.T) {
+	for _, test := range bestEdgesToAddForDiameterTests {
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < test.n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+		got := BestEdgesToAddForDiameter(g, test.budget)
+		var gotIDs [][2]int64
+		for _, e := range got {
+			if g.HasEdgeBetween(e.From().ID(), e.To().ID()) {
+				t.Errorf("unexpected existing edge suggested for %q: %d--%d", test.name, e.From().ID(), e.To().ID())
+			}
+			gotIDs = append(gotIDs, [2]int64{e.From().ID(), e.To().ID()})
+		}
+		if !reflect.DeepEqual(gotIDs, test.want) {
+			t.Errorf("unexpected edges for %q: got:%v want:%v", test.name, gotIDs, test.want)
+		}
+		for _, e := range got {
+			g.SetEdge(e)
+		}
+		if len(got) != 0 && diameter(g) >= diameterOfEdges(test.n, test.edges) && test.n > 0 {
+			t.Errorf("diameter not reduced for %q", test.name)
+		}
+	}
+}
+
+// diameter returns the diameter of g by exhaustive breadth-first search,
+// returning -1 if g is not connected.
+func diameter(g graph.Undirected) int {
+	nodes := graph.NodesOf(g.Nodes())
+	var max int
+	for _, u := range nodes {
+		depth := map[int64]int{u.ID(): 0}
+		queue := []graph.Node{u}
+		for len(queue) != 0 {
+			k := queue[0]
+			queue = queue[1:]
+			to := g.From(k.ID())
+			for to.Next() {
+				v := to.Node()
+				if _, ok := depth[v.ID()]; !ok {
+					depth[v.ID()] = depth[k.ID()] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+		if len(depth) != len(nodes) {
+			return -1
+		}
+		for _, d := range depth {
+			if d > max {
+				max = d
+			}
+		}
+	}
+	return max
+}
+
+func diameterOfEdges(n int, edges [][2]int64) int {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for _, e := range edges {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	d := diameter(g)
+	if d < 0 {
+		return n
+	}
+	return d
+}
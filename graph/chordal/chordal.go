@@ -0,0 +1,103 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chordal
+
+import (
+	"errors"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/ordering"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// ErrNotChordal is returned when a graph passed to a function
+// requiring a chordal graph is not chordal.
+var ErrNotChordal = errors.New("chordal: graph is not chordal")
+
+// MaxClique returns a maximum clique of the chordal graph g with nodes
+// ordered by ID. The clique is found in O(|V|+|E|) time from a perfect
+// elimination ordering of g, since every maximal clique of a chordal graph
+// is a node and its neighbors that follow it in the ordering. If g is not
+// chordal, MaxClique returns ErrNotChordal; topo.BronKerbosch may be used to
+// find the maximal cliques of general graphs.
+func MaxClique(g graph.Undirected) ([]graph.Node, error) {
+	peo, err := perfectElimination(g)
+	if err != nil {
+		return nil, err
+	}
+	pos := positions(peo)
+
+	var clique []graph.Node
+	for i, u := range peo {
+		c := []graph.Node{u}
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if pos[v.ID()] > i {
+				c = append(c, v)
+			}
+		}
+		if len(c) > len(clique) {
+			clique = c
+		}
+	}
+	sort.Sort(ordered.ByID(clique))
+	return clique, nil
+}
+
+// Coloring returns an optimal proper coloring of the chordal graph g keyed
+// by node ID, with colors in [0, k) where k is the size of a maximum clique
+// of g. Nodes are colored greedily in the reverse of a perfect elimination
+// ordering, in which the colored neighbors of each node form a clique. If g
+// is not chordal, Coloring returns ErrNotChordal.
+func Coloring(g graph.Undirected) (map[int64]int, error) {
+	peo, err := perfectElimination(g)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make(map[int64]int, len(peo))
+	var used []bool
+	for i := len(peo) - 1; i >= 0; i-- {
+		uid := peo[i].ID()
+		used = used[:0]
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			c, ok := colors[v.ID()]
+			if !ok {
+				continue
+			}
+			for len(used) <= c {
+				used = append(used, false)
+			}
+			used[c] = true
+		}
+		c := 0
+		for c < len(used) && used[c] {
+			c++
+		}
+		colors[uid] = c
+	}
+	return colors, nil
+}
+
+// perfectElimination returns a perfect elimination ordering of g, or
+// ErrNotChordal if g is not chordal.
+func perfectElimination(g graph.Undirected) ([]graph.Node, error) {
+	if !topo.IsChordal(g) {
+		return nil, ErrNotChordal
+	}
+	peo := ordering.LexBFS(g)
+	ordered.Reverse(peo)
+	return peo, nil
+}
+
+// positions returns the index of each node in order keyed by node ID.
+func positions(order []graph.Node) map[int64]int {
+	pos := make(map[int64]int, len(order))
+	for i, n := range order {
+		pos[n.ID()] = i
+	}
+	return pos
+}
@@ -0,0 +1,117 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chordal
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// randomChordal returns a random chordal graph with n nodes. Each node after
+// the first is joined to a random subset of a random maximal clique of the
+// graph so far, which preserves chordality.
+func randomChordal(n int, src rand.Source) *simple.UndirectedGraph {
+	rnd := rand.New(src)
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	cliques := [][]graph.Node{{simple.Node(0)}}
+	for i := 1; i < n; i++ {
+		u := simple.Node(i)
+		g.AddNode(u)
+		base := cliques[rnd.Intn(len(cliques))]
+		clique := []graph.Node{u}
+		for _, v := range base {
+			if rnd.Float64() < 0.8 {
+				g.SetEdge(simple.Edge{F: u, T: v})
+				clique = append(clique, v)
+			}
+		}
+		cliques = append(cliques, clique)
+	}
+	return g
+}
+
+func TestMaxCliqueAndColoring(t *testing.T) {
+	for seed := uint64(1); seed <= 50; seed++ {
+		g := randomChordal(30, rand.NewSource(seed))
+		if !topo.IsChordal(g) {
+			t.Fatalf("test graph for seed %d is not chordal", seed)
+		}
+
+		clique, err := MaxClique(g)
+		if err != nil {
+			t.Errorf("unexpected error for seed %d: %v", seed, err)
+			continue
+		}
+		for i, u := range clique {
+			if i > 0 && clique[i-1].ID() >= u.ID() {
+				t.Errorf("clique not ordered by ID for seed %d", seed)
+			}
+			for _, v := range clique[i+1:] {
+				if !g.HasEdgeBetween(u.ID(), v.ID()) {
+					t.Errorf("clique nodes %d and %d not adjacent for seed %d", u.ID(), v.ID(), seed)
+				}
+			}
+		}
+		var want int
+		for _, c := range topo.BronKerbosch(g) {
+			if len(c) > want {
+				want = len(c)
+			}
+		}
+		if len(clique) != want {
+			t.Errorf("unexpected clique size for seed %d: got:%d want:%d", seed, len(clique), want)
+		}
+
+		colors, err := Coloring(g)
+		if err != nil {
+			t.Errorf("unexpected error for seed %d: %v", seed, err)
+			continue
+		}
+		if len(colors) != g.Nodes().Len() {
+			t.Errorf("not all nodes colored for seed %d", seed)
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			if colors[e.From().ID()] == colors[e.To().ID()] {
+				t.Errorf("adjacent nodes %d and %d share color for seed %d", e.From().ID(), e.To().ID(), seed)
+			}
+		}
+		for id, c := range colors {
+			if c < 0 || c >= want {
+				t.Errorf("color %d of node %d out of range [0,%d) for seed %d", c, id, want, seed)
+			}
+		}
+	}
+}
+
+func TestNotChordal(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 4; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % 4)})
+	}
+	if _, err := MaxClique(g); err != ErrNotChordal {
+		t.Errorf("unexpected error from MaxClique: got:%v want:%v", err, ErrNotChordal)
+	}
+	if _, err := Coloring(g); err != ErrNotChordal {
+		t.Errorf("unexpected error from Coloring: got:%v want:%v", err, ErrNotChordal)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	clique, err := MaxClique(g)
+	if err != nil || clique != nil {
+		t.Errorf("unexpected result for empty graph: got:%v,%v want:nil,nil", clique, err)
+	}
+	colors, err := Coloring(g)
+	if err != nil || len(colors) != 0 {
+		t.Errorf("unexpected coloring for empty graph: got:%v,%v", colors, err)
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chordal provides exact polynomial time algorithms for problems
+// on chordal graphs that are NP-hard for general graphs.
+package chordal // import "gonum.org/v1/gonum/graph/chordal"
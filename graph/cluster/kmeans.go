@@ -0,0 +1,227 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// KMeans partitions the nodes described by features into k clusters using
+// Lloyd's k-means algorithm with k-means++ initialization. The features map
+// holds a feature vector for each node, keyed by node ID. All feature vectors
+// must have the same length.
+//
+// KMeans returns the cluster label in [0, k) of each node and the centroid of
+// each cluster. Iteration stops when no node changes cluster or after maxIter
+// iterations. If maxIter is not positive, iteration continues until no node
+// changes cluster. If a cluster becomes empty during iteration, its centroid
+// is moved to the feature vector that is farthest from its current centroid.
+//
+// If src is not nil it is used as the random source, otherwise rand.Float64
+// is used. For a given src the result is deterministic.
+//
+// KMeans will panic if k is not positive, if k is greater than the number of
+// nodes or if the feature vectors do not all have the same length.
+func KMeans(features map[int64][]float64, k int, src rand.Source, maxIter int) (labels map[int64]int, centroids [][]float64) {
+	if k <= 0 {
+		panic("cluster: k must be positive")
+	}
+	if k > len(features) {
+		panic("cluster: k greater than number of nodes")
+	}
+
+	// Order the nodes so the result does not depend
+	// on map iteration order.
+	ids := make([]int64, 0, len(features))
+	for id := range features {
+		ids = append(ids, id)
+	}
+	sort.Sort(ordered.Int64s(ids))
+	x := make([][]float64, len(ids))
+	dim := len(features[ids[0]])
+	for i, id := range ids {
+		x[i] = features[id]
+		if len(x[i]) != dim {
+			panic("cluster: feature length mismatch")
+		}
+	}
+
+	var rnd func() float64
+	if src == nil {
+		rnd = rand.Float64
+	} else {
+		rnd = rand.New(src).Float64
+	}
+
+	centroids = initCentroids(x, k, rnd)
+	assign := make([]int, len(x))
+	for i := range assign {
+		assign[i] = -1
+	}
+	counts := make([]int, k)
+	for iter := 0; maxIter <= 0 || iter < maxIter; iter++ {
+		changed := false
+		for i, v := range x {
+			best := nearest(v, centroids)
+			// Only move a node when it is strictly nearer to another
+			// centroid so that ties cannot cause oscillation.
+			if best != assign[i] && (assign[i] < 0 || sqDist(v, centroids[best]) < sqDist(v, centroids[assign[i]])) {
+				assign[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		for c := range centroids {
+			counts[c] = 0
+			for j := range centroids[c] {
+				centroids[c][j] = 0
+			}
+		}
+		for i, v := range x {
+			c := assign[i]
+			counts[c]++
+			for j, f := range v {
+				centroids[c][j] += f
+			}
+		}
+		for c, n := range counts {
+			if n == 0 {
+				continue
+			}
+			for j := range centroids[c] {
+				centroids[c][j] /= float64(n)
+			}
+		}
+		for c, n := range counts {
+			if n != 0 {
+				continue
+			}
+			// Move the centroid of the empty cluster to
+			// the point farthest from its own centroid.
+			far, dist := -1, -1.0
+			for i, v := range x {
+				if counts[assign[i]] < 2 {
+					continue
+				}
+				if d := sqDist(v, centroids[assign[i]]); d > dist {
+					far, dist = i, d
+				}
+			}
+			if far < 0 {
+				continue
+			}
+			counts[assign[far]]--
+			copy(centroids[c], x[far])
+			assign[far] = c
+			counts[c]++
+		}
+	}
+
+	labels = make(map[int64]int, len(ids))
+	for i, id := range ids {
+		labels[id] = assign[i]
+	}
+	return labels, centroids
+}
+
+// initCentroids returns k initial centroids for the points in x chosen
+// using the k-means++ seeding method.
+func initCentroids(x [][]float64, k int, rnd func() float64) [][]float64 {
+	centroids := make([][]float64, 0, k)
+	first := int(rnd() * float64(len(x)))
+	if first == len(x) {
+		first--
+	}
+	centroids = append(centroids, append([]float64(nil), x[first]...))
+	chosen := make([]bool, len(x))
+	chosen[first] = true
+
+	dist := make([]float64, len(x))
+	for i, v := range x {
+		dist[i] = sqDist(v, centroids[0])
+	}
+	for len(centroids) < k {
+		var sum float64
+		for _, d := range dist {
+			sum += d
+		}
+		next := -1
+		if sum > 0 {
+			r := rnd() * sum
+			for i, d := range dist {
+				if d == 0 {
+					continue
+				}
+				next = i
+				r -= d
+				if r < 0 {
+					break
+				}
+			}
+		} else {
+			// All points coincide with a centroid,
+			// so take the first point not yet chosen.
+			for i, ok := range chosen {
+				if !ok {
+					next = i
+					break
+				}
+			}
+		}
+		chosen[next] = true
+		c := append([]float64(nil), x[next]...)
+		centroids = append(centroids, c)
+		for i, v := range x {
+			dist[i] = math.Min(dist[i], sqDist(v, c))
+		}
+	}
+	return centroids
+}
+
+// nearest returns the index of the centroid nearest to v.
+func nearest(v []float64, centroids [][]float64) int {
+	best, dist := 0, math.Inf(1)
+	for c, centroid := range centroids {
+		if d := sqDist(v, centroid); d < dist {
+			best, dist = c, d
+		}
+	}
+	return best
+}
+
+// Inertia returns the sum of the squared Euclidean distances between the
+// feature vectors of the nodes and the centroids of the clusters they are
+// labeled with, the objective minimized by KMeans. The distances are summed
+// in order of node ID so the result does not depend on map iteration order.
+func Inertia(features map[int64][]float64, labels map[int64]int, centroids [][]float64) float64 {
+	ids := make([]int64, 0, len(features))
+	for id := range features {
+		ids = append(ids, id)
+	}
+	sort.Sort(ordered.Int64s(ids))
+	var sum float64
+	for _, id := range ids {
+		sum += sqDist(features[id], centroids[labels[id]])
+	}
+	return sum
+}
+
+// sqDist returns the squared Euclidean distance between a and b.
+func sqDist(a, b []float64) float64 {
+	var d float64
+	for i, v := range a {
+		diff := v - b[i]
+		d += diff * diff
+	}
+	return d
+}
@@ -0,0 +1,181 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// blobs returns n points around each of the given centers with
+// normally distributed offsets scaled by sd.
+func blobs(centers [][]float64, n int, sd float64, src rand.Source) (features map[int64][]float64, truth map[int64]int) {
+	rnd := rand.New(src)
+	features = make(map[int64][]float64)
+	truth = make(map[int64]int)
+	var id int64
+	for c, center := range centers {
+		for i := 0; i < n; i++ {
+			v := make([]float64, len(center))
+			for j, f := range center {
+				v[j] = f + sd*rnd.NormFloat64()
+			}
+			features[id] = v
+			truth[id] = c
+			id++
+		}
+	}
+	return features, truth
+}
+
+func TestKMeansSeparated(t *testing.T) {
+	centers := [][]float64{{0, 0}, {10, 10}, {-10, 10}, {10, -10}}
+	features, truth := blobs(centers, 50, 0.5, rand.NewSource(1))
+	for seed := uint64(1); seed <= 10; seed++ {
+		labels, centroids := KMeans(features, len(centers), rand.NewSource(seed), 100)
+		if len(centroids) != len(centers) {
+			t.Fatalf("unexpected number of centroids for seed %d: got:%d want:%d", seed, len(centroids), len(centers))
+		}
+
+		// Each true cluster must map to exactly one label.
+		labelOf := make(map[int]int)
+		for id, c := range truth {
+			l, ok := labelOf[c]
+			if !ok {
+				labelOf[c] = labels[id]
+				continue
+			}
+			if l != labels[id] {
+				t.Errorf("cluster %d split for seed %d", c, seed)
+				break
+			}
+		}
+		used := make(map[int]bool)
+		for _, l := range labelOf {
+			used[l] = true
+		}
+		if len(used) != len(centers) {
+			t.Errorf("clusters merged for seed %d: got %d distinct labels", seed, len(used))
+		}
+
+		got := Inertia(features, labels, centroids)
+		if max := 2 * 0.5 * 0.5 * float64(len(features)) * 2; got > max {
+			t.Errorf("unexpectedly large inertia for seed %d: got:%v want<=%v", seed, got, max)
+		}
+	}
+}
+
+func TestKMeansDeterministic(t *testing.T) {
+	features, _ := blobs([][]float64{{0, 0, 0}, {3, 0, 0}, {0, 3, 0}}, 30, 1, rand.NewSource(2))
+	for seed := uint64(1); seed <= 5; seed++ {
+		wantLabels, wantCentroids := KMeans(features, 3, rand.NewSource(seed), 0)
+		for i := 0; i < 5; i++ {
+			gotLabels, gotCentroids := KMeans(features, 3, rand.NewSource(seed), 0)
+			if !reflect.DeepEqual(gotLabels, wantLabels) {
+				t.Errorf("labels differ between runs for seed %d", seed)
+			}
+			if !reflect.DeepEqual(gotCentroids, wantCentroids) {
+				t.Errorf("centroids differ between runs for seed %d", seed)
+			}
+		}
+	}
+}
+
+func TestKMeansConverged(t *testing.T) {
+	features, _ := blobs([][]float64{{0, 0}, {2, 1}, {1, 3}}, 40, 1, rand.NewSource(3))
+	for seed := uint64(1); seed <= 5; seed++ {
+		labels, centroids := KMeans(features, 3, rand.NewSource(seed), 0)
+
+		// At convergence every node is labeled with its nearest
+		// centroid and each centroid is the mean of its nodes.
+		sums := make([][]float64, len(centroids))
+		counts := make([]int, len(centroids))
+		for i := range sums {
+			sums[i] = make([]float64, 2)
+		}
+		for id, v := range features {
+			l := labels[id]
+			if n := nearest(v, centroids); sqDist(v, centroids[n]) < sqDist(v, centroids[l]) {
+				t.Errorf("node %d not labeled with nearest centroid for seed %d", id, seed)
+			}
+			counts[l]++
+			for j, f := range v {
+				sums[l][j] += f
+			}
+		}
+		for c, s := range sums {
+			if counts[c] == 0 {
+				t.Errorf("empty cluster %d for seed %d", c, seed)
+				continue
+			}
+			for j, f := range s {
+				if mean := f / float64(counts[c]); math.Abs(mean-centroids[c][j]) > 1e-12 {
+					t.Errorf("centroid %d not at cluster mean for seed %d: got:%v want:%v", c, seed, centroids[c][j], mean)
+				}
+			}
+		}
+	}
+}
+
+func TestKMeansInertiaDecreasing(t *testing.T) {
+	features, _ := blobs([][]float64{{0, 0}, {2, 1}, {1, 3}, {4, 4}}, 25, 1.5, rand.NewSource(4))
+	for seed := uint64(1); seed <= 5; seed++ {
+		last := math.Inf(1)
+		for iter := 1; iter <= 10; iter++ {
+			labels, centroids := KMeans(features, 4, rand.NewSource(seed), iter)
+			got := Inertia(features, labels, centroids)
+			if got > last+1e-9 {
+				t.Errorf("inertia increased for seed %d at iteration %d: %v > %v", seed, iter, got, last)
+			}
+			last = got
+		}
+	}
+}
+
+func TestKMeansCoincident(t *testing.T) {
+	features := map[int64][]float64{
+		0: {1, 1},
+		1: {1, 1},
+		2: {1, 1},
+		3: {5, 5},
+	}
+	labels, centroids := KMeans(features, 3, rand.NewSource(1), 0)
+	if len(centroids) != 3 {
+		t.Fatalf("unexpected number of centroids: got:%d want:3", len(centroids))
+	}
+	for id := int64(0); id < 3; id++ {
+		if labels[3] == labels[id] {
+			t.Errorf("distinct node given coincident node label: %v", labels)
+		}
+	}
+	if got := Inertia(features, labels, centroids); got != 0 {
+		t.Errorf("unexpected inertia: got:%v want:0", got)
+	}
+}
+
+func TestKMeansPanics(t *testing.T) {
+	features := map[int64][]float64{0: {0}, 1: {1}}
+	for _, test := range []struct {
+		name     string
+		features map[int64][]float64
+		k        int
+	}{
+		{name: "zero k", features: features, k: 0},
+		{name: "large k", features: features, k: 3},
+		{name: "length mismatch", features: map[int64][]float64{0: {0}, 1: {1, 2}}, k: 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %s", test.name)
+				}
+			}()
+			KMeans(test.features, test.k, nil, 0)
+		}()
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dataflow
+
+import (
+	"container/heap"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/ssa"
+)
+
+// Fact is a value of a data-flow analysis lattice.
+type Fact interface {
+	// Equal returns whether the receiver and
+	// the parameter hold the same value.
+	Equal(Fact) bool
+}
+
+// Solve performs a forward data-flow analysis over the control flow graph g
+// and returns the fact holding on exit from each node reachable from the root
+// of the dominator tree dt, keyed by node ID. The dominator tree must have been
+// constructed from g.
+//
+// The fact on entry to a node is the meet of the exit facts of its predecessors
+// that have been evaluated, and the exit fact of the node is transfer applied to
+// the node and its entry fact. A node other than the root is not evaluated until
+// at least one of its predecessors has been. The root is the entry of the control
+// flow graph, so its entry fact is always nil and edges into the root are ignored.
+// Predecessors that are not reachable from the root are also ignored.
+//
+// Nodes are evaluated from a worklist in preorder of the dominator tree, with
+// nodes immediately dominated by the same node ordered by ascending ID, so that
+// a node is evaluated after its dominators. Evaluation continues until no exit
+// fact changes. Solve is guaranteed to terminate only if transfer and meet are
+// monotone over a lattice of finite height.
+func Solve(dt path.DominatorTree, g graph.Directed, transfer func(n graph.Node, in Fact) Fact, meet func(a, b Fact) Fact) map[int64]Fact {
+	var order []graph.Node
+	ssa.RenameWalk(dt, func(n graph.Node) {
+		order = append(order, n)
+	}, nil)
+	if len(order) == 0 {
+		return nil
+	}
+	indexOf := make(map[int64]int, len(order))
+	for i, n := range order {
+		indexOf[n.ID()] = i
+	}
+
+	out := make(map[int64]Fact, len(order))
+	work := worklist{queued: make([]bool, len(order))}
+	for i := range order {
+		work.add(i)
+	}
+	for work.Len() != 0 {
+		n := order[heap.Pop(&work).(int)]
+		id := n.ID()
+
+		var in Fact
+		isRoot := id == order[0].ID()
+		evaluated := false
+		to := g.To(id)
+		for !isRoot && to.Next() {
+			f, ok := out[to.Node().ID()]
+			if !ok {
+				continue
+			}
+			if evaluated {
+				in = meet(in, f)
+			} else {
+				in = f
+				evaluated = true
+			}
+		}
+
+		if !isRoot && !evaluated {
+			// The node will be queued again when
+			// one of its predecessors is evaluated.
+			continue
+		}
+
+		f := transfer(n, in)
+		if old, ok := out[id]; ok && f.Equal(old) {
+			continue
+		}
+		out[id] = f
+		from := g.From(id)
+		for from.Next() {
+			if i, ok := indexOf[from.Node().ID()]; ok {
+				work.add(i)
+			}
+		}
+	}
+	return out
+}
+
+// worklist is a priority queue of node preorder
+// indexes that holds each index at most once.
+type worklist struct {
+	indexes []int
+	queued  []bool
+}
+
+func (q *worklist) add(i int) {
+	if q.queued[i] {
+		return
+	}
+	q.queued[i] = true
+	heap.Push(q, i)
+}
+
+func (q *worklist) Less(i, j int) bool { return q.indexes[i] < q.indexes[j] }
+func (q *worklist) Swap(i, j int)      { q.indexes[i], q.indexes[j] = q.indexes[j], q.indexes[i] }
+func (q *worklist) Len() int           { return len(q.indexes) }
+func (q *worklist) Push(x interface{}) { q.indexes = append(q.indexes, x.(int)) }
+func (q *worklist) Pop() interface{} {
+	i := q.indexes[len(q.indexes)-1]
+	q.indexes = q.indexes[:len(q.indexes)-1]
+	q.queued[i] = false
+	return i
+}
@@ -0,0 +1,217 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dataflow
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// constant is a constant propagation lattice value. A variable
+// absent from an environment has not yet been assigned.
+type constant struct {
+	notConst bool
+	value    int
+}
+
+func (c constant) String() string {
+	if c.notConst {
+		return "NAC"
+	}
+	return fmt.Sprint(c.value)
+}
+
+// env is a constant propagation fact.
+type env map[string]constant
+
+func (e env) Equal(f Fact) bool {
+	o := f.(env)
+	if len(e) != len(o) {
+		return false
+	}
+	for v, c := range e {
+		if oc, ok := o[v]; !ok || oc != c {
+			return false
+		}
+	}
+	return true
+}
+
+func meetEnv(a, b Fact) Fact {
+	m := make(env)
+	for v, c := range a.(env) {
+		m[v] = c
+	}
+	for v, c := range b.(env) {
+		if mc, ok := m[v]; ok && mc != c {
+			c = constant{notConst: true}
+		}
+		m[v] = c
+	}
+	return m
+}
+
+// assign is a statement assigning the result of op to v.
+type assign struct {
+	v  string
+	op func(env) constant
+}
+
+func lit(v int) func(env) constant {
+	return func(env) constant { return constant{value: v} }
+}
+
+func add(v string, c int) func(env) constant {
+	return func(e env) constant {
+		x, ok := e[v]
+		if !ok || x.notConst {
+			return constant{notConst: true}
+		}
+		return constant{value: x.value + c}
+	}
+}
+
+func TestSolveConstantPropagation(t *testing.T) {
+	// The program is:
+	//
+	//  0: x = 1; y = 2; i = 0
+	//  1: if ... goto 3
+	//  2: y = 3; goto 4
+	//  3: x = 1; y = 4
+	//  4: z = x + 1
+	//  5: i = i + 1
+	//  6: if ... goto 5
+	//  7: w = z + 1
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {5, 6}, {6, 5}, {6, 7},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	code := map[int64][]assign{
+		0: {{"x", lit(1)}, {"y", lit(2)}, {"i", lit(0)}},
+		2: {{"y", lit(3)}},
+		3: {{"x", lit(1)}, {"y", lit(4)}},
+		4: {{"z", add("x", 1)}},
+		5: {{"i", add("i", 1)}},
+		7: {{"w", add("z", 1)}},
+	}
+	transfer := func(n graph.Node, in Fact) Fact {
+		out := make(env)
+		if in != nil {
+			for v, c := range in.(env) {
+				out[v] = c
+			}
+		}
+		for _, s := range code[n.ID()] {
+			out[s.v] = s.op(out)
+		}
+		return out
+	}
+
+	got := Solve(path.Dominators(simple.Node(0), g), g, transfer, meetEnv)
+	want := map[int64]string{
+		0: "map[i:0 x:1 y:2]",
+		1: "map[i:0 x:1 y:2]",
+		2: "map[i:0 x:1 y:3]",
+		3: "map[i:0 x:1 y:4]",
+		4: "map[i:0 x:1 y:NAC z:2]",
+		5: "map[i:NAC x:1 y:NAC z:2]",
+		6: "map[i:NAC x:1 y:NAC z:2]",
+		7: "map[i:NAC w:3 x:1 y:NAC z:2]",
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected number of facts: got:%d want:%d", len(got), len(want))
+	}
+	for id, w := range want {
+		if s := fmt.Sprint(got[id]); s != w {
+			t.Errorf("unexpected fact for node %d: got:%s want:%s", id, s, w)
+		}
+	}
+}
+
+// ids is a set of node IDs fact.
+type ids set.Int64s
+
+func (s ids) Equal(f Fact) bool { return set.Int64sEqual(set.Int64s(s), set.Int64s(f.(ids))) }
+
+func TestSolveDominators(t *testing.T) {
+	// Computing the nodes that lie on every path from the
+	// root is a data-flow problem whose solution must agree
+	// with the dominator tree.
+	transfer := func(n graph.Node, in Fact) Fact {
+		out := make(ids)
+		if in != nil {
+			for id := range in.(ids) {
+				out[id] = struct{}{}
+			}
+		}
+		out[n.ID()] = struct{}{}
+		return out
+	}
+	meet := func(a, b Fact) Fact {
+		m := make(ids)
+		for id := range a.(ids) {
+			if _, ok := b.(ids)[id]; ok {
+				m[id] = struct{}{}
+			}
+		}
+		return m
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 1 + rnd.Intn(20)
+		g := simple.NewDirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 2/float64(n) {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		dt := path.Dominators(simple.Node(0), g)
+		got := Solve(dt, g, transfer, meet)
+
+		for id, f := range got {
+			var gotDoms, wantDoms []int64
+			for d := range f.(ids) {
+				gotDoms = append(gotDoms, d)
+			}
+			for d := g.Node(id); d != nil; d = dt.DominatorOf(d.ID()) {
+				wantDoms = append(wantDoms, d.ID())
+			}
+			sort.Sort(ordered.Int64s(gotDoms))
+			sort.Sort(ordered.Int64s(wantDoms))
+			if fmt.Sprint(gotDoms) != fmt.Sprint(wantDoms) {
+				t.Errorf("unexpected dominators of %d in test %d: got:%v want:%v", id, i, gotDoms, wantDoms)
+			}
+		}
+		var reachable int
+		var count func(graph.Node)
+		count = func(n graph.Node) {
+			reachable++
+			for _, c := range dt.DominatedBy(n.ID()) {
+				count(c)
+			}
+		}
+		count(dt.Root())
+		if len(got) != reachable {
+			t.Errorf("unexpected number of facts in test %d: got:%d want:%d", i, len(got), reachable)
+		}
+	}
+}
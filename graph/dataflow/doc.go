@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dataflow provides an iterative data-flow analysis solver for
+// control flow graphs.
+package dataflow // import "gonum.org/v1/gonum/graph/dataflow"
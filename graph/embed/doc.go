@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package embed provides routines for generating the training data used
+// by graph embedding methods.
+package embed // import "gonum.org/v1/gonum/graph/embed"
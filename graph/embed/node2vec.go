@@ -0,0 +1,140 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package embed
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Node2VecWalks returns a corpus of biased second-order random walks on g
+// as described by Grover and Leskovec in "node2vec: Scalable Feature
+// Learning for Networks" (2016). The corpus may be used to train skip-gram
+// node embeddings.
+//
+// numWalks walks are started from each node of g, with the nodes visited in
+// order of ascending ID in each round. Each walk holds at most walkLength
+// nodes and ends early if it reaches a node with no out-going edges.
+//
+// The first step of a walk from a node is taken to a neighbor with
+// probability proportional to the weight of the edge to the neighbor. After
+// stepping from t to v, the probability of stepping from v to x is
+// proportional to the weight of the edge from v to x multiplied by 1/p if x
+// is t, by 1 if there is an edge from t to x and by 1/q otherwise. The return
+// parameter p controls the likelihood of immediately revisiting a node and
+// the in-out parameter q biases walks toward (q > 1) or away from (q < 1)
+// the neighborhood of the previous node.
+//
+// If src is not nil it is used as the random source, otherwise rand.Float64
+// is used. For a given src the corpus is deterministic.
+//
+// Node2VecWalks will panic if p or q is not positive or if g has a negative
+// edge weight.
+func Node2VecWalks(g graph.Weighted, numWalks, walkLength int, p, q float64, src rand.Source) [][]graph.Node {
+	if p <= 0 || q <= 0 {
+		panic("embed: non-positive node2vec parameter")
+	}
+	if numWalks <= 0 || walkLength <= 0 {
+		return nil
+	}
+
+	var rnd func() float64
+	if src == nil {
+		rnd = rand.Float64
+	} else {
+		rnd = rand.New(src).Float64
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	// Cache the sorted neighbors of each node so that
+	// steps are independent of iteration order.
+	neighbors := make(map[int64][]graph.Node)
+	from := func(u graph.Node) []graph.Node {
+		uid := u.ID()
+		to, ok := neighbors[uid]
+		if !ok {
+			to = graph.NodesOf(g.From(uid))
+			sort.Sort(ordered.ByID(to))
+			neighbors[uid] = to
+		}
+		return to
+	}
+
+	var weights []float64
+	walks := make([][]graph.Node, 0, numWalks*len(nodes))
+	for i := 0; i < numWalks; i++ {
+		for _, start := range nodes {
+			walk := make([]graph.Node, 1, walkLength)
+			walk[0] = start
+			for len(walk) < walkLength {
+				v := walk[len(walk)-1]
+				to := from(v)
+				if len(to) == 0 {
+					break
+				}
+
+				weights = weights[:0]
+				for _, x := range to {
+					w, ok := g.Weight(v.ID(), x.ID())
+					if !ok {
+						panic("embed: unexpected invalid weight")
+					}
+					if w < 0 {
+						panic("embed: negative edge weight")
+					}
+					if len(walk) > 1 {
+						t := walk[len(walk)-2]
+						switch {
+						case x.ID() == t.ID():
+							w /= p
+						case g.Edge(t.ID(), x.ID()) != nil:
+						default:
+							w /= q
+						}
+					}
+					weights = append(weights, w)
+				}
+				next := choose(weights, rnd)
+				if next < 0 {
+					break
+				}
+				walk = append(walk, to[next])
+			}
+			walks = append(walks, walk)
+		}
+	}
+	return walks
+}
+
+// choose returns an index into weights chosen with probability proportional
+// to the weight at the index. If all weights are zero, choose returns -1.
+func choose(weights []float64, rnd func() float64) int {
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	if sum == 0 {
+		return -1
+	}
+	r := rnd() * sum
+	last := -1
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		last = i
+		r -= w
+		if r < 0 {
+			return i
+		}
+	}
+	return last
+}
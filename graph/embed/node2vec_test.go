@@ -0,0 +1,162 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package embed
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNode2VecWalks(t *testing.T) {
+	u := simple.NewUndirectedGraph()
+	gen.Gnp(u, 50, 0.1, rand.NewSource(1))
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range graph.EdgesOf(u.Edges()) {
+		g.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: float64(1 + (e.From().ID()+e.To().ID())%3)})
+	}
+	for _, n := range graph.NodesOf(u.Nodes()) {
+		if g.Node(n.ID()) == nil {
+			g.AddNode(n)
+		}
+	}
+
+	const (
+		numWalks   = 3
+		walkLength = 10
+	)
+	walks := Node2VecWalks(g, numWalks, walkLength, 0.5, 2, rand.NewSource(1))
+	n := g.Nodes().Len()
+	if len(walks) != numWalks*n {
+		t.Fatalf("unexpected number of walks: got:%d want:%d", len(walks), numWalks*n)
+	}
+	for i, w := range walks {
+		if want := int64(i % n); w[0].ID() != want {
+			t.Errorf("unexpected start of walk %d: got:%d want:%d", i, w[0].ID(), want)
+		}
+		if g.From(w[0].ID()).Len() == 0 {
+			if len(w) != 1 {
+				t.Errorf("unexpected length of walk from isolated node %d: got:%d want:1", w[0].ID(), len(w))
+			}
+			continue
+		}
+		if len(w) != walkLength {
+			t.Errorf("unexpected length of walk %d: got:%d want:%d", i, len(w), walkLength)
+		}
+		for j := 1; j < len(w); j++ {
+			if g.Edge(w[j-1].ID(), w[j].ID()) == nil {
+				t.Errorf("walk %d steps along non-existent edge %d--%d", i, w[j-1].ID(), w[j].ID())
+			}
+		}
+	}
+
+	again := Node2VecWalks(g, numWalks, walkLength, 0.5, 2, rand.NewSource(1))
+	if !reflect.DeepEqual(walks, again) {
+		t.Error("walks differ for the same source")
+	}
+}
+
+func TestNode2VecWalksBias(t *testing.T) {
+	// The walk 0 -> 1 may return to 0, move to 2 which is
+	// adjacent to 0 or move away from 0 to 3.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		p, q float64
+	}{
+		{p: 1, q: 1},
+		{p: 0.5, q: 2},
+		{p: 4, q: 0.25},
+	} {
+		walks := Node2VecWalks(g, 20000, 3, test.p, test.q, rand.NewSource(1))
+		counts := make(map[int64]float64)
+		var total float64
+		for _, w := range walks {
+			if w[0].ID() != 0 || w[1].ID() != 1 {
+				continue
+			}
+			counts[w[2].ID()]++
+			total++
+		}
+		sum := 1/test.p + 1 + 1/test.q
+		want := map[int64]float64{0: 1 / test.p / sum, 2: 1 / sum, 3: 1 / test.q / sum}
+		for id, p := range want {
+			if got := counts[id] / total; math.Abs(got-p) > 0.02 {
+				t.Errorf("unexpected step frequency to %d for p=%v q=%v: got:%.3f want:%.3f", id, test.p, test.q, got, p)
+			}
+		}
+	}
+}
+
+func TestNode2VecWalksWeights(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 3})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(3), W: 0})
+
+	walks := Node2VecWalks(g, 10000, 5, 1, 1, rand.NewSource(1))
+	counts := make(map[int64]float64)
+	var total float64
+	for _, w := range walks {
+		if w[0].ID() != 0 {
+			if len(w) != 1 {
+				t.Errorf("unexpected walk from sink: %v", w)
+			}
+			continue
+		}
+		// Each walk from 0 ends at a sink.
+		if len(w) != 2 {
+			t.Errorf("unexpected walk length from 0: got:%d want:2", len(w))
+			continue
+		}
+		counts[w[1].ID()]++
+		total++
+	}
+	want := map[int64]float64{1: 0.25, 2: 0.75, 3: 0}
+	for id, p := range want {
+		if got := counts[id] / total; math.Abs(got-p) > 0.02 {
+			t.Errorf("unexpected step frequency to %d: got:%.3f want:%.3f", id, got, p)
+		}
+	}
+}
+
+func TestNode2VecWalksPanics(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	neg := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	neg.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: -1})
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+		p, q float64
+	}{
+		{name: "zero p", g: g, p: 0, q: 1},
+		{name: "negative q", g: g, p: 1, q: -1},
+		{name: "negative weight", g: neg, p: 1, q: 1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %s", test.name)
+				}
+			}()
+			Node2VecWalks(test.g, 1, 3, test.p, test.q, nil)
+		}()
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binary
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/weights"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Version is the version of the binary format written by Encode.
+const Version = 1
+
+const (
+	directed = 1 << iota
+	weighted
+
+	knownFlags = directed | weighted
+)
+
+// maxPrealloc is the largest number of elements that will be allocated
+// on the basis of an encoded length before the elements have been read.
+// It guards against excessive allocation from corrupt input.
+const maxPrealloc = 1 << 16
+
+var (
+	// ErrVersion is returned by Decode when the encoded data
+	// was written with an unknown format version.
+	ErrVersion = errors.New("binary: unsupported format version")
+
+	errSelfEdge = errors.New("binary: self edges are not supported")
+)
+
+// Encode writes the binary encoding of g to w. Directed graphs are encoded
+// as directed and graph.Weighted graphs have their edge weights encoded.
+// Encode returns an error if g contains self edges.
+//
+// Only node IDs and edge weights are retained in the encoding.
+func Encode(w io.Writer, g graph.Graph) error {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	var flags byte
+	_, isDirected := g.(graph.Directed)
+	if isDirected {
+		flags |= directed
+	}
+	wg, isWeighted := g.(graph.Weighted)
+	if isWeighted {
+		flags |= weighted
+	}
+
+	e := encoder{w: bufio.NewWriter(w)}
+	e.writeByte(Version)
+	e.writeByte(flags)
+	e.writeUvarint(uint64(len(nodes)))
+	for i, n := range nodes {
+		if i == 0 {
+			e.writeVarint(n.ID())
+			continue
+		}
+		e.writeUvarint(uint64(n.ID()) - uint64(nodes[i-1].ID()) - 1)
+	}
+	if isWeighted {
+		self, absent := weights.Params(wg, nodes)
+		e.writeFloat(self)
+		e.writeFloat(absent)
+	}
+
+	var (
+		idx     []int
+		weights []float64
+	)
+	for i, u := range nodes {
+		uid := u.ID()
+		idx = idx[:0]
+		to := g.From(uid)
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			if j == i {
+				return errSelfEdge
+			}
+			if !isDirected && j < i {
+				continue
+			}
+			idx = append(idx, j)
+		}
+		sort.Ints(idx)
+
+		e.writeUvarint(uint64(len(idx)))
+		for k, j := range idx {
+			if k == 0 {
+				e.writeUvarint(uint64(j))
+				continue
+			}
+			e.writeUvarint(uint64(j - idx[k-1] - 1))
+		}
+		if isWeighted {
+			weights = weights[:0]
+			for _, j := range idx {
+				weights = append(weights, wg.WeightedEdge(uid, nodes[j].ID()).Weight())
+			}
+			for _, wt := range weights {
+				e.writeFloat(wt)
+			}
+		}
+		if e.err != nil {
+			return e.err
+		}
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// encoder is a sticky error binary writer.
+type encoder struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (e *encoder) writeByte(b byte) {
+	if e.err != nil {
+		return
+	}
+	e.err = e.w.WriteByte(b)
+}
+
+func (e *encoder) writeUvarint(v uint64) {
+	if e.err != nil {
+		return
+	}
+	n := binary.PutUvarint(e.buf[:], v)
+	_, e.err = e.w.Write(e.buf[:n])
+}
+
+func (e *encoder) writeVarint(v int64) {
+	if e.err != nil {
+		return
+	}
+	n := binary.PutVarint(e.buf[:], v)
+	_, e.err = e.w.Write(e.buf[:n])
+}
+
+func (e *encoder) writeFloat(f float64) {
+	if e.err != nil {
+		return
+	}
+	binary.LittleEndian.PutUint64(e.buf[:8], math.Float64bits(f))
+	_, e.err = e.w.Write(e.buf[:8])
+}
+
+// Decode reads a binary encoded graph from r. The returned graph is a
+// *simple.DirectedGraph, *simple.UndirectedGraph,
+// *simple.WeightedDirectedGraph or *simple.WeightedUndirectedGraph
+// depending on the encoded flags.
+//
+// If r does not implement io.ByteReader, Decode may read beyond the end of
+// the encoded graph.
+func Decode(r io.Reader) (graph.Graph, error) {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	d := decoder{r: br}
+
+	version := d.readByte()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if version != Version {
+		return nil, ErrVersion
+	}
+	flags := d.readByte()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if flags&^knownFlags != 0 {
+		return nil, fmt.Errorf("binary: unknown flags: %#x", flags)
+	}
+	isDirected := flags&directed != 0
+	isWeighted := flags&weighted != 0
+
+	n := d.readUvarint()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if n > math.MaxInt32 {
+		return nil, fmt.Errorf("binary: node count too large: %d", n)
+	}
+	ids := make([]int64, 0, min(int(n), maxPrealloc))
+	for i := 0; i < int(n); i++ {
+		if i == 0 {
+			ids = append(ids, d.readVarint())
+		} else {
+			gap := d.readUvarint()
+			prev := uint64(ids[i-1])
+			next := prev + gap + 1
+			if gap == math.MaxUint64 || int64(next) <= int64(prev) {
+				return nil, errors.New("binary: node IDs out of order")
+			}
+			ids = append(ids, int64(next))
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+	}
+
+	var (
+		dst  graph.Builder
+		wdst graph.WeightedBuilder
+	)
+	if isWeighted {
+		self := d.readFloat()
+		absent := d.readFloat()
+		if d.err != nil {
+			return nil, d.err
+		}
+		if isDirected {
+			wdst = simple.NewWeightedDirectedGraph(self, absent)
+		} else {
+			wdst = simple.NewWeightedUndirectedGraph(self, absent)
+		}
+		for _, id := range ids {
+			wdst.AddNode(simple.Node(id))
+		}
+	} else {
+		if isDirected {
+			dst = simple.NewDirectedGraph()
+		} else {
+			dst = simple.NewUndirectedGraph()
+		}
+		for _, id := range ids {
+			dst.AddNode(simple.Node(id))
+		}
+	}
+
+	var idx []int
+	for i, uid := range ids {
+		deg := d.readUvarint()
+		if d.err != nil {
+			return nil, d.err
+		}
+		if deg > n {
+			return nil, fmt.Errorf("binary: invalid degree for node %d: %d", uid, deg)
+		}
+		idx = idx[:0]
+		for k := 0; k < int(deg); k++ {
+			v := d.readUvarint()
+			if d.err != nil {
+				return nil, d.err
+			}
+			if k != 0 {
+				v += uint64(idx[k-1]) + 1
+			}
+			if v >= n || (k != 0 && v <= uint64(idx[k-1])) {
+				return nil, fmt.Errorf("binary: invalid neighbor index for node %d", uid)
+			}
+			j := int(v)
+			if j == i || (!isDirected && j < i) {
+				return nil, fmt.Errorf("binary: invalid neighbor index for node %d", uid)
+			}
+			idx = append(idx, j)
+		}
+		for _, j := range idx {
+			u := simple.Node(uid)
+			v := simple.Node(ids[j])
+			if isWeighted {
+				w := d.readFloat()
+				if d.err != nil {
+					return nil, d.err
+				}
+				wdst.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: w})
+			} else {
+				dst.SetEdge(simple.Edge{F: u, T: v})
+			}
+		}
+	}
+
+	if isWeighted {
+		return wdst.(graph.Graph), nil
+	}
+	return dst.(graph.Graph), nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// decoder is a sticky error binary reader.
+type decoder struct {
+	r   byteReader
+	buf [8]byte
+	err error
+}
+
+func (d *decoder) readByte() byte {
+	if d.err != nil {
+		return 0
+	}
+	var b byte
+	b, d.err = d.r.ReadByte()
+	d.checkEOF()
+	return b
+}
+
+func (d *decoder) readUvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	v, d.err = binary.ReadUvarint(d.r)
+	d.checkEOF()
+	return v
+}
+
+func (d *decoder) readVarint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	var v int64
+	v, d.err = binary.ReadVarint(d.r)
+	d.checkEOF()
+	return v
+}
+
+func (d *decoder) readFloat() float64 {
+	if d.err != nil {
+		return 0
+	}
+	_, d.err = io.ReadFull(d.r, d.buf[:])
+	d.checkEOF()
+	return math.Float64frombits(binary.LittleEndian.Uint64(d.buf[:]))
+}
+
+// checkEOF converts an io.EOF error into io.ErrUnexpectedEOF since
+// the decoder only reads when more data is required.
+func (d *decoder) checkEOF() {
+	if d.err == io.EOF {
+		d.err = io.ErrUnexpectedEOF
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
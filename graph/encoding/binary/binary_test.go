@@ -0,0 +1,240 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binary
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var roundTripTests = []struct {
+	name string
+	g    func() graph.Graph
+}{
+	{
+		name: "empty directed",
+		g:    func() graph.Graph { return simple.NewDirectedGraph() },
+	},
+	{
+		name: "empty weighted undirected",
+		g:    func() graph.Graph { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+	},
+	{
+		name: "sparse IDs",
+		g: func() graph.Graph {
+			g := simple.NewDirectedGraph()
+			g.AddNode(simple.Node(math.MinInt64))
+			g.AddNode(simple.Node(math.MaxInt64))
+			g.SetEdge(simple.Edge{F: simple.Node(-10), T: simple.Node(1 << 40)})
+			g.SetEdge(simple.Edge{F: simple.Node(1 << 40), T: simple.Node(-10)})
+			g.SetEdge(simple.Edge{F: simple.Node(math.MaxInt64), T: simple.Node(-10)})
+			return g
+		},
+	},
+	{
+		name: "weighted directed",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(1, 0)
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 0.5})
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(0), W: math.Inf(-1)})
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(5), W: math.NaN()})
+			g.AddNode(simple.Node(3))
+			return g
+		},
+	},
+	{
+		name: "gnp undirected",
+		g: func() graph.Graph {
+			g := simple.NewUndirectedGraph()
+			gen.Gnp(g, 100, 0.1, rand.NewSource(1))
+			return g
+		},
+	},
+	{
+		name: "gnp weighted undirected",
+		g: func() graph.Graph {
+			src := rand.NewSource(1)
+			u := simple.NewUndirectedGraph()
+			gen.Gnp(u, 100, 0.1, src)
+			rnd := rand.New(src)
+			g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+			for _, e := range graph.EdgesOf(u.Edges()) {
+				g.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: rnd.NormFloat64()})
+			}
+			return g
+		},
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range roundTripTests {
+		g := test.g()
+		var buf bytes.Buffer
+		err := Encode(&buf, g)
+		if err != nil {
+			t.Errorf("unexpected error encoding %q: %v", test.name, err)
+			continue
+		}
+		got, err := Decode(&buf)
+		if err != nil {
+			t.Errorf("unexpected error decoding %q: %v", test.name, err)
+			continue
+		}
+		if buf.Len() != 0 {
+			t.Errorf("unexpected unread data for %q: %d bytes", test.name, buf.Len())
+		}
+		if !equal(got, g) {
+			t.Errorf("round trip mismatch for %q", test.name)
+		}
+	}
+}
+
+func equal(a, b graph.Graph) bool {
+	_, aDirected := a.(graph.Directed)
+	_, bDirected := b.(graph.Directed)
+	aw, aWeighted := a.(graph.Weighted)
+	bw, bWeighted := b.(graph.Weighted)
+	if aDirected != bDirected || aWeighted != bWeighted {
+		return false
+	}
+
+	an := graph.NodesOf(a.Nodes())
+	bn := graph.NodesOf(b.Nodes())
+	if len(an) != len(bn) {
+		return false
+	}
+	sort.Sort(ordered.ByID(an))
+	sort.Sort(ordered.ByID(bn))
+	for i, u := range an {
+		if u.ID() != bn[i].ID() {
+			return false
+		}
+	}
+	for _, u := range an {
+		for _, v := range an {
+			uid, vid := u.ID(), v.ID()
+			if (a.Edge(uid, vid) == nil) != (b.Edge(uid, vid) == nil) {
+				return false
+			}
+			if aWeighted {
+				x, xok := aw.Weight(uid, vid)
+				y, yok := bw.Weight(uid, vid)
+				if xok != yok || !same(x, y) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func same(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func TestEncodeSize(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	gen.Gnp(g, 1000, 0.01, rand.NewSource(1))
+
+	var buf bytes.Buffer
+	err := Encode(&buf, g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, err := dot.Marshal(g, "", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len()*4 > len(d) {
+		t.Errorf("binary encoding not substantially smaller than DOT: binary=%d DOT=%d", buf.Len(), len(d))
+	}
+}
+
+func TestDecodeVersion(t *testing.T) {
+	_, err := Decode(bytes.NewReader([]byte{Version + 1, 0, 0}))
+	if err != ErrVersion {
+		t.Errorf("unexpected error for unknown version: got:%v want:%v", err, ErrVersion)
+	}
+}
+
+// TestDecodeCorrupt checks that Decode does not panic on truncated or
+// corrupted input.
+func TestDecodeCorrupt(t *testing.T) {
+	var valid [][]byte
+	for _, test := range roundTripTests {
+		var buf bytes.Buffer
+		err := Encode(&buf, test.g())
+		if err != nil {
+			t.Fatalf("unexpected error encoding %q: %v", test.name, err)
+		}
+		valid = append(valid, buf.Bytes())
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, data := range valid {
+		for i := 0; i < len(data); i++ {
+			decodeNoPanic(t, data[:i])
+			if len(data) > 1000 && i > 100 {
+				i += rnd.Intn(100)
+			}
+		}
+		for i := 0; i < 1000; i++ {
+			corrupt := append([]byte(nil), data...)
+			for j := 0; j <= rnd.Intn(4); j++ {
+				corrupt[rnd.Intn(len(corrupt))] = byte(rnd.Intn(256))
+			}
+			decodeNoPanic(t, corrupt)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, 1+rnd.Intn(64))
+		rnd.Read(data)
+		data[0] = Version
+		decodeNoPanic(t, data)
+	}
+}
+
+func decodeNoPanic(t *testing.T, data []byte) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			t.Errorf("unexpected panic decoding %v: %v", data, r)
+		}
+	}()
+	Decode(bytes.NewReader(data))
+}
+
+func BenchmarkEncode(b *testing.B) {
+	g := simple.NewDirectedGraph()
+	gen.Gnp(g, 1000, 0.01, rand.NewSource(1))
+	var buf bytes.Buffer
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		Encode(&buf, g)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	g := simple.NewDirectedGraph()
+	gen.Gnp(g, 1000, 0.01, rand.NewSource(1))
+	var buf bytes.Buffer
+	Encode(&buf, g)
+	data := buf.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Decode(bytes.NewReader(data))
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package binary implements a compact binary serialization of graphs.
+//
+// The encoding is intended for large graphs where text formats such as
+// DOT are prohibitively verbose. An encoded graph is laid out as follows,
+// where uvarint and varint are the variable length integer encodings
+// provided by the standard library encoding/binary package and float64
+// values are encoded as their IEEE 754 bits in little-endian byte order.
+//
+//  byte     format version, currently 1
+//  byte     flags: bit 0 is set for directed graphs, bit 1 for weighted graphs
+//  uvarint  number of nodes, n
+//  varint   smallest node ID
+//  uvarint  n-1 node ID gaps, each the difference between successive
+//           sorted IDs minus one
+//  float64  self weight (weighted graphs only)
+//  float64  absent weight (weighted graphs only)
+//
+// followed by the compressed sparse row adjacency of the graph, for each
+// node in ID order,
+//
+//  uvarint  number of neighbors, d
+//  uvarint  index of the first neighbor in the node ID table, followed by
+//           d-1 index gaps, each the difference between successive sorted
+//           indexes minus one
+//  float64  d edge weights (weighted graphs only)
+//
+// For undirected graphs only neighbors with an index greater than the node's
+// own index are recorded so that each edge is stored once.
+package binary // import "gonum.org/v1/gonum/graph/encoding/binary"
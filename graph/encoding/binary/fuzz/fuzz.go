@@ -0,0 +1,35 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build gofuzz
+
+package fuzz
+
+import (
+	"bytes"
+
+	"gonum.org/v1/gonum/graph/encoding/binary"
+)
+
+// Fuzz implements the fuzzing function required for go-fuzz.
+//
+// See documentation at https://github.com/dvyukov/go-fuzz.
+func Fuzz(data []byte) int {
+	g, err := binary.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+
+	// Check that a successfully decoded graph round-trips.
+	var buf bytes.Buffer
+	err = binary.Encode(&buf, g)
+	if err != nil {
+		panic("could not encode decoded graph")
+	}
+	_, err = binary.Decode(&buf)
+	if err != nil {
+		panic("could not decode re-encoded graph")
+	}
+	return 1
+}
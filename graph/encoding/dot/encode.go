@@ -199,9 +199,7 @@ func (p *simpleGraphPrinter) print(g graph.Graph, name string, needsIndent, isSu
 		}
 		p.newline()
 		p.writeNode(n)
-		if a, ok := n.(encoding.Attributer); ok {
-			p.writeAttributeList(a)
-		}
+		p.writeAttributeList(nodeAttributes(g, n))
 		p.buf.WriteByte(';')
 	}
 
@@ -278,9 +276,7 @@ func (p *simpleGraphPrinter) print(g graph.Graph, name string, needsIndent, isSu
 				}
 			}
 
-			if a, ok := g.Edge(nid, tid).(encoding.Attributer); ok {
-				p.writeAttributeList(a)
-			}
+			p.writeAttributeList(edgeAttributes(g, e, nid, tid))
 
 			p.buf.WriteByte(';')
 		}
@@ -352,8 +348,34 @@ func graphID(g interface{}, n graph.Node) string {
 	}
 }
 
-func (p *printer) writeAttributeList(a encoding.Attributer) {
-	attributes := a.Attributes()
+// nodeAttributes returns the attributes of n if it is an encoding.Attributer
+// followed by the attributes held for n by g if g is an encoding.AttributeStore.
+func nodeAttributes(g interface{}, n graph.Node) []encoding.Attribute {
+	var attributes []encoding.Attribute
+	if a, ok := n.(encoding.Attributer); ok {
+		attributes = a.Attributes()
+	}
+	if s, ok := g.(encoding.AttributeStore); ok {
+		attributes = append(attributes[:len(attributes):len(attributes)], s.NodeAttributes(n.ID())...)
+	}
+	return attributes
+}
+
+// edgeAttributes returns the attributes of e if it is an encoding.Attributer
+// followed by the attributes held for the edge from uid to vid by g if g is
+// an encoding.AttributeStore.
+func edgeAttributes(g, e interface{}, uid, vid int64) []encoding.Attribute {
+	var attributes []encoding.Attribute
+	if a, ok := e.(encoding.Attributer); ok {
+		attributes = a.Attributes()
+	}
+	if s, ok := g.(encoding.AttributeStore); ok {
+		attributes = append(attributes[:len(attributes):len(attributes)], s.EdgeAttributes(uid, vid)...)
+	}
+	return attributes
+}
+
+func (p *printer) writeAttributeList(attributes []encoding.Attribute) {
 	switch len(attributes) {
 	case 0:
 	case 1:
@@ -493,9 +515,7 @@ func (p *multiGraphPrinter) print(g graph.Multigraph, name string, needsIndent,
 		}
 		p.newline()
 		p.writeNode(n)
-		if a, ok := n.(encoding.Attributer); ok {
-			p.writeAttributeList(a)
-		}
+		p.writeAttributeList(nodeAttributes(g, n))
 		p.buf.WriteByte(';')
 	}
 
@@ -571,9 +591,7 @@ func (p *multiGraphPrinter) print(g graph.Multigraph, name string, needsIndent,
 					}
 				}
 
-				if a, ok := l.(encoding.Attributer); ok {
-					p.writeAttributeList(a)
-				}
+				p.writeAttributeList(edgeAttributes(g, l, nid, tid))
 
 				p.buf.WriteByte(';')
 			}
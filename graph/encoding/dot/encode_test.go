@@ -325,6 +325,20 @@ func (g graphAttributer) DOTAttributers() (graph, node, edge encoding.Attributer
 	return g.graph, g.node, g.edge
 }
 
+type attributeStoreGraph struct {
+	graph.Graph
+	nodes map[int64][]encoding.Attribute
+	edges map[[2]int64][]encoding.Attribute
+}
+
+func (g attributeStoreGraph) NodeAttributes(id int64) []encoding.Attribute {
+	return g.nodes[id]
+}
+
+func (g attributeStoreGraph) EdgeAttributes(uid, vid int64) []encoding.Attribute {
+	return g.edges[[2]int64{uid, vid}]
+}
+
 type structuredGraph struct {
 	*simple.UndirectedGraph
 	sub []Graph
@@ -698,6 +712,43 @@ var encodeTests = []struct {
 # }`,
 	},
 
+	// Handling attribute stores.
+	{
+		g: attributeStoreGraph{
+			Graph: undirectedNodeAttrGraphFrom(powerMethodGraph, [][]encoding.Attribute{
+				2: {{Key: "fontsize", Value: "16"}},
+			}),
+			nodes: map[int64][]encoding.Attribute{
+				2: {{Key: "label", Value: "mid"}},
+				4: {{Key: "shape", Value: "box"}},
+			},
+			edges: map[[2]int64][]encoding.Attribute{
+				{0, 1}: {{Key: "color", Value: "red"}},
+			},
+		},
+
+		want: `strict graph {
+	// Node definitions.
+	0;
+	1;
+	2 [
+		fontsize=16
+		label=mid
+	];
+	3;
+	4 [shape=box];
+
+	// Edge definitions.
+	0 -- 1 [color=red];
+	0 -- 2;
+	0 -- 4;
+	1 -- 3;
+	2 -- 3;
+	2 -- 4;
+	3 -- 4;
+}`,
+	},
+
 	// Handling nodes with attributes.
 	{
 		g: directedNodeAttrGraphFrom(powerMethodGraph, nil),
@@ -34,3 +34,17 @@ type Attributer interface {
 type Attribute struct {
 	Key, Value string
 }
+
+// AttributeStore defines graph.Graph values that hold node and edge
+// attributes separately from the graph's nodes and edges. Encoders
+// include the attributes returned by an AttributeStore after any
+// attributes provided by the nodes and edges themselves.
+type AttributeStore interface {
+	// NodeAttributes returns the attributes for
+	// the node with the given ID.
+	NodeAttributes(id int64) []Attribute
+
+	// EdgeAttributes returns the attributes for
+	// the edge from u to v with IDs uid and vid.
+	EdgeAttributes(uid, vid int64) []Attribute
+}
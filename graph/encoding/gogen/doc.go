@@ -0,0 +1,11 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gogen implements Go source code generation for graphs.
+//
+// The generated source reconstructs a graph using the graph types
+// provided by the gonum.org/v1/gonum/graph/simple package, allowing
+// a fixed graph to be embedded in a program without depending on a
+// runtime data file.
+package gogen // import "gonum.org/v1/gonum/graph/encoding/gogen"
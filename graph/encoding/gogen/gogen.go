@@ -0,0 +1,164 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/weights"
+)
+
+// Fprint writes Go source code to w that declares a package level variable,
+// varName, in the package pkg holding a graph equal to g. The generated graph
+// is one of the graph types provided by the simple package, chosen according
+// to whether g is directed or undirected, and whether g is weighted.
+//
+// Node IDs and edge weights are preserved, but the dynamic types of nodes and
+// edges are not; nodes are encoded as simple.Node and edges as simple.Edge or
+// simple.WeightedEdge. For weighted graphs, the self and absent weights of the
+// generated graph are obtained by querying g.
+//
+// Fprint returns an error if pkg or varName are not valid Go identifiers, if
+// varName clashes with the names of the packages imported by the generated
+// code, or if g contains a self edge, since these are not supported by the
+// simple graphs.
+func Fprint(w io.Writer, pkg, varName string, g graph.Graph) error {
+	if !isIdentifier(pkg) {
+		return fmt.Errorf("gogen: invalid package name: %q", pkg)
+	}
+	if !isIdentifier(varName) {
+		return fmt.Errorf("gogen: invalid variable name: %q", varName)
+	}
+	if varName == "simple" || varName == "math" {
+		return fmt.Errorf("gogen: variable name clashes with import: %q", varName)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	_, isDirected := g.(graph.Directed)
+	wg, isWeighted := g.(graph.Weighted)
+	var p printer
+
+	var typ, constructor string
+	switch {
+	case isDirected && isWeighted:
+		typ = "WeightedDirectedGraph"
+	case isDirected:
+		typ = "DirectedGraph"
+	case isWeighted:
+		typ = "WeightedUndirectedGraph"
+	default:
+		typ = "UndirectedGraph"
+	}
+	if isWeighted {
+		self, absent := weights.Params(wg, nodes)
+		constructor = fmt.Sprintf("simple.New%s(%s, %s)", typ, p.float(self), p.float(absent))
+	} else {
+		constructor = fmt.Sprintf("simple.New%s()", typ)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "var %s = func() *simple.%s {\n", varName, typ)
+	fmt.Fprintf(&body, "g := %s\n", constructor)
+	if len(nodes) != 0 {
+		body.WriteString("for _, id := range []int64{")
+		for i, n := range nodes {
+			if i != 0 {
+				body.WriteString(", ")
+			}
+			fmt.Fprint(&body, n.ID())
+		}
+		body.WriteString("} {\ng.AddNode(simple.Node(id))\n}\n")
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if vid == uid {
+				return fmt.Errorf("gogen: self edge for node %d", uid)
+			}
+			if !isDirected && vid < uid {
+				// Only emit one of the two edge directions
+				// for undirected graphs.
+				continue
+			}
+			if isWeighted {
+				weight := wg.WeightedEdge(uid, vid).Weight()
+				fmt.Fprintf(&body, "g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(%d), T: simple.Node(%d), W: %s})\n", uid, vid, p.float(weight))
+			} else {
+				fmt.Fprintf(&body, "g.SetEdge(simple.Edge{F: simple.Node(%d), T: simple.Node(%d)})\n", uid, vid)
+			}
+		}
+	}
+	body.WriteString("return g\n}()\n")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gonum.org/v1/gonum/graph/encoding/gogen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	if p.usesMath {
+		buf.WriteString("import (\n\"math\"\n\n\"gonum.org/v1/gonum/graph/simple\"\n)\n\n")
+	} else {
+		buf.WriteString("import \"gonum.org/v1/gonum/graph/simple\"\n\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		// This should never happen.
+		return errors.New("gogen: failed to format generated source: " + err.Error())
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// isIdentifier returns whether s is a valid Go identifier.
+func isIdentifier(s string) bool {
+	if s == "" || token.Lookup(s).IsKeyword() {
+		return false
+	}
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// printer records whether the generated code refers to the math package.
+type printer struct {
+	usesMath bool
+}
+
+// float returns a Go expression for the float64 value f.
+func (p *printer) float(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		p.usesMath = true
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		p.usesMath = true
+		return "math.Inf(-1)"
+	case math.IsNaN(f):
+		p.usesMath = true
+		return "math.NaN()"
+	case f == 0 && math.Signbit(f):
+		p.usesMath = true
+		return "math.Copysign(0, -1)"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,207 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogen_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding/gogen"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func weightedDirectedSource() *simple.WeightedDirectedGraph {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(-3))
+	g.AddNode(simple.Node(10))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1.5},
+		{F: simple.Node(1), T: simple.Node(0), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: -0.25},
+		{F: simple.Node(2), T: simple.Node(4), W: 1e10},
+		{F: simple.Node(4), T: simple.Node(0), W: math.Inf(1)},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	return g
+}
+
+// TestFprintCompiles checks that the output of Fprint is identical to the
+// compiled source in weighted_directed_generated_test.go and that the graph
+// it constructs is equal to the source graph.
+func TestFprintCompiles(t *testing.T) {
+	g := weightedDirectedSource()
+
+	var buf bytes.Buffer
+	err := gogen.Fprint(&buf, "gogen_test", "weightedDirected", g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := ioutil.ReadFile("weighted_directed_generated_test.go")
+	if err != nil {
+		t.Fatalf("failed to read generated source: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("unexpected generated source:\ngot:\n%s\nwant:\n%s", buf.Bytes(), want)
+	}
+
+	if !equalWeighted(weightedDirected, g) {
+		t.Error("generated graph does not match source graph")
+	}
+}
+
+func equalWeighted(a, b graph.Weighted) bool {
+	an := graph.NodesOf(a.Nodes())
+	bn := graph.NodesOf(b.Nodes())
+	if len(an) != len(bn) {
+		return false
+	}
+	sort.Sort(ordered.ByID(an))
+	sort.Sort(ordered.ByID(bn))
+	for i, u := range an {
+		if u.ID() != bn[i].ID() {
+			return false
+		}
+	}
+	for _, u := range an {
+		for _, v := range an {
+			aw, aok := a.Weight(u.ID(), v.ID())
+			bw, bok := b.Weight(u.ID(), v.ID())
+			if aok != bok || (aw != bw && !(math.IsNaN(aw) && math.IsNaN(bw))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var fprintTests = []struct {
+	name    string
+	pkg     string
+	varName string
+	g       func() graph.Graph
+	want    string
+	wantErr bool
+}{
+	{
+		name:    "empty",
+		pkg:     "p",
+		varName: "G",
+		g:       func() graph.Graph { return simple.NewDirectedGraph() },
+		want: `// Code generated by gonum.org/v1/gonum/graph/encoding/gogen. DO NOT EDIT.
+
+package p
+
+import "gonum.org/v1/gonum/graph/simple"
+
+var G = func() *simple.DirectedGraph {
+	g := simple.NewDirectedGraph()
+	return g
+}()
+`,
+	},
+	{
+		name:    "undirected",
+		pkg:     "p",
+		varName: "G",
+		g: func() graph.Graph {
+			g := simple.NewUndirectedGraph()
+			g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1)})
+			g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(3)})
+			return g
+		},
+		want: `// Code generated by gonum.org/v1/gonum/graph/encoding/gogen. DO NOT EDIT.
+
+package p
+
+import "gonum.org/v1/gonum/graph/simple"
+
+var G = func() *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for _, id := range []int64{1, 2, 3} {
+		g.AddNode(simple.Node(id))
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(3)})
+	return g
+}()
+`,
+	},
+	{
+		name:    "weighted undirected",
+		pkg:     "p",
+		varName: "G",
+		g: func() graph.Graph {
+			g := simple.NewWeightedUndirectedGraph(1, 0)
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(0), W: 0.5})
+			return g
+		},
+		want: `// Code generated by gonum.org/v1/gonum/graph/encoding/gogen. DO NOT EDIT.
+
+package p
+
+import "gonum.org/v1/gonum/graph/simple"
+
+var G = func() *simple.WeightedUndirectedGraph {
+	g := simple.NewWeightedUndirectedGraph(1, 0)
+	for _, id := range []int64{0, 1} {
+		g.AddNode(simple.Node(id))
+	}
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 0.5})
+	return g
+}()
+`,
+	},
+	{
+		name:    "bad package",
+		pkg:     "a b",
+		varName: "G",
+		g:       func() graph.Graph { return simple.NewDirectedGraph() },
+		wantErr: true,
+	},
+	{
+		name:    "bad variable",
+		pkg:     "p",
+		varName: "0G",
+		g:       func() graph.Graph { return simple.NewDirectedGraph() },
+		wantErr: true,
+	},
+	{
+		name:    "keyword variable",
+		pkg:     "p",
+		varName: "func",
+		g:       func() graph.Graph { return simple.NewDirectedGraph() },
+		wantErr: true,
+	},
+	{
+		name:    "import clash",
+		pkg:     "p",
+		varName: "math",
+		g:       func() graph.Graph { return simple.NewDirectedGraph() },
+		wantErr: true,
+	},
+}
+
+func TestFprint(t *testing.T) {
+	for _, test := range fprintTests {
+		var buf bytes.Buffer
+		err := gogen.Fprint(&buf, test.pkg, test.varName, test.g())
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error status for %q: got:%v want error:%t", test.name, err, test.wantErr)
+			continue
+		}
+		if test.wantErr {
+			continue
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("unexpected result for %q:\ngot:\n%s\nwant:\n%s", test.name, got, test.want)
+		}
+	}
+}
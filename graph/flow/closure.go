@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// MaximumClosure returns a closed subset of the nodes of g with the maximum
+// total profit, and that profit. A set of nodes is closed if for every node u
+// in the set, each node v with an edge from u to v is also in the set. This
+// is the project selection problem, where an edge from u to v indicates that
+// project u requires project v, and a negative profit is a cost.
+//
+// The closure is found from a minimum s-t cut of the network with an arc from
+// the source terminal to each node with positive profit with that profit as
+// its capacity, an arc from each node with negative profit to the sink
+// terminal with the negated profit as its capacity and an arc of infinite
+// capacity for each edge of g, following Picard doi:10.1287/mnsc.22.11.1268.
+// The maximum profit is the sum of the positive profits less the capacity of
+// the cut. When more than one closure has the maximum profit, the closure with
+// the fewest nodes is returned. The selected nodes are ordered by ID.
+func MaximumClosure(g graph.Directed, profit func(graph.Node) float64) (selected []graph.Node, value float64) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	s, t := n, n+1
+	r := newResidual(n + 2)
+	for i, u := range nodes {
+		switch p := profit(u); {
+		case p > 0:
+			r.addArc(s, i, p)
+			value += p
+		case p < 0:
+			r.addArc(i, t, -p)
+		}
+		to := g.From(u.ID())
+		for to.Next() {
+			r.addArc(i, indexOf[to.Node().ID()], math.Inf(1))
+		}
+	}
+	value -= r.maxFlow(s, t)
+
+	for i, ok := range r.reachable(s)[:n] {
+		if ok {
+			selected = append(selected, nodes[i])
+		}
+	}
+	return selected, value
+}
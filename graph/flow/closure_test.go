@@ -0,0 +1,108 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMaximumClosure(t *testing.T) {
+	// Projects 0 and 1 both require the costly tool 2, which is
+	// only worth buying for both. Project 3 requires tool 4, which
+	// costs more than the project returns.
+	g := simple.NewDirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(2)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(3), T: simple.Node(4)},
+	} {
+		g.SetEdge(e)
+	}
+	profits := map[int64]float64{0: 4, 1: 3, 2: -6, 3: 2, 4: -5}
+	selected, value := MaximumClosure(g, func(n graph.Node) float64 { return profits[n.ID()] })
+	if got, want := fmt.Sprint(ids(selected)), "[0 1 2]"; got != want {
+		t.Errorf("unexpected selection: got:%s want:%s", got, want)
+	}
+	if value != 1 {
+		t.Errorf("unexpected value: got:%v want:1", value)
+	}
+
+	// With a zero total both the empty set and {0, 1, 2}
+	// are optimal and the smaller is returned.
+	profits[1] = 2
+	selected, value = MaximumClosure(g, func(n graph.Node) float64 { return profits[n.ID()] })
+	if len(selected) != 0 || value != 0 {
+		t.Errorf("unexpected tied selection: got:%v value:%v want:[] value:0", ids(selected), value)
+	}
+}
+
+func TestMaximumClosureRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(9)
+		g := simple.NewDirectedGraph()
+		profits := make(map[int64]float64)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+			profits[int64(u)] = float64(rnd.Intn(11) - 5)
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.2 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		profit := func(n graph.Node) float64 { return profits[n.ID()] }
+
+		want := math.Inf(-1)
+		for set := 0; set < 1<<uint(n); set++ {
+			closed := true
+			var p float64
+			for u := 0; u < n; u++ {
+				if set&(1<<uint(u)) == 0 {
+					continue
+				}
+				p += profits[int64(u)]
+				for _, v := range graph.NodesOf(g.From(int64(u))) {
+					if set&(1<<uint(v.ID())) == 0 {
+						closed = false
+					}
+				}
+			}
+			if closed {
+				want = math.Max(want, p)
+			}
+		}
+
+		selected, value := MaximumClosure(g, profit)
+		if value != want {
+			t.Errorf("unexpected value for test %d: got:%v want:%v", i, value, want)
+		}
+		in := make(map[int64]bool)
+		var p float64
+		for _, u := range selected {
+			in[u.ID()] = true
+			p += profit(u)
+		}
+		if p != value {
+			t.Errorf("selection profit does not match value for test %d: got:%v want:%v", i, p, value)
+		}
+		for _, u := range selected {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				if !in[v.ID()] {
+					t.Errorf("selection for test %d is not closed: %d→%d", i, u.ID(), v.ID())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMaxDensitySubgraph(t *testing.T) {
+	// A complete graph on 0-3 with a path 3-4-5-6 hanging
+	// from it. The clique has density 6/4.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3},
+		{3, 4}, {4, 5}, {5, 6},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	nodes, density := MaxDensitySubgraph(g)
+	if got, want := fmt.Sprint(ids(nodes)), "[0 1 2 3]"; got != want {
+		t.Errorf("unexpected nodes: got:%s want:%s", got, want)
+	}
+	if density != 1.5 {
+		t.Errorf("unexpected density: got:%v want:1.5", density)
+	}
+
+	nodes, density = MaxDensitySubgraph(simple.NewUndirectedGraph())
+	if nodes != nil || density != 0 {
+		t.Errorf("unexpected result for empty graph: got:%v density:%v", ids(nodes), density)
+	}
+}
+
+func TestMaxDensitySubgraphRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 200; test++ {
+		n := 2 + rnd.Intn(9)
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		var want float64
+		for set := 1; set < 1<<uint(n); set++ {
+			if d := inducedDensity(g, func(id int64) bool { return set&(1<<uint(id)) != 0 }); d > want {
+				want = d
+			}
+		}
+
+		nodes, density := MaxDensitySubgraph(g)
+		if density != want {
+			t.Errorf("unexpected density for test %d: got:%v want:%v", test, density, want)
+		}
+		if want == 0 {
+			continue
+		}
+		in := make(map[int64]bool)
+		for _, u := range nodes {
+			in[u.ID()] = true
+		}
+		if d := inducedDensity(g, func(id int64) bool { return in[id] }); d != density {
+			t.Errorf("subgraph density does not match for test %d: got:%v want:%v", test, d, density)
+		}
+	}
+}
+
+// inducedDensity returns the density of the subgraph of g induced by
+// the nodes for which in returns true.
+func inducedDensity(g graph.Undirected, in func(id int64) bool) float64 {
+	var n, m int
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		if !in(u.ID()) {
+			continue
+		}
+		n++
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if in(v.ID()) && u.ID() < v.ID() {
+				m++
+			}
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(m) / float64(n)
+}
@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinCutBinaryLabels(t *testing.T) {
+	// A strip of pixels with a bright left half and a dark right
+	// half. Pixel 1 alone prefers the background, but the smoothness
+	// term pulls it into the foreground with its neighbors.
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	for i := 0; i < 5; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: 3})
+	}
+	source := map[int64]float64{0: 9, 1: 4, 2: 9, 3: 1, 4: 1, 5: 1}
+	sink := map[int64]float64{0: 1, 1: 5, 2: 1, 3: 9, 4: 9, 5: 9}
+	labels, energy := MinCutBinaryLabels(g, source, sink)
+	want := map[int64]int{0: 0, 1: 0, 2: 0, 3: 1, 4: 1, 5: 1}
+	for id, l := range want {
+		if labels[id] != l {
+			t.Errorf("unexpected label for node %d: got:%d want:%d", id, labels[id], l)
+		}
+	}
+	if energy != 1+5+1+1+1+1+3 {
+		t.Errorf("unexpected energy: got:%v want:13", energy)
+	}
+}
+
+func TestMinCutBinaryLabelsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(8)
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		source := make(map[int64]float64)
+		sink := make(map[int64]float64)
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+			if rnd.Float64() < 0.8 {
+				source[int64(j)] = float64(rnd.Intn(10))
+			}
+			if rnd.Float64() < 0.8 {
+				sink[int64(j)] = float64(rnd.Intn(10))
+			}
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(6))})
+				}
+			}
+		}
+
+		want := math.Inf(1)
+		wantZeros := n + 1
+		for set := 0; set < 1<<uint(n); set++ {
+			labels := make(map[int64]int)
+			var zeros int
+			for j := 0; j < n; j++ {
+				labels[int64(j)] = (set >> uint(j)) & 1
+				if labels[int64(j)] == 0 {
+					zeros++
+				}
+			}
+			e := labelEnergy(g, source, sink, labels)
+			if e < want || (e == want && zeros < wantZeros) {
+				want, wantZeros = e, zeros
+			}
+		}
+
+		labels, got := MinCutBinaryLabels(g, source, sink)
+		if got != want {
+			t.Errorf("unexpected energy for test %d: got:%v want:%v", i, got, want)
+		}
+		if e := labelEnergy(g, source, sink, labels); e != got {
+			t.Errorf("energy of labeling does not match for test %d: got:%v want:%v", i, e, got)
+		}
+		var zeros int
+		for _, l := range labels {
+			if l == 0 {
+				zeros++
+			}
+		}
+		if zeros != wantZeros {
+			t.Errorf("unexpected number of nodes labeled 0 for test %d: got:%d want:%d", i, zeros, wantZeros)
+		}
+	}
+}
+
+// labelEnergy returns the graph cuts energy of the labeling.
+func labelEnergy(g *simple.WeightedUndirectedGraph, source, sink map[int64]float64, labels map[int64]int) float64 {
+	var e float64
+	for id, l := range labels {
+		if l == 1 {
+			e += source[id]
+		} else {
+			e += sink[id]
+		}
+	}
+	for _, edge := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+		if labels[edge.From().ID()] != labels[edge.To().ID()] {
+			e += edge.Weight()
+		}
+	}
+	return e
+}
@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// GreedyMaximalMatching returns a maximal matching of g, a set of edges no
+// two of which share an end node and to which no edge of g can be added
+// without sharing an end node with an edge of the set. The matching is
+// found by considering the edges of g in a random order and adding each
+// edge whose end nodes are both unmatched. The matching has at least half
+// as many edges as a maximum matching of g and is found in time linear in
+// the size of g. Self loops are not included in the matching.
+//
+// The edges are returned as given by g.Edge in the order they were added.
+// If src is nil, rand.Intn is used as the random number generator,
+// otherwise the result is deterministic for a given src.
+func GreedyMaximalMatching(g graph.Undirected, src rand.Source) []graph.Edge {
+	var rnd func(int) int
+	if src == nil {
+		rnd = rand.Intn
+	} else {
+		rnd = rand.New(src).Intn
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	var pairs [][2]int64
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if vid := v.ID(); uid < vid {
+				pairs = append(pairs, [2]int64{uid, vid})
+			}
+		}
+	}
+	for i := len(pairs) - 1; i > 0; i-- {
+		j := rnd(i + 1)
+		pairs[i], pairs[j] = pairs[j], pairs[i]
+	}
+
+	var matching []graph.Edge
+	matched := make(map[int64]bool)
+	for _, p := range pairs {
+		if matched[p[0]] || matched[p[1]] {
+			continue
+		}
+		matched[p[0]] = true
+		matched[p[1]] = true
+		matching = append(matching, g.Edge(p[0], p[1]))
+	}
+	return matching
+}
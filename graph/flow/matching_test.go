@@ -0,0 +1,92 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestGreedyMaximalMatching(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(9)
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		seed := rnd.Uint64()
+		matching := GreedyMaximalMatching(g, rand.NewSource(seed))
+		matched := make(map[int64]bool)
+		for _, e := range matching {
+			u, v := e.From().ID(), e.To().ID()
+			if !g.HasEdgeBetween(u, v) {
+				t.Errorf("matching edge %d-%d not in graph for test %d", u, v, i)
+			}
+			if matched[u] || matched[v] {
+				t.Errorf("matching edge %d-%d shares an end node for test %d", u, v, i)
+			}
+			matched[u] = true
+			matched[v] = true
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			if !matched[e.From().ID()] && !matched[e.To().ID()] {
+				t.Errorf("matching not maximal for test %d: edge %d-%d can be added", i, e.From().ID(), e.To().ID())
+			}
+		}
+		if max := maximumMatchingSize(g); 2*len(matching) < max {
+			t.Errorf("matching too small for test %d: got:%d maximum:%d", i, len(matching), max)
+		}
+
+		again := GreedyMaximalMatching(g, rand.NewSource(seed))
+		if len(again) != len(matching) {
+			t.Errorf("result not deterministic for test %d", i)
+			continue
+		}
+		for j, e := range again {
+			if e.From().ID() != matching[j].From().ID() || e.To().ID() != matching[j].To().ID() {
+				t.Errorf("result not deterministic for test %d", i)
+				break
+			}
+		}
+	}
+}
+
+// maximumMatchingSize returns the size of a maximum matching of g by
+// exhaustive search.
+func maximumMatchingSize(g *simple.UndirectedGraph) int {
+	edges := graph.EdgesOf(g.Edges())
+	var search func(i int, used map[int64]bool) int
+	search = func(i int, used map[int64]bool) int {
+		if i == len(edges) {
+			return 0
+		}
+		best := search(i+1, used)
+		u, v := edges[i].From().ID(), edges[i].To().ID()
+		if !used[u] && !used[v] {
+			used[u] = true
+			used[v] = true
+			if n := 1 + search(i+1, used); n > best {
+				best = n
+			}
+			delete(used, u)
+			delete(used, v)
+		}
+		return best
+	}
+	return search(0, make(map[int64]bool))
+}
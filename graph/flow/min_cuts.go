@@ -0,0 +1,223 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// AllMinCuts returns all the distinct minimum s-t cuts of g, where the
+// capacity of each edge is its weight. Each cut is returned as the nodes on
+// the source side followed by the nodes on the sink side, each ordered by ID.
+// See AllMinCutsFunc for the order in which cuts are returned.
+//
+// The number of minimum cuts of a graph may be exponential in its order, so
+// AllMinCutsFunc should be used when the cuts can be processed one at a time.
+func AllMinCuts(g graph.WeightedDirected, s, t graph.Node) [][2][]graph.Node {
+	var cuts [][2][]graph.Node
+	AllMinCutsFunc(g, s, t, func(cut [2][]graph.Node) bool {
+		cuts = append(cuts, cut)
+		return false
+	})
+	return cuts
+}
+
+// AllMinCutsFunc calls fn with each distinct minimum s-t cut of g, where
+// the capacity of each edge is its weight, until fn returns true. Each cut is
+// passed as the nodes on the source side followed by the nodes on the sink
+// side, each ordered by ID. The first cut passed to fn has the smallest
+// possible source side.
+//
+// The cuts are enumerated with the structure described by Picard and
+// Queyranne doi:10.1007/BFb0120902. After a maximum flow has been found, the
+// source sides of the minimum cuts are exactly the sets of nodes that contain
+// s, do not contain t and are closed under the arcs of the residual graph.
+// Each such set is a union of strongly connected components of the residual
+// graph, so the closed sets of the condensation are enumerated, taking time
+// O(|V|+|E|) for each cut after the maximum flow has been found.
+//
+// AllMinCutsFunc will panic if s and t are the same node, if either is not
+// in g or if g has a negative edge weight.
+func AllMinCutsFunc(g graph.WeightedDirected, s, t graph.Node, fn func(cut [2][]graph.Node) (stop bool)) {
+	if s.ID() == t.ID() {
+		panic("flow: source and sink are the same node")
+	}
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		panic("flow: terminal not in graph")
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	r := newResidual(len(nodes))
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			w, ok := g.Weight(uid, vid)
+			if !ok {
+				panic("flow: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("flow: negative edge weight")
+			}
+			r.addArc(i, indexOf[vid], w)
+		}
+	}
+	si, ti := indexOf[s.ID()], indexOf[t.ID()]
+	r.maxFlow(si, ti)
+
+	comp, n := r.components()
+	fixed := make([]int, n) // 1 for components in every source side, -1 for none.
+	for i, ok := range r.reachable(si) {
+		if ok {
+			fixed[comp[i]] = 1
+		}
+	}
+	for i, ok := range r.reaching(ti) {
+		if ok {
+			fixed[comp[i]] = -1
+		}
+	}
+
+	// succ holds the successors of each free component in the
+	// condensation of the residual graph.
+	succ := make([][]int, n)
+	for u, arcs := range r.adj {
+		cu := comp[u]
+		if fixed[cu] != 0 {
+			continue
+		}
+		for _, a := range arcs {
+			if cv := comp[r.to[a]]; r.cap[a] > 0 && cv != cu && fixed[cv] == 0 {
+				succ[cu] = append(succ[cu], cv)
+			}
+		}
+	}
+
+	// Components are numbered in topological order, so
+	// visiting them in reverse decides each component
+	// after all its successors.
+	var free []int
+	for c := n - 1; c >= 0; c-- {
+		if fixed[c] == 0 {
+			free = append(free, c)
+		}
+	}
+	in := make([]bool, n)
+	for c, f := range fixed {
+		in[c] = f == 1
+	}
+	var enumerate func(k int) bool
+	enumerate = func(k int) bool {
+		if k == len(free) {
+			var cut [2][]graph.Node
+			for i, u := range nodes {
+				if in[comp[i]] {
+					cut[0] = append(cut[0], u)
+				} else {
+					cut[1] = append(cut[1], u)
+				}
+			}
+			return fn(cut)
+		}
+		c := free[k]
+		if enumerate(k + 1) {
+			return true
+		}
+		for _, d := range succ[c] {
+			if !in[d] {
+				return false
+			}
+		}
+		in[c] = true
+		stop := enumerate(k + 1)
+		in[c] = false
+		return stop
+	}
+	enumerate(0)
+}
+
+// components returns the strongly connected component of each node of the
+// residual graph and the number of components. Components are numbered in a
+// topological order of the condensation of the residual graph.
+func (r *residual) components() (comp []int, n int) {
+	// Kosaraju's algorithm with iterative depth-first searches.
+	visited := make([]bool, len(r.adj))
+	order := make([]int, 0, len(r.adj))
+	type frame struct{ u, next int }
+	for root := range r.adj {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		stack := []frame{{u: root}}
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			if f.next == len(r.adj[f.u]) {
+				order = append(order, f.u)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			a := r.adj[f.u][f.next]
+			f.next++
+			if v := r.to[a]; r.cap[a] > 0 && !visited[v] {
+				visited[v] = true
+				stack = append(stack, frame{u: v})
+			}
+		}
+	}
+
+	comp = make([]int, len(r.adj))
+	for i := range comp {
+		comp[i] = -1
+	}
+	for k := len(order) - 1; k >= 0; k-- {
+		root := order[k]
+		if comp[root] >= 0 {
+			continue
+		}
+		comp[root] = n
+		stack := []int{root}
+		for len(stack) != 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, b := range r.adj[v] {
+				// Arc b^1 is an arc from r.to[b] into v.
+				if u := r.to[b]; r.cap[b^1] > 0 && comp[u] < 0 {
+					comp[u] = n
+					stack = append(stack, u)
+				}
+			}
+		}
+		n++
+	}
+	return comp, n
+}
+
+// reaching returns which nodes can reach t in the residual graph.
+func (r *residual) reaching(t int) []bool {
+	seen := make([]bool, len(r.adj))
+	seen[t] = true
+	stack := []int{t}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, b := range r.adj[v] {
+			if u := r.to[b]; r.cap[b^1] > 0 && !seen[u] {
+				seen[u] = true
+				stack = append(stack, u)
+			}
+		}
+	}
+	return seen
+}
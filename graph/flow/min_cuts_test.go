@@ -0,0 +1,126 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAllMinCuts(t *testing.T) {
+	// Two disjoint unit capacity paths of length two give
+	// four minimum cuts, one for each choice of an edge on
+	// each path.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	got := cutStrings(AllMinCuts(g, simple.Node(0), simple.Node(3)))
+	want := []string{"[0 1 2]|[3]", "[0 1]|[2 3]", "[0 2]|[1 3]", "[0]|[1 2 3]"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("unexpected cuts: got:%v want:%v", got, want)
+	}
+
+	var n int
+	AllMinCutsFunc(g, simple.Node(0), simple.Node(3), func(cut [2][]graph.Node) bool {
+		n++
+		if n == 1 && fmt.Sprint(ids(cut[0])) != "[0]" {
+			t.Errorf("unexpected first cut: got:%v want source side [0]", ids(cut[0]))
+		}
+		return n == 2
+	})
+	if n != 2 {
+		t.Errorf("enumeration did not stop: got %d cuts", n)
+	}
+}
+
+func TestAllMinCutsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(7)
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(4))})
+				}
+			}
+		}
+		s, tn := 0, n-1
+
+		// Find all sets containing s and not t with
+		// the minimum cut capacity.
+		min := math.Inf(1)
+		var want []string
+		for set := 0; set < 1<<uint(n); set++ {
+			if set&1 == 0 || set&(1<<uint(tn)) != 0 {
+				continue
+			}
+			var c float64
+			for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+				if set&(1<<uint(e.From().ID())) != 0 && set&(1<<uint(e.To().ID())) == 0 {
+					c += e.Weight()
+				}
+			}
+			var cut [2][]graph.Node
+			for u := 0; u < n; u++ {
+				side := 1
+				if set&(1<<uint(u)) != 0 {
+					side = 0
+				}
+				cut[side] = append(cut[side], simple.Node(u))
+			}
+			switch {
+			case c < min:
+				min = c
+				want = []string{cutString(cut)}
+			case c == min:
+				want = append(want, cutString(cut))
+			}
+		}
+		sort.Strings(want)
+
+		got := cutStrings(AllMinCuts(g, simple.Node(s), simple.Node(tn)))
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("unexpected cuts for test %d:\ngot: %v\nwant:%v", i, got, want)
+		}
+	}
+}
+
+func cutStrings(cuts [][2][]graph.Node) []string {
+	s := make([]string, len(cuts))
+	for i, c := range cuts {
+		s[i] = cutString(c)
+	}
+	sort.Strings(s)
+	return s
+}
+
+func cutString(cut [2][]graph.Node) string {
+	return fmt.Sprintf("%v|%v", ids(cut[0]), ids(cut[1]))
+}
+
+func ids(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
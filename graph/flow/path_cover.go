@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// MinPathCover returns a minimum set of vertex-disjoint paths covering all
+// the nodes of the directed acyclic graph g, and the number of paths. Each
+// node of g is in exactly one of the returned paths, and consecutive nodes of
+// each path are joined by an edge of g. A path may hold a single node.
+//
+// The cover is found from a maximum matching in the bipartite graph with a
+// left and a right copy of each node and an edge from the left copy of u to
+// the right copy of v for each edge u→v of g, found as a maximum flow. Each
+// matched edge joins two nodes in a path, so the number of paths is the
+// number of nodes less the size of the matching. When g is transitively
+// closed, the number of paths is the size of a maximum antichain of g by
+// Dilworth's theorem.
+//
+// The paths are ordered by the ID of their first node. MinPathCover will panic
+// if g contains a cycle.
+func MinPathCover(g graph.Directed) ([][]graph.Node, int) {
+	if _, err := topo.Sort(g); err != nil {
+		panic("flow: graph has a cycle")
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// Left copies are nodes 0 to n-1 and right
+	// copies are nodes n to 2n-1.
+	s, t := 2*n, 2*n+1
+	r := newResidual(2*n + 2)
+	type arc struct {
+		index int
+		u, v  int
+	}
+	var arcs []arc
+	for i, u := range nodes {
+		r.addArc(s, i, 1)
+		r.addArc(n+i, t, 1)
+		to := g.From(u.ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			arcs = append(arcs, arc{index: r.addArc(i, n+j, 1), u: i, v: j})
+		}
+	}
+	r.maxFlow(s, t)
+
+	next := make([]int, n)
+	for i := range next {
+		next[i] = -1
+	}
+	hasPrev := make([]bool, n)
+	for _, a := range arcs {
+		if r.flow(a.index) > 0 {
+			next[a.u] = a.v
+			hasPrev[a.v] = true
+		}
+	}
+
+	var paths [][]graph.Node
+	for i, u := range nodes {
+		if hasPrev[i] {
+			continue
+		}
+		p := []graph.Node{u}
+		for j := next[i]; j >= 0; j = next[j] {
+			p = append(p, nodes[j])
+		}
+		paths = append(paths, p)
+	}
+	return paths, len(paths)
+}
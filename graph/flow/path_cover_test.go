@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinPathCover(t *testing.T) {
+	// Two chains 0→1→2 and 3→4 with cross edges 0→4 and
+	// 3→1, and an isolated node 5.
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {3, 4}, {0, 4}, {3, 1}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(5))
+
+	paths, n := MinPathCover(g)
+	if n != 3 || len(paths) != 3 {
+		t.Fatalf("unexpected number of paths: got:%d len:%d want:3", n, len(paths))
+	}
+	checkPathCover(t, g, paths, "example")
+	if got, want := fmt.Sprint(ids(paths[2])), "[5]"; got != want {
+		t.Errorf("unexpected last path: got:%s want:%s", got, want)
+	}
+}
+
+func TestMinPathCoverRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 200; test++ {
+		n := 1 + rnd.Intn(8)
+		g := simple.NewDirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		// Edges only go from lower to higher IDs,
+		// so g is acyclic.
+		split := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+					split.SetEdge(simple.Edge{F: simple.Node(2 * u), T: simple.Node(2*v + 1)})
+				}
+			}
+		}
+
+		paths, count := MinPathCover(g)
+		if count != len(paths) {
+			t.Errorf("count does not match paths for test %d: got:%d want:%d", test, count, len(paths))
+		}
+		if want := n - maximumMatchingSize(split); count != want {
+			t.Errorf("unexpected number of paths for test %d: got:%d want:%d", test, count, want)
+		}
+		checkPathCover(t, g, paths, fmt.Sprintf("test %d", test))
+	}
+}
+
+// checkPathCover checks that paths are vertex-disjoint paths of g
+// covering all its nodes.
+func checkPathCover(t *testing.T, g graph.Directed, paths [][]graph.Node, name string) {
+	t.Helper()
+	seen := make(map[int64]bool)
+	for _, p := range paths {
+		for i, u := range p {
+			if seen[u.ID()] {
+				t.Errorf("node %d in more than one path for %s", u.ID(), name)
+			}
+			seen[u.ID()] = true
+			if i > 0 && !g.HasEdgeFromTo(p[i-1].ID(), u.ID()) {
+				t.Errorf("missing edge %d→%d in path for %s", p[i-1].ID(), u.ID(), name)
+			}
+		}
+	}
+	if len(seen) != g.Nodes().Len() {
+		t.Errorf("paths do not cover all nodes for %s: got:%d want:%d", name, len(seen), g.Nodes().Len())
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import "math"
+
+// residual is a flow network on nodes indexed from zero with a residual
+// graph representation suitable for Dinic's maximum flow algorithm. Arcs
+// are held in pairs so that arc i^1 is the reverse of arc i.
+type residual struct {
+	adj  [][]int
+	to   []int
+	cap  []float64
+	orig []float64
+
+	level []int
+	next  []int
+}
+
+// newResidual returns a flow network with n nodes and no arcs.
+func newResidual(n int) *residual {
+	return &residual{adj: make([][]int, n)}
+}
+
+// addArc adds an arc from u to v with capacity c and returns its index.
+// The reverse arc has index one greater and zero capacity.
+func (r *residual) addArc(u, v int, c float64) int {
+	i := len(r.to)
+	r.adj[u] = append(r.adj[u], i)
+	r.to = append(r.to, v)
+	r.cap = append(r.cap, c)
+	r.orig = append(r.orig, c)
+	r.adj[v] = append(r.adj[v], i+1)
+	r.to = append(r.to, u)
+	r.cap = append(r.cap, 0)
+	r.orig = append(r.orig, 0)
+	return i
+}
+
+// flow returns the flow on the arc with index i.
+func (r *residual) flow(i int) float64 {
+	return r.orig[i] - r.cap[i]
+}
+
+// maxFlow augments the flow in the network to a maximum flow from s to t
+// using Dinic's algorithm and returns the value of the flow added.
+func (r *residual) maxFlow(s, t int) float64 {
+	var total float64
+	r.level = make([]int, len(r.adj))
+	r.next = make([]int, len(r.adj))
+	for r.levels(s, t) {
+		for i := range r.next {
+			r.next[i] = 0
+		}
+		for {
+			f := r.augment(s, t, math.Inf(1))
+			if f == 0 {
+				break
+			}
+			total += f
+		}
+	}
+	return total
+}
+
+// levels computes the breadth-first level of each node from s in the
+// residual graph and returns whether t is reachable.
+func (r *residual) levels(s, t int) bool {
+	for i := range r.level {
+		r.level[i] = -1
+	}
+	r.level[s] = 0
+	queue := []int{s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range r.adj[u] {
+			if v := r.to[a]; r.cap[a] > 0 && r.level[v] < 0 {
+				r.level[v] = r.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return r.level[t] >= 0
+}
+
+// augment pushes at most limit units of flow along level-increasing
+// paths from u to t and returns the amount pushed.
+func (r *residual) augment(u, t int, limit float64) float64 {
+	if u == t {
+		return limit
+	}
+	for ; r.next[u] < len(r.adj[u]); r.next[u]++ {
+		a := r.adj[u][r.next[u]]
+		v := r.to[a]
+		if r.cap[a] <= 0 || r.level[v] != r.level[u]+1 {
+			continue
+		}
+		f := r.augment(v, t, math.Min(limit, r.cap[a]))
+		if f > 0 {
+			r.cap[a] -= f
+			r.cap[a^1] += f
+			return f
+		}
+	}
+	return 0
+}
+
+// reachable returns which nodes are reachable from s in the residual
+// graph. After a maximum flow from s has been found, the reachable
+// nodes are the source side of a minimum cut.
+func (r *residual) reachable(s int) []bool {
+	seen := make([]bool, len(r.adj))
+	seen[s] = true
+	stack := []int{s}
+	for len(stack) != 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, a := range r.adj[u] {
+			if v := r.to[a]; r.cap[a] > 0 && !seen[v] {
+				seen[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return seen
+}
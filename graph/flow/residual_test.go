@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestResidualMaxFlow(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(7)
+		type arc struct {
+			u, v int
+			c    float64
+		}
+		var arcs []arc
+		r := newResidual(n)
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					c := float64(rnd.Intn(10))
+					arcs = append(arcs, arc{u: u, v: v, c: c})
+					r.addArc(u, v, c)
+				}
+			}
+		}
+		s, tn := 0, n-1
+
+		// The maximum flow value equals the minimum
+		// capacity of a cut separating s from t.
+		want := math.Inf(1)
+		for set := 0; set < 1<<uint(n); set++ {
+			if set&1 == 0 || set&(1<<uint(tn)) != 0 {
+				continue
+			}
+			var c float64
+			for _, a := range arcs {
+				if set&(1<<uint(a.u)) != 0 && set&(1<<uint(a.v)) == 0 {
+					c += a.c
+				}
+			}
+			want = math.Min(want, c)
+		}
+
+		got := r.maxFlow(s, tn)
+		if got != want {
+			t.Errorf("unexpected maximum flow for test %d: got:%v want:%v", i, got, want)
+		}
+
+		// The flow must respect capacities and be conserved.
+		excess := make([]float64, n)
+		for j, a := range arcs {
+			f := r.flow(2 * j)
+			if f < 0 || f > a.c {
+				t.Errorf("flow out of bounds on arc %d->%d for test %d: %v not in [0, %v]", a.u, a.v, i, f, a.c)
+			}
+			excess[a.u] -= f
+			excess[a.v] += f
+		}
+		for u, e := range excess {
+			switch u {
+			case s:
+				e = -e
+				fallthrough
+			case tn:
+				if e != got {
+					t.Errorf("unexpected terminal excess at %d for test %d: got:%v want:%v", u, i, e, got)
+				}
+			default:
+				if e != 0 {
+					t.Errorf("flow not conserved at %d for test %d: excess %v", u, i, e)
+				}
+			}
+		}
+
+		// The reachable set is the source side of a minimum cut.
+		side := r.reachable(s)
+		if side[tn] {
+			t.Errorf("sink reachable after maximum flow for test %d", i)
+		}
+		var cut float64
+		for _, a := range arcs {
+			if side[a.u] && !side[a.v] {
+				cut += a.c
+			}
+		}
+		if cut != got {
+			t.Errorf("unexpected cut capacity for test %d: got:%v want:%v", i, cut, got)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// TransportationOption is a functional option for TransportationProblem.
+type TransportationOption func(*transportationOptions)
+
+type transportationOptions struct {
+	balance bool
+}
+
+// BalanceWithDummy allows TransportationProblem to solve an unbalanced
+// problem by adding a dummy demand node that receives any excess supply, or
+// a dummy supply node that provides any excess demand, at zero cost. Flows
+// to and from the dummy node are not included in the returned flows.
+func BalanceWithDummy() TransportationOption {
+	return func(o *transportationOptions) { o.balance = true }
+}
+
+// TransportationProblem returns the flows from supply nodes to demand nodes
+// that ship the full supply of each supply node and deliver the full demand
+// of each demand node at the minimum total cost, and that cost. The supply
+// and demand maps hold the amount available at each supply node and required
+// at each demand node, keyed by node ID, and cost returns the cost of
+// shipping a unit amount from a supply node to a demand node. A cost of +Inf
+// prevents shipping between the nodes. The returned flows are keyed by the
+// supply and then demand node IDs and hold only non-zero flows.
+//
+// The problem is solved as a minimum cost flow by successive shortest paths
+// with Bellman-Ford searches, so costs may be negative. If the total supply
+// and total demand differ, ok is returned false unless the BalanceWithDummy
+// option is given. If no shipment meeting the supplies and demands exists
+// because of infinite costs, ok is returned false. Totals are compared with
+// a relative tolerance of 1e-12.
+//
+// TransportationProblem will panic if a supply or demand is negative.
+func TransportationProblem(supply, demand map[int64]float64, cost func(from, to int64) float64, opts ...TransportationOption) (flow map[[2]int64]float64, totalCost float64, ok bool) {
+	var o transportationOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	from := positiveKeys(supply, "flow: negative supply")
+	to := positiveKeys(demand, "flow: negative demand")
+	var totalSupply, totalDemand float64
+	for _, id := range from {
+		totalSupply += supply[id]
+	}
+	for _, id := range to {
+		totalDemand += demand[id]
+	}
+	tol := 1e-12 * math.Max(1, math.Max(totalSupply, totalDemand))
+	if math.Abs(totalSupply-totalDemand) > tol && !o.balance {
+		return nil, 0, false
+	}
+
+	// Supply nodes are indexed from zero, followed by the
+	// demand nodes, a dummy node and the terminals.
+	n := len(from) + len(to)
+	dummy, s, t := n, n+1, n+2
+	c := newCostResidual(n + 3)
+	type arc struct {
+		index    int
+		from, to int64
+		cost     float64
+	}
+	var arcs []arc
+	for i, u := range from {
+		c.addArc(s, i, supply[u], 0)
+		for j, v := range to {
+			w := cost(u, v)
+			if math.IsInf(w, 1) {
+				continue
+			}
+			arcs = append(arcs, arc{index: c.addArc(i, len(from)+j, math.Min(supply[u], demand[v]), w), from: u, to: v, cost: w})
+		}
+	}
+	for j, v := range to {
+		c.addArc(len(from)+j, t, demand[v], 0)
+	}
+	need := math.Min(totalSupply, totalDemand)
+	if o.balance {
+		switch {
+		case totalSupply > totalDemand:
+			for i, u := range from {
+				c.addArc(i, dummy, supply[u], 0)
+			}
+			c.addArc(dummy, t, totalSupply-totalDemand, 0)
+		case totalDemand > totalSupply:
+			c.addArc(s, dummy, totalDemand-totalSupply, 0)
+			for j, v := range to {
+				c.addArc(dummy, len(from)+j, demand[v], 0)
+			}
+		}
+		need = math.Max(totalSupply, totalDemand)
+	}
+
+	if shipped := c.minCostFlow(s, t, tol); shipped < need-tol {
+		return nil, 0, false
+	}
+
+	flow = make(map[[2]int64]float64)
+	for _, a := range arcs {
+		f := c.flow(a.index)
+		if f <= tol {
+			continue
+		}
+		flow[[2]int64{a.from, a.to}] = f
+		totalCost += f * a.cost
+	}
+	return flow, totalCost, true
+}
+
+// positiveKeys returns the keys of amounts with positive values, ordered
+// ascending. It panics with msg if any value is negative.
+func positiveKeys(amounts map[int64]float64, msg string) []int64 {
+	var keys []int64
+	for id, a := range amounts {
+		if a < 0 {
+			panic(msg)
+		}
+		if a > 0 {
+			keys = append(keys, id)
+		}
+	}
+	sort.Sort(ordered.Int64s(keys))
+	return keys
+}
+
+// costResidual is a flow network with arc costs for minimum cost flow.
+type costResidual struct {
+	*residual
+	cost []float64
+}
+
+// newCostResidual returns a flow network with n nodes and no arcs.
+func newCostResidual(n int) *costResidual {
+	return &costResidual{residual: newResidual(n)}
+}
+
+// addArc adds an arc from u to v with capacity c and unit cost w and returns
+// its index. The reverse arc has index one greater, zero capacity and cost -w.
+func (r *costResidual) addArc(u, v int, c, w float64) int {
+	r.cost = append(r.cost, w, -w)
+	return r.residual.addArc(u, v, c)
+}
+
+// minCostFlow augments the flow in the network to a maximum flow from s to t
+// of minimum cost, augmenting along successive cheapest paths in the residual
+// graph found by the Bellman-Ford algorithm, and returns the value of the flow
+// added. Residual capacities no greater than tol are treated as zero.
+func (r *costResidual) minCostFlow(s, t int, tol float64) float64 {
+	n := len(r.adj)
+	dist := make([]float64, n)
+	via := make([]int, n)
+	var total float64
+	for {
+		for i := range dist {
+			dist[i] = math.Inf(1)
+			via[i] = -1
+		}
+		dist[s] = 0
+		for k := 0; k < n-1; k++ {
+			var changed bool
+			for u, arcs := range r.adj {
+				if math.IsInf(dist[u], 1) {
+					continue
+				}
+				for _, a := range arcs {
+					if r.cap[a] <= tol {
+						continue
+					}
+					if d := dist[u] + r.cost[a]; d < dist[r.to[a]] {
+						dist[r.to[a]] = d
+						via[r.to[a]] = a
+						changed = true
+					}
+				}
+			}
+			if !changed {
+				break
+			}
+		}
+		if via[t] < 0 {
+			return total
+		}
+
+		f := math.Inf(1)
+		for v := t; v != s; v = r.to[via[v]^1] {
+			f = math.Min(f, r.cap[via[v]])
+		}
+		for v := t; v != s; v = r.to[via[v]^1] {
+			r.cap[via[v]] -= f
+			r.cap[via[v]^1] += f
+		}
+		total += f
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestTransportationProblem(t *testing.T) {
+	// Two factories supplying three shops.
+	supply := map[int64]float64{0: 20, 1: 30}
+	demand := map[int64]float64{10: 10, 11: 25, 12: 15}
+	costs := map[[2]int64]float64{
+		{0, 10}: 8, {0, 11}: 6, {0, 12}: 10,
+		{1, 10}: 9, {1, 11}: 12, {1, 12}: 13,
+	}
+	cost := func(from, to int64) float64 { return costs[[2]int64{from, to}] }
+
+	flow, total, ok := TransportationProblem(supply, demand, cost)
+	if !ok {
+		t.Fatal("unexpected failure")
+	}
+	want := map[[2]int64]float64{{0, 11}: 20, {1, 10}: 10, {1, 11}: 5, {1, 12}: 15}
+	if !reflect.DeepEqual(flow, want) {
+		t.Errorf("unexpected flow: got:%v want:%v", flow, want)
+	}
+	if total != 465 {
+		t.Errorf("unexpected total cost: got:%v want:465", total)
+	}
+
+	// Excess supply is not shipped without the dummy option,
+	// and the cheapest supply is used with it.
+	supply[1] = 40
+	if _, _, ok := TransportationProblem(supply, demand, cost); ok {
+		t.Error("unexpected success for unbalanced problem")
+	}
+	flow, total, ok = TransportationProblem(supply, demand, cost, BalanceWithDummy())
+	if !ok {
+		t.Fatal("unexpected failure with dummy")
+	}
+	want = map[[2]int64]float64{{0, 11}: 20, {1, 10}: 10, {1, 11}: 5, {1, 12}: 15}
+	if !reflect.DeepEqual(flow, want) {
+		t.Errorf("unexpected flow with dummy: got:%v want:%v", flow, want)
+	}
+	if total != 465 {
+		t.Errorf("unexpected total cost with dummy: got:%v want:465", total)
+	}
+
+	// An infinite cost route may make the problem infeasible.
+	supply[1] = 30
+	costs[[2]int64{1, 12}] = math.Inf(1)
+	costs[[2]int64{0, 12}] = math.Inf(1)
+	if _, _, ok := TransportationProblem(supply, demand, cost); ok {
+		t.Error("unexpected success for infeasible problem")
+	}
+}
+
+func TestTransportationProblemRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 200; test++ {
+		ns, nd := 1+rnd.Intn(3), 1+rnd.Intn(3)
+		supply := make(map[int64]float64)
+		demand := make(map[int64]float64)
+		var total int
+		for i := 0; i < ns; i++ {
+			a := rnd.Intn(4)
+			supply[int64(i)] = float64(a)
+			total += a
+		}
+		for j := 0; j < nd-1 && total > 0; j++ {
+			a := rnd.Intn(total + 1)
+			demand[int64(10+j)] = float64(a)
+			total -= a
+		}
+		demand[int64(10+nd-1)] += float64(total)
+		costs := make(map[[2]int64]float64)
+		for i := 0; i < ns; i++ {
+			for j := 0; j < nd; j++ {
+				c := float64(rnd.Intn(11) - 3)
+				if rnd.Float64() < 0.1 {
+					c = math.Inf(1)
+				}
+				costs[[2]int64{int64(i), int64(10 + j)}] = c
+			}
+		}
+		cost := func(from, to int64) float64 { return costs[[2]int64{from, to}] }
+
+		want, feasible := bruteForceTransportation(supply, demand, costs)
+		flow, got, ok := TransportationProblem(supply, demand, cost)
+		if ok != feasible {
+			t.Errorf("unexpected feasibility for test %d: got:%t want:%t", test, ok, feasible)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("unexpected total cost for test %d: got:%v want:%v", test, got, want)
+		}
+		shipped := make(map[int64]float64)
+		received := make(map[int64]float64)
+		var sum float64
+		for k, f := range flow {
+			shipped[k[0]] += f
+			received[k[1]] += f
+			sum += f * costs[k]
+		}
+		for id, s := range supply {
+			if math.Abs(shipped[id]-s) > 1e-9 {
+				t.Errorf("unexpected shipment from %d for test %d: got:%v want:%v", id, test, shipped[id], s)
+			}
+		}
+		for id, d := range demand {
+			if math.Abs(received[id]-d) > 1e-9 {
+				t.Errorf("unexpected receipt at %d for test %d: got:%v want:%v", id, test, received[id], d)
+			}
+		}
+		if math.Abs(sum-got) > 1e-9 {
+			t.Errorf("total cost does not match flows for test %d: got:%v want:%v", test, got, sum)
+		}
+	}
+}
+
+// bruteForceTransportation returns the minimum cost of an integer
+// shipment meeting the balanced supply and demand by exhaustive search,
+// and whether any such shipment exists.
+func bruteForceTransportation(supply, demand map[int64]float64, costs map[[2]int64]float64) (float64, bool) {
+	var keys [][2]int64
+	for k := range costs {
+		keys = append(keys, k)
+	}
+	remaining := make(map[int64]float64)
+	for id, s := range supply {
+		remaining[id] = s
+	}
+	need := make(map[int64]float64)
+	for id, d := range demand {
+		need[id] = d
+	}
+	best := math.Inf(1)
+	var search func(k int, cost float64)
+	search = func(k int, cost float64) {
+		if k == len(keys) {
+			for _, s := range remaining {
+				if s != 0 {
+					return
+				}
+			}
+			for _, d := range need {
+				if d != 0 {
+					return
+				}
+			}
+			best = math.Min(best, cost)
+			return
+		}
+		u, v := keys[k][0], keys[k][1]
+		c := costs[keys[k]]
+		max := math.Min(remaining[u], need[v])
+		if math.IsInf(c, 1) {
+			max = 0
+		}
+		for f := 0.0; f <= max; f++ {
+			remaining[u] -= f
+			need[v] -= f
+			search(k+1, cost+f*c)
+			remaining[u] += f
+			need[v] += f
+		}
+	}
+	search(0, 0)
+	return best, !math.IsInf(best, 1)
+}
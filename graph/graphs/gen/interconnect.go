@@ -0,0 +1,156 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Hypercube constructs the d-dimensional hypercube graph as a subgraph in
+// the destination, dst. The hypercube has 2^d nodes, and nodes are joined
+// by an edge when the binary representations of their indexes differ in
+// exactly one bit. The nodes are created with dst.NewNode, and the node
+// with index i is the i-th node created. If dst is a graph.Directed, edges
+// are added in both directions.
+//
+// The d-dimensional hypercube is d-regular with diameter d.
+func Hypercube(dst graph.Builder, d int) error {
+	if d < 0 || d >= 31 {
+		return fmt.Errorf("gen: bad dimension: d=%d", d)
+	}
+	nodes := addNodes(dst, 1<<uint(d))
+	_, isDirected := dst.(graph.Directed)
+	for i := range nodes {
+		for b := uint(0); b < uint(d); b++ {
+			j := i ^ 1<<b
+			if j < i && !isDirected {
+				continue
+			}
+			dst.SetEdge(dst.NewEdge(nodes[i], nodes[j]))
+		}
+	}
+	return nil
+}
+
+// DeBruijn constructs the directed De Bruijn graph of order n over an
+// alphabet of k symbols as a subgraph in the destination, dst. The nodes
+// of the graph are the k^n words of length n over the alphabet and there
+// is an edge from each word s_1 s_2 ... s_n to each of the k words
+// s_2 ... s_n x. The node with index i is the word whose base-k digits,
+// most significant first, are the symbols of the word, and is the i-th
+// node created with dst.NewNode.
+//
+// The self edges of the k words consisting of a single repeated symbol are
+// not added, so those words have k-1 out-edges and k-1 in-edges and all
+// other words have k of each. For k > 1 the De Bruijn graph has diameter n.
+func DeBruijn(dst graph.DirectedBuilder, k, n int) error {
+	if k < 1 {
+		return fmt.Errorf("gen: bad alphabet size: k=%d", k)
+	}
+	size, ok := pow(k, n)
+	if !ok || n < 1 {
+		return fmt.Errorf("gen: bad word length: n=%d", n)
+	}
+	nodes := addNodes(dst, size)
+	for i, u := range nodes {
+		for x := 0; x < k; x++ {
+			if j := (i*k + x) % size; j != i {
+				dst.SetEdge(dst.NewEdge(u, nodes[j]))
+			}
+		}
+	}
+	return nil
+}
+
+// Kautz constructs the directed Kautz graph of order n over an alphabet of
+// k+1 symbols as a subgraph in the destination, dst. The nodes of the graph
+// are the (k+1)k^(n-1) words of length n over the alphabet in which no two
+// consecutive symbols are equal, and there is an edge from each word
+// s_1 s_2 ... s_n to each of the k words s_2 ... s_n x where x is not s_n.
+// The node with index i is the i-th word in lexical order, and is the i-th
+// node created with dst.NewNode.
+//
+// The Kautz graph is k-regular and has no self edges. For k > 1 it has
+// diameter n.
+func Kautz(dst graph.DirectedBuilder, k, n int) error {
+	if k < 1 {
+		return fmt.Errorf("gen: bad degree: k=%d", k)
+	}
+	if n < 1 {
+		return fmt.Errorf("gen: bad word length: n=%d", n)
+	}
+	tail, ok := pow(k, n-1)
+	if !ok || tail > math.MaxInt32/(k+1) {
+		return fmt.Errorf("gen: bad word length: n=%d", n)
+	}
+	nodes := addNodes(dst, (k+1)*tail)
+
+	// A Kautz word is encoded by its first symbol followed by
+	// n-1 digits in [0, k). Each digit gives the next symbol
+	// as the digit itself if it is less than the previous
+	// symbol and as the digit plus one otherwise.
+	word := make([]int, n)
+	next := make([]int, n)
+	for i, u := range nodes {
+		word[0] = i / tail
+		rem := i % tail
+		for j, p := 1, tail/k; j < n; j, p = j+1, p/k {
+			word[j] = rem / p
+			if word[j] >= word[j-1] {
+				word[j]++
+			}
+			rem %= p
+		}
+		copy(next, word[1:])
+		for x := 0; x <= k; x++ {
+			if x == word[n-1] {
+				continue
+			}
+			next[n-1] = x
+			dst.SetEdge(dst.NewEdge(u, nodes[kautzIndex(next, k, tail)]))
+		}
+	}
+	return nil
+}
+
+// kautzIndex returns the lexical index of the Kautz word w over an
+// alphabet of k+1 symbols, where tail is k^(len(w)-1).
+func kautzIndex(w []int, k, tail int) int {
+	idx := w[0] * tail
+	for j, p := 1, tail/k; j < len(w); j, p = j+1, p/k {
+		d := w[j]
+		if d > w[j-1] {
+			d--
+		}
+		idx += d * p
+	}
+	return idx
+}
+
+// addNodes adds n new nodes to dst and returns them in order of creation.
+func addNodes(dst graph.NodeAdder, n int) []graph.Node {
+	nodes := make([]graph.Node, n)
+	for i := range nodes {
+		u := dst.NewNode()
+		dst.AddNode(u)
+		nodes[i] = u
+	}
+	return nodes
+}
+
+// pow returns k^n and whether the result is no larger than math.MaxInt32.
+func pow(k, n int) (int, bool) {
+	r := 1
+	for i := 0; i < n; i++ {
+		if r > math.MaxInt32/k {
+			return 0, false
+		}
+		r *= k
+	}
+	return r, true
+}
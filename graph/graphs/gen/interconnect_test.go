@@ -0,0 +1,190 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gen
+
+import (
+	"math/bits"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestHypercube(t *testing.T) {
+	for d := 0; d <= 6; d++ {
+		for _, directed := range []bool{false, true} {
+			var g graph.Graph
+			var err error
+			if directed {
+				dg := simple.NewDirectedGraph()
+				err = Hypercube(dg, d)
+				g = dg
+			} else {
+				ug := simple.NewUndirectedGraph()
+				err = Hypercube(ug, d)
+				g = ug
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for d=%d: %v", d, err)
+			}
+			n := 1 << uint(d)
+			if got := g.Nodes().Len(); got != n {
+				t.Errorf("unexpected number of nodes for d=%d: got:%d want:%d", d, got, n)
+			}
+			for u := 0; u < n; u++ {
+				for v := 0; v < n; v++ {
+					want := bits.OnesCount(uint(u^v)) == 1
+					if got := g.Edge(int64(u), int64(v)) != nil; got != want {
+						t.Errorf("unexpected edge %d-%d for d=%d directed=%t: got:%t want:%t", u, v, d, directed, got, want)
+					}
+				}
+			}
+			if got := diameter(g); got != d {
+				t.Errorf("unexpected diameter for d=%d: got:%d want:%d", d, got, d)
+			}
+		}
+	}
+	if err := Hypercube(simple.NewUndirectedGraph(), -1); err == nil {
+		t.Error("expected error for negative dimension")
+	}
+}
+
+func TestDeBruijn(t *testing.T) {
+	for k := 1; k <= 4; k++ {
+		for n := 1; n <= 4; n++ {
+			g := simple.NewDirectedGraph()
+			if err := DeBruijn(g, k, n); err != nil {
+				t.Fatalf("unexpected error for k=%d n=%d: %v", k, n, err)
+			}
+			size := 1
+			for i := 0; i < n; i++ {
+				size *= k
+			}
+			if got := g.Nodes().Len(); got != size {
+				t.Errorf("unexpected number of nodes for k=%d n=%d: got:%d want:%d", k, n, got, size)
+			}
+			for u := 0; u < size; u++ {
+				uw := digits(u, k, n)
+				for v := 0; v < size; v++ {
+					vw := digits(v, k, n)
+					want := u != v && equalInts(uw[1:], vw[:n-1])
+					if got := g.HasEdgeFromTo(int64(u), int64(v)); got != want {
+						t.Errorf("unexpected edge %v->%v for k=%d n=%d: got:%t want:%t", uw, vw, k, n, got, want)
+					}
+				}
+			}
+			if k > 1 {
+				if got := diameter(g); got != n {
+					t.Errorf("unexpected diameter for k=%d n=%d: got:%d want:%d", k, n, got, n)
+				}
+			}
+		}
+	}
+	if err := DeBruijn(simple.NewDirectedGraph(), 0, 2); err == nil {
+		t.Error("expected error for empty alphabet")
+	}
+}
+
+func TestKautz(t *testing.T) {
+	for k := 1; k <= 3; k++ {
+		for n := 1; n <= 4; n++ {
+			g := simple.NewDirectedGraph()
+			if err := Kautz(g, k, n); err != nil {
+				t.Fatalf("unexpected error for k=%d n=%d: %v", k, n, err)
+			}
+
+			// Enumerate the Kautz words in lexical order.
+			var words [][]int
+			size := 1
+			for i := 0; i < n; i++ {
+				size *= k + 1
+			}
+			for i := 0; i < size; i++ {
+				w := digits(i, k+1, n)
+				ok := true
+				for j := 1; j < n; j++ {
+					if w[j] == w[j-1] {
+						ok = false
+						break
+					}
+				}
+				if ok {
+					words = append(words, w)
+				}
+			}
+			if got := g.Nodes().Len(); got != len(words) {
+				t.Errorf("unexpected number of nodes for k=%d n=%d: got:%d want:%d", k, n, got, len(words))
+			}
+			for u, uw := range words {
+				if got := g.From(int64(u)).Len(); got != k {
+					t.Errorf("unexpected out-degree of %v for k=%d n=%d: got:%d want:%d", uw, k, n, got, k)
+				}
+				for v, vw := range words {
+					want := u != v && equalInts(uw[1:], vw[:n-1])
+					if got := g.HasEdgeFromTo(int64(u), int64(v)); got != want {
+						t.Errorf("unexpected edge %v->%v for k=%d n=%d: got:%t want:%t", uw, vw, k, n, got, want)
+					}
+				}
+			}
+			if k > 1 {
+				if got := diameter(g); got != n {
+					t.Errorf("unexpected diameter for k=%d n=%d: got:%d want:%d", k, n, got, n)
+				}
+			}
+		}
+	}
+}
+
+// digits returns the n base-k digits of i, most significant first.
+func digits(i, k, n int) []int {
+	d := make([]int, n)
+	for j := n - 1; j >= 0; j-- {
+		d[j] = i % k
+		i /= k
+	}
+	return d
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diameter returns the largest shortest path length in g, or -1 if
+// g is not strongly connected.
+func diameter(g graph.Graph) int {
+	var diam int
+	nodes := graph.NodesOf(g.Nodes())
+	for _, s := range nodes {
+		dist := map[int64]int{s.ID(): 0}
+		queue := []int64{s.ID()}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range graph.NodesOf(g.From(u)) {
+				if _, ok := dist[v.ID()]; !ok {
+					dist[v.ID()] = dist[u] + 1
+					queue = append(queue, v.ID())
+				}
+			}
+		}
+		if len(dist) != len(nodes) {
+			return -1
+		}
+		for _, d := range dist {
+			if d > diam {
+				diam = d
+			}
+		}
+	}
+	return diam
+}
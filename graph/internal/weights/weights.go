@@ -0,0 +1,30 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Params returns the self and absent weights of g. The self weight is
+// obtained by querying the weight between the first of the given nodes and
+// itself, and the absent weight by querying the weight between that node and
+// an ID that is not present in g. If nodes is empty, Params returns zero and
+// +Inf, the conventional self and absent weights.
+func Params(g graph.Weighted, nodes []graph.Node) (self, absent float64) {
+	if len(nodes) == 0 {
+		return 0, math.Inf(1)
+	}
+	id := nodes[0].ID()
+	self, _ = g.Weight(id, id)
+	missing := id - 1
+	for g.Node(missing) != nil {
+		missing--
+	}
+	absent, _ = g.Weight(id, missing)
+	return self, absent
+}
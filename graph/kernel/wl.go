@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kernel
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// WeisfeilerLehman returns the Gram matrix of the Weisfeiler-Lehman subtree
+// kernel of Shervashidze et al. http://www.jmlr.org/papers/v12/shervashidze11a.html
+// for the given graphs after the given number of iterations. Element i, j of
+// the Gram matrix is the inner product of the label count histograms of
+// graphs[i] and graphs[j].
+//
+// All nodes start with the same label, so the kernel compares the structure
+// of the graphs. In each iteration every node is relabeled by its current
+// label and the sorted multiset of the labels of its neighbors, with the new
+// labels shared between all the graphs. The histogram of a graph counts the
+// nodes holding each label over the initial labeling and every iteration.
+// Since the histograms of isomorphic graphs are equal, the kernel value for
+// a pair of isomorphic graphs is equal to the kernel value of either graph
+// with itself, and by the Cauchy-Schwarz inequality the normalized kernel
+//
+//	K(G, H) / sqrt(K(G, G) K(H, H))
+//
+// reaches its maximum of one for such pairs.
+//
+// WeisfeilerLehman will panic if iterations is negative.
+func WeisfeilerLehman(graphs []graph.Undirected, iterations int) (gram [][]float64) {
+	if iterations < 0 {
+		panic("kernel: negative iteration count")
+	}
+
+	type neighborhood struct {
+		nodes []graph.Node
+		adj   [][]int
+	}
+	hoods := make([]neighborhood, len(graphs))
+	labels := make([][]int, len(graphs))
+	hist := make([]map[int]float64, len(graphs))
+	for k, g := range graphs {
+		nodes := graph.NodesOf(g.Nodes())
+		indexOf := make(map[int64]int, len(nodes))
+		for i, n := range nodes {
+			indexOf[n.ID()] = i
+		}
+		adj := make([][]int, len(nodes))
+		for i, u := range nodes {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				adj[i] = append(adj[i], indexOf[v.ID()])
+			}
+		}
+		hoods[k] = neighborhood{nodes: nodes, adj: adj}
+
+		// The initial label of every node is zero.
+		labels[k] = make([]int, len(nodes))
+		hist[k] = map[int]float64{0: float64(len(nodes))}
+	}
+
+	// dict maps the signature of a relabeling to its
+	// new label. Labels are numbered from one since
+	// zero is the initial label.
+	dict := make(map[string]int)
+	var (
+		buf  strings.Builder
+		nbrs []int
+	)
+	for it := 0; it < iterations; it++ {
+		next := make([][]int, len(graphs))
+		for k, h := range hoods {
+			next[k] = make([]int, len(h.nodes))
+			for i := range h.nodes {
+				nbrs = nbrs[:0]
+				for _, j := range h.adj[i] {
+					nbrs = append(nbrs, labels[k][j])
+				}
+				sort.Ints(nbrs)
+
+				buf.Reset()
+				buf.WriteString(strconv.Itoa(labels[k][i]))
+				buf.WriteByte(':')
+				for _, l := range nbrs {
+					buf.WriteString(strconv.Itoa(l))
+					buf.WriteByte(',')
+				}
+				sig := buf.String()
+				l, ok := dict[sig]
+				if !ok {
+					l = len(dict) + 1
+					dict[sig] = l
+				}
+				next[k][i] = l
+				hist[k][l]++
+			}
+		}
+		labels = next
+	}
+
+	gram = make([][]float64, len(graphs))
+	for i := range gram {
+		gram[i] = make([]float64, len(graphs))
+	}
+	for i := range graphs {
+		for j := i; j < len(graphs); j++ {
+			a, b := hist[i], hist[j]
+			if len(b) < len(a) {
+				a, b = b, a
+			}
+			var dot float64
+			for l, c := range a {
+				dot += c * b[l]
+			}
+			gram[i][j] = dot
+			gram[j][i] = dot
+		}
+	}
+	return gram
+}
@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kernel
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestWeisfeilerLehman(t *testing.T) {
+	path := simple.NewUndirectedGraph()
+	path.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	path.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	triangle := simple.NewUndirectedGraph()
+	triangle.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	triangle.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	triangle.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(0)})
+	graphs := []graph.Undirected{path, triangle}
+
+	for _, test := range []struct {
+		iterations int
+		want       [][]float64
+	}{
+		// Without iterations only the node counts are compared.
+		{iterations: 0, want: [][]float64{{9, 9}, {9, 9}}},
+
+		// After one iteration the path has two nodes of degree one
+		// and one of degree two and the triangle three of degree two.
+		{iterations: 1, want: [][]float64{{14, 12}, {12, 18}}},
+
+		// The labels of the second iteration refine the degree
+		// classes without splitting them.
+		{iterations: 2, want: [][]float64{{19, 12}, {12, 27}}},
+	} {
+		got := WeisfeilerLehman(graphs, test.iterations)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected Gram matrix for %d iterations: got:%v want:%v", test.iterations, got, test.want)
+		}
+	}
+}
+
+func TestWeisfeilerLehmanIsomorphic(t *testing.T) {
+	// Isomorphic graphs have the maximal normalized
+	// kernel value of one.
+	for seed := uint64(1); seed <= 10; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 15, 0.25, rand.NewSource(seed))
+		perm := rand.New(rand.NewSource(seed)).Perm(15)
+		h := simple.NewUndirectedGraph()
+		for _, n := range perm {
+			h.AddNode(simple.Node(n + 100))
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			h.SetEdge(simple.Edge{F: simple.Node(perm[e.From().ID()] + 100), T: simple.Node(perm[e.To().ID()] + 100)})
+		}
+		other := simple.NewUndirectedGraph()
+		gen.Gnp(other, 15, 0.25, rand.NewSource(seed+100))
+
+		gram := WeisfeilerLehman([]graph.Undirected{g, h, other}, 3)
+		if gram[0][1] != gram[0][0] || gram[0][1] != gram[1][1] {
+			t.Errorf("unexpected kernel values for isomorphic graphs with seed %d: %v", seed, gram)
+		}
+		for i := range gram {
+			for j := range gram {
+				if gram[i][j] != gram[j][i] {
+					t.Errorf("Gram matrix not symmetric for seed %d: %v", seed, gram)
+				}
+				if norm := gram[i][j] / math.Sqrt(gram[i][i]*gram[j][j]); norm > 1+1e-12 {
+					t.Errorf("normalized kernel value greater than one for seed %d: %v", seed, norm)
+				}
+			}
+		}
+	}
+}
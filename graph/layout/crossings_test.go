@@ -0,0 +1,148 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCrossings(t *testing.T) {
+	// Two layers joined as a crossed ladder:
+	//
+	//  0   1   2
+	//   \  |  /
+	//    \ | /
+	//  3   4   5
+	//
+	// with 0→5, 1→4 and 2→3.
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 5}, {1, 4}, {2, 3}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	layers := [][]graph.Node{
+		{simple.Node(0), simple.Node(1), simple.Node(2)},
+		{simple.Node(3), simple.Node(4), simple.Node(5)},
+	}
+	if n := Crossings(layers, g); n != 3 {
+		t.Errorf("unexpected number of crossings: got:%d want:3", n)
+	}
+
+	got := MinimizeCrossings(layers, g, 4)
+	if n := Crossings(got, g); n != 0 {
+		t.Errorf("unexpected number of crossings after minimization: got:%d want:0", n)
+	}
+	if ids := layerIDs(layers); ids[1][0] != 3 {
+		t.Errorf("input layers altered: %v", ids)
+	}
+
+	// Edges between the same nodes in both directions
+	// are drawn as one segment.
+	g.SetEdge(simple.Edge{F: simple.Node(4), T: simple.Node(1)})
+	if n := Crossings(layers, g); n != 3 {
+		t.Errorf("unexpected number of crossings with reversed edge: got:%d want:3", n)
+	}
+}
+
+func TestMinimizeCrossingsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 50; test++ {
+		g := simple.NewDirectedGraph()
+		var layers [][]graph.Node
+		id := 0
+		for l := 0; l < 2+rnd.Intn(4); l++ {
+			var layer []graph.Node
+			for i := 0; i < 1+rnd.Intn(6); i++ {
+				n := simple.Node(id)
+				g.AddNode(n)
+				layer = append(layer, n)
+				id++
+			}
+			layers = append(layers, layer)
+		}
+		for l := 1; l < len(layers); l++ {
+			for _, u := range layers[l-1] {
+				for _, v := range layers[l] {
+					if rnd.Float64() < 0.4 {
+						g.SetEdge(simple.Edge{F: u, T: v})
+					}
+				}
+			}
+		}
+
+		before := Crossings(layers, g)
+		if want := bruteForceCrossings(layers, g); before != want {
+			t.Errorf("unexpected number of crossings for test %d: got:%d want:%d", test, before, want)
+		}
+
+		got := MinimizeCrossings(layers, g, 10)
+		after := Crossings(got, g)
+		if after > before {
+			t.Errorf("minimization increased crossings for test %d: got:%d before:%d", test, after, before)
+		}
+		if want := bruteForceCrossings(got, g); after != want {
+			t.Errorf("unexpected number of crossings after minimization for test %d: got:%d want:%d", test, after, want)
+		}
+		want := layerIDs(layers)
+		for i, ids := range layerIDs(got) {
+			sort.Sort(ordered.Int64s(ids))
+			if !equalIDs(ids, want[i]) {
+				t.Errorf("layer %d not a permutation for test %d: got:%v want:%v", i, test, ids, want[i])
+			}
+		}
+	}
+}
+
+// bruteForceCrossings returns the number of crossings in the layered
+// drawing by comparing every pair of segments.
+func bruteForceCrossings(layers [][]graph.Node, g graph.Directed) int {
+	var n int
+	for l := 1; l < len(layers); l++ {
+		var segs [][2]int
+		for i, u := range layers[l-1] {
+			for j, v := range layers[l] {
+				if g.HasEdgeBetween(u.ID(), v.ID()) {
+					segs = append(segs, [2]int{i, j})
+				}
+			}
+		}
+		for a := range segs {
+			for b := a + 1; b < len(segs); b++ {
+				if (segs[a][0]-segs[b][0])*(segs[a][1]-segs[b][1]) < 0 {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+func layerIDs(layers [][]graph.Node) [][]int64 {
+	ids := make([][]int64, len(layers))
+	for i, l := range layers {
+		for _, n := range l {
+			ids[i] = append(ids[i], n.ID())
+		}
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
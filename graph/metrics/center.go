@@ -0,0 +1,173 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// Eccentricity returns the eccentricity of each node in g keyed by node ID.
+// The eccentricity of a node u is the greatest shortest path distance from u
+// to any other node of g, or +Inf if some node is not reachable from u. If g
+// implements path.Weighted, distances are path weights found by Dijkstra's
+// algorithm; otherwise they are the number of edges in each path.
+//
+// Eccentricity will panic if g has a negative edge weight.
+func Eccentricity(g graph.Graph) map[int64]float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	if u, ok := g.(graph.Undirected); ok && isUnweightedTree(u, nodes) {
+		return treeEccentricity(u, nodes)
+	}
+	ecc := make(map[int64]float64, len(nodes))
+	for _, u := range nodes {
+		paths := path.DijkstraFrom(u, g)
+		var max float64
+		for _, v := range nodes {
+			if d := paths.WeightTo(v.ID()); d > max {
+				max = d
+			}
+		}
+		ecc[u.ID()] = max
+	}
+	return ecc
+}
+
+// JordanCenter returns the center of g, the nodes of g with minimum
+// eccentricity, ordered by ID. Distances are as described for Eccentricity.
+// If no node reaches all others, every node has infinite eccentricity and
+// all nodes are returned.
+//
+// If g is an unweighted undirected tree, the center is found in linear time
+// by repeatedly removing the leaves of the tree until one or two nodes remain.
+func JordanCenter(g graph.Graph) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	if u, ok := g.(graph.Undirected); ok && isUnweightedTree(u, nodes) {
+		center := peelLeaves(u, nodes)
+		sort.Sort(ordered.ByID(center))
+		return center
+	}
+	return extremes(nodes, Eccentricity(g), func(a, b float64) bool { return a < b })
+}
+
+// Periphery returns the periphery of g, the nodes of g with maximum
+// eccentricity, ordered by ID. Distances are as described for Eccentricity.
+// If no node reaches all others, every node has infinite eccentricity and
+// all nodes are returned.
+func Periphery(g graph.Graph) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	return extremes(nodes, Eccentricity(g), func(a, b float64) bool { return a > b })
+}
+
+// extremes returns the nodes whose eccentricity is not beaten by any other
+// node according to better, ordered by ID.
+func extremes(nodes []graph.Node, ecc map[int64]float64, better func(a, b float64) bool) []graph.Node {
+	var (
+		best []graph.Node
+		e    float64
+	)
+	for _, n := range nodes {
+		switch d := ecc[n.ID()]; {
+		case best == nil || better(d, e):
+			best = append(best[:0], n)
+			e = d
+		case d == e:
+			best = append(best, n)
+		}
+	}
+	sort.Sort(ordered.ByID(best))
+	return best
+}
+
+// isUnweightedTree returns whether g is a tree that does not implement
+// path.Weighted.
+func isUnweightedTree(g graph.Undirected, nodes []graph.Node) bool {
+	if _, ok := g.(path.Weighted); ok || len(nodes) == 0 {
+		return false
+	}
+	var degrees int
+	for _, n := range nodes {
+		to := g.From(n.ID())
+		for to.Next() {
+			if to.Node().ID() == n.ID() {
+				return false
+			}
+			degrees++
+		}
+	}
+	if degrees != 2*(len(nodes)-1) {
+		return false
+	}
+	var reached int
+	var bf traverse.BreadthFirst
+	bf.Walk(g, nodes[0], func(graph.Node, int) bool {
+		reached++
+		return false
+	})
+	return reached == len(nodes)
+}
+
+// peelLeaves returns the center of the tree g by repeatedly removing its
+// leaves until at most two nodes remain.
+func peelLeaves(g graph.Undirected, nodes []graph.Node) []graph.Node {
+	degree := make(map[int64]int, len(nodes))
+	var leaves []graph.Node
+	for _, n := range nodes {
+		degree[n.ID()] = g.From(n.ID()).Len()
+		if degree[n.ID()] <= 1 {
+			leaves = append(leaves, n)
+		}
+	}
+	remaining := len(nodes)
+	for remaining > 2 {
+		remaining -= len(leaves)
+		var next []graph.Node
+		for _, u := range leaves {
+			to := g.From(u.ID())
+			for to.Next() {
+				v := to.Node()
+				degree[v.ID()]--
+				if degree[v.ID()] == 1 {
+					next = append(next, v)
+				}
+			}
+		}
+		leaves = next
+	}
+	return leaves
+}
+
+// treeEccentricity returns the eccentricities of the nodes of the tree g.
+// The furthest node from any node of a tree is an end of a diameter, so the
+// eccentricities are found by breadth-first searches from the two ends of a
+// diameter.
+func treeEccentricity(g graph.Undirected, nodes []graph.Node) map[int64]float64 {
+	depths := func(from graph.Node) (map[int64]int, graph.Node) {
+		depth := make(map[int64]int, len(nodes))
+		far := from
+		var bf traverse.BreadthFirst
+		bf.Walk(g, from, func(n graph.Node, d int) bool {
+			depth[n.ID()] = d
+			if d > depth[far.ID()] {
+				far = n
+			}
+			return false
+		})
+		return depth, far
+	}
+	_, a := depths(nodes[0])
+	da, b := depths(a)
+	db, _ := depths(b)
+	ecc := make(map[int64]float64, len(nodes))
+	for _, n := range nodes {
+		ecc[n.ID()] = math.Max(float64(da[n.ID()]), float64(db[n.ID()]))
+	}
+	return ecc
+}
@@ -0,0 +1,151 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCenterPeriphery(t *testing.T) {
+	// A path 0-1-2-3-4 with a pendant 5 on node 3.
+	g := simple.NewUndirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(2), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(4)},
+		{F: simple.Node(3), T: simple.Node(5)},
+	} {
+		g.SetEdge(e)
+	}
+	wantEcc := map[int64]float64{0: 4, 1: 3, 2: 2, 3: 3, 4: 4, 5: 4}
+	if got := Eccentricity(g); !reflect.DeepEqual(got, wantEcc) {
+		t.Errorf("unexpected eccentricity: got:%v want:%v", got, wantEcc)
+	}
+	if got, want := ids(JordanCenter(g)), []int64{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected center: got:%v want:%v", got, want)
+	}
+	if got, want := ids(Periphery(g)), []int64{0, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected periphery: got:%v want:%v", got, want)
+	}
+
+	g.RemoveEdge(3, 5)
+	g.SetEdge(simple.Edge{F: simple.Node(4), T: simple.Node(5)})
+	if got, want := ids(JordanCenter(g)), []int64{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected center of even path: got:%v want:%v", got, want)
+	}
+}
+
+func TestCenterRandom(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		src := rand.NewSource(seed)
+		rnd := rand.New(src)
+
+		tree := simple.NewUndirectedGraph()
+		tree.AddNode(simple.Node(0))
+		for i := 1; i < 40; i++ {
+			tree.SetEdge(simple.Edge{F: simple.Node(rnd.Intn(i)), T: simple.Node(i)})
+		}
+
+		undirected := simple.NewUndirectedGraph()
+		gen.Gnp(undirected, 30, 0.15, src)
+
+		directed := simple.NewDirectedGraph()
+		gen.Gnp(directed, 20, 0.3, src)
+
+		weighted := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		for _, e := range graph.EdgesOf(undirected.Edges()) {
+			weighted.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: float64(1 + rnd.Intn(5))})
+		}
+		for _, n := range graph.NodesOf(undirected.Nodes()) {
+			if weighted.Node(n.ID()) == nil {
+				weighted.AddNode(n)
+			}
+		}
+
+		for _, test := range []struct {
+			name string
+			g    graph.Graph
+		}{
+			{name: "tree", g: tree},
+			{name: "undirected", g: undirected},
+			{name: "directed", g: directed},
+			{name: "weighted", g: weighted},
+		} {
+			want := bruteForceEccentricity(test.g)
+			got := Eccentricity(test.g)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected eccentricity for %s with seed %d", test.name, seed)
+				continue
+			}
+
+			min, max := math.Inf(1), math.Inf(-1)
+			for _, e := range want {
+				min = math.Min(min, e)
+				max = math.Max(max, e)
+			}
+			for _, c := range JordanCenter(test.g) {
+				if want[c.ID()] != min {
+					t.Errorf("node %d in center for %s with seed %d has eccentricity %v, not %v",
+						c.ID(), test.name, seed, want[c.ID()], min)
+				}
+			}
+			for _, p := range Periphery(test.g) {
+				if want[p.ID()] != max {
+					t.Errorf("node %d in periphery for %s with seed %d has eccentricity %v, not %v",
+						p.ID(), test.name, seed, want[p.ID()], max)
+				}
+			}
+			var nCenter, nPeriphery int
+			for _, e := range want {
+				if e == min {
+					nCenter++
+				}
+				if e == max {
+					nPeriphery++
+				}
+			}
+			if got := len(JordanCenter(test.g)); got != nCenter {
+				t.Errorf("unexpected center size for %s with seed %d: got:%d want:%d", test.name, seed, got, nCenter)
+			}
+			if got := len(Periphery(test.g)); got != nPeriphery {
+				t.Errorf("unexpected periphery size for %s with seed %d: got:%d want:%d", test.name, seed, got, nPeriphery)
+			}
+		}
+	}
+}
+
+// bruteForceEccentricity returns the eccentricities of g computed
+// from all pairs shortest paths.
+func bruteForceEccentricity(g graph.Graph) map[int64]float64 {
+	paths, _ := path.FloydWarshall(g)
+	nodes := graph.NodesOf(g.Nodes())
+	ecc := make(map[int64]float64)
+	for _, u := range nodes {
+		var max float64
+		for _, v := range nodes {
+			max = math.Max(max, paths.Weight(u.ID(), v.ID()))
+		}
+		ecc[u.ID()] = max
+	}
+	return ecc
+}
+
+func ids(nodes []graph.Node) []int64 {
+	var ids []int64
+	for _, n := range nodes {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
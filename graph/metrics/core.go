@@ -0,0 +1,47 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// CoreNumbers returns the core number, or coreness, of each node in g keyed
+// by node ID. The core number of a node is the largest k such that the node
+// is in the k-core of g, the maximal subgraph in which every node has degree
+// at least k.
+func CoreNumbers(g graph.Undirected) map[int64]int {
+	_, shells := topo.DegeneracyOrdering(g)
+	core := make(map[int64]int)
+	for k, shell := range shells {
+		for _, n := range shell {
+			core[n.ID()] = k
+		}
+	}
+	return core
+}
+
+// KShells returns the k-shells of g keyed by k. The k-shell of g holds the
+// nodes with a core number of exactly k, so the k-shells partition the nodes
+// of g. Only non-empty shells are included and the nodes of each shell are
+// ordered by ID.
+func KShells(g graph.Undirected) map[int][]graph.Node {
+	_, cores := topo.DegeneracyOrdering(g)
+	shells := make(map[int][]graph.Node)
+	for k, c := range cores {
+		if len(c) == 0 {
+			continue
+		}
+		shell := make([]graph.Node, len(c))
+		copy(shell, c)
+		sort.Sort(ordered.ByID(shell))
+		shells[k] = shell
+	}
+	return shells
+}
@@ -0,0 +1,127 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestKShells(t *testing.T) {
+	// A 4-clique {0,1,2,3} with a triangle {3,4,5} hanging off node 3,
+	// a pendant node 6 and an isolated node 7.
+	g := simple.NewUndirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(0), T: simple.Node(2)},
+		{F: simple.Node(0), T: simple.Node(3)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(1), T: simple.Node(3)},
+		{F: simple.Node(2), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(4)},
+		{F: simple.Node(3), T: simple.Node(5)},
+		{F: simple.Node(4), T: simple.Node(5)},
+		{F: simple.Node(5), T: simple.Node(6)},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(simple.Node(7))
+
+	want := map[int][]int64{
+		0: {7},
+		1: {6},
+		2: {4, 5},
+		3: {0, 1, 2, 3},
+	}
+	got := make(map[int][]int64)
+	for k, shell := range KShells(g) {
+		for _, n := range shell {
+			got[k] = append(got[k], n.ID())
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected k-shells: got:%v want:%v", got, want)
+	}
+
+	wantCore := map[int64]int{0: 3, 1: 3, 2: 3, 3: 3, 4: 2, 5: 2, 6: 1, 7: 0}
+	if gotCore := CoreNumbers(g); !reflect.DeepEqual(gotCore, wantCore) {
+		t.Errorf("unexpected core numbers: got:%v want:%v", gotCore, wantCore)
+	}
+}
+
+func TestKShellsPartition(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 60, 0.1, rand.NewSource(seed))
+
+		want := bruteForceCoreNumbers(g)
+		if got := CoreNumbers(g); !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected core numbers for seed %d: got:%v want:%v", seed, got, want)
+		}
+
+		seen := make(map[int64]bool)
+		for k, shell := range KShells(g) {
+			if len(shell) == 0 {
+				t.Errorf("empty %d-shell for seed %d", k, seed)
+			}
+			for i, n := range shell {
+				if i > 0 && shell[i-1].ID() >= n.ID() {
+					t.Errorf("%d-shell not ordered by ID for seed %d", k, seed)
+				}
+				if seen[n.ID()] {
+					t.Errorf("node %d in more than one shell for seed %d", n.ID(), seed)
+				}
+				seen[n.ID()] = true
+				if want[n.ID()] != k {
+					t.Errorf("node %d in %d-shell for seed %d but has core number %d", n.ID(), k, seed, want[n.ID()])
+				}
+			}
+		}
+		if len(seen) != g.Nodes().Len() {
+			t.Errorf("shells do not cover all nodes for seed %d: got:%d want:%d", seed, len(seen), g.Nodes().Len())
+		}
+	}
+}
+
+// bruteForceCoreNumbers returns the core numbers of g by repeatedly
+// peeling nodes of degree less than k from the graph for increasing k.
+func bruteForceCoreNumbers(g graph.Undirected) map[int64]int {
+	core := make(map[int64]int)
+	remaining := make(map[int64]bool)
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		remaining[n.ID()] = true
+	}
+	degree := func(id int64) int {
+		var d int
+		for _, v := range graph.NodesOf(g.From(id)) {
+			if remaining[v.ID()] {
+				d++
+			}
+		}
+		return d
+	}
+	for k := 0; len(remaining) != 0; k++ {
+		for {
+			var peeled bool
+			for id := range remaining {
+				if degree(id) <= k {
+					core[id] = k
+					delete(remaining, id)
+					peeled = true
+				}
+			}
+			if !peeled {
+				break
+			}
+		}
+	}
+	return core
+}
@@ -0,0 +1,144 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DegreeSequence returns the degrees of the nodes of g sorted in descending
+// order. The degree of a node is the number of nodes adjacent to it, with a
+// self edge contributing two to the degree of its node.
+func DegreeSequence(g graph.Undirected) []int {
+	return sequence(g, undirectedDegree(g))
+}
+
+// InDegreeSequence returns the in-degrees of the nodes of g sorted in
+// descending order. The in-degree of a node is the number of nodes with an
+// edge to it.
+func InDegreeSequence(g graph.Directed) []int {
+	return sequence(g, func(id int64) int { return count(g.To(id)) })
+}
+
+// OutDegreeSequence returns the out-degrees of the nodes of g sorted in
+// descending order. The out-degree of a node is the number of nodes with an
+// edge from it.
+func OutDegreeSequence(g graph.Directed) []int {
+	return sequence(g, func(id int64) int { return count(g.From(id)) })
+}
+
+// DegreeDistribution returns the number of nodes of g with each degree,
+// keyed by degree. Degrees are calculated as described for DegreeSequence.
+func DegreeDistribution(g graph.Undirected) map[int]int {
+	return distribution(g, undirectedDegree(g))
+}
+
+// InDegreeDistribution returns the number of nodes of g with each in-degree,
+// keyed by in-degree.
+func InDegreeDistribution(g graph.Directed) map[int]int {
+	return distribution(g, func(id int64) int { return count(g.To(id)) })
+}
+
+// OutDegreeDistribution returns the number of nodes of g with each out-degree,
+// keyed by out-degree.
+func OutDegreeDistribution(g graph.Directed) map[int]int {
+	return distribution(g, func(id int64) int { return count(g.From(id)) })
+}
+
+// undirectedDegree returns a function returning the degree of a node in g.
+func undirectedDegree(g graph.Undirected) func(id int64) int {
+	return func(id int64) int {
+		var d int
+		to := g.From(id)
+		for to.Next() {
+			d++
+			if to.Node().ID() == id {
+				d++
+			}
+		}
+		return d
+	}
+}
+
+// count returns the number of nodes in it.
+func count(it graph.Nodes) int {
+	n := it.Len()
+	if n >= 0 {
+		return n
+	}
+	n = 0
+	for it.Next() {
+		n++
+	}
+	return n
+}
+
+// sequence returns the degrees of the nodes of g sorted in descending order.
+func sequence(g graph.Graph, degree func(id int64) int) []int {
+	nodes := g.Nodes()
+	var seq []int
+	for nodes.Next() {
+		seq = append(seq, degree(nodes.Node().ID()))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(seq)))
+	return seq
+}
+
+// distribution returns the number of nodes of g with each degree.
+func distribution(g graph.Graph, degree func(id int64) int) map[int]int {
+	dist := make(map[int]int)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		dist[degree(nodes.Node().ID())]++
+	}
+	return dist
+}
+
+// IsGraphical returns whether seq is the degree sequence of a simple
+// undirected graph, determined using the Erdős–Gallai theorem. The order
+// of elements in seq is not significant and seq is not modified.
+func IsGraphical(seq []int) bool {
+	d := make([]int, len(seq))
+	copy(d, seq)
+	sort.Sort(sort.Reverse(sort.IntSlice(d)))
+
+	n := len(d)
+	var total int
+	for _, v := range d {
+		if v < 0 || v >= n {
+			return false
+		}
+		total += v
+	}
+	if total%2 != 0 {
+		return false
+	}
+
+	// prefix[i] is the sum of the i largest degrees.
+	prefix := make([]int, n+1)
+	for i, v := range d {
+		prefix[i+1] = prefix[i] + v
+	}
+	// c is the number of degrees that are at least k.
+	c := n
+	for k := 1; k <= n; k++ {
+		for c > 0 && d[c-1] < k {
+			c--
+		}
+		// The sum of min(d_i, k) over i > k is k for each
+		// of the degrees at positions k+1 through c and the
+		// degree itself for the remaining positions.
+		rest := prefix[n] - prefix[k]
+		if c > k {
+			rest = (c-k)*k + prefix[n] - prefix[c]
+		}
+		if prefix[k] > k*(k-1)+rest {
+			return false
+		}
+	}
+	return true
+}
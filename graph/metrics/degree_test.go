@@ -0,0 +1,148 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDegreeSequence(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(4))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+	if got, want := DegreeSequence(g), []int{3, 2, 2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected degree sequence: got:%v want:%v", got, want)
+	}
+	if got, want := DegreeDistribution(g), map[int]int{0: 1, 1: 1, 2: 2, 3: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected degree distribution: got:%v want:%v", got, want)
+	}
+	if !IsGraphical(DegreeSequence(g)) {
+		t.Error("degree sequence of a simple graph is not graphical")
+	}
+
+	if got := DegreeSequence(simple.NewUndirectedGraph()); got != nil {
+		t.Errorf("unexpected degree sequence for empty graph: got:%v", got)
+	}
+}
+
+func TestDegreeSequenceSelfLine(t *testing.T) {
+	g := multi.NewUndirectedGraph()
+	g.SetLine(multi.Line{F: multi.Node(0), T: multi.Node(0), UID: 0})
+	g.SetLine(multi.Line{F: multi.Node(0), T: multi.Node(1), UID: 1})
+
+	if got, want := DegreeSequence(g), []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected degree sequence: got:%v want:%v", got, want)
+	}
+}
+
+func TestDirectedDegreeSequence(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(2)})
+
+	if got, want := InDegreeSequence(g), []int{3, 1, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected in-degree sequence: got:%v want:%v", got, want)
+	}
+	if got, want := OutDegreeSequence(g), []int{3, 1, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected out-degree sequence: got:%v want:%v", got, want)
+	}
+	if got, want := InDegreeDistribution(g), map[int]int{0: 1, 1: 2, 3: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected in-degree distribution: got:%v want:%v", got, want)
+	}
+	if got, want := OutDegreeDistribution(g), map[int]int{0: 1, 1: 2, 3: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected out-degree distribution: got:%v want:%v", got, want)
+	}
+}
+
+var isGraphicalTests = []struct {
+	seq  []int
+	want bool
+}{
+	{seq: nil, want: true},
+	{seq: []int{0}, want: true},
+	{seq: []int{1}, want: false},
+	{seq: []int{1, 1}, want: true},
+	{seq: []int{2, 2, 2}, want: true},
+	{seq: []int{3, 3, 3, 3}, want: true},
+	{seq: []int{3, 3, 1, 1}, want: false},
+	{seq: []int{1, 1, 1}, want: false},
+	{seq: []int{-1, 1}, want: false},
+	{seq: []int{4, 1, 1, 1, 1}, want: true},
+	{seq: []int{1, 3, 1, 1, 2}, want: true},
+}
+
+func TestIsGraphical(t *testing.T) {
+	for _, test := range isGraphicalTests {
+		orig := append([]int(nil), test.seq...)
+		got := IsGraphical(test.seq)
+		if got != test.want {
+			t.Errorf("unexpected result for %v: got:%t want:%t", test.seq, got, test.want)
+		}
+		if !reflect.DeepEqual(orig, test.seq) {
+			t.Errorf("sequence modified: got:%v want:%v", test.seq, orig)
+		}
+	}
+}
+
+// TestIsGraphicalExhaustive checks IsGraphical against the degree
+// sequences of all simple graphs of up to five nodes.
+func TestIsGraphicalExhaustive(t *testing.T) {
+	for n := 1; n <= 5; n++ {
+		var pairs [][2]int
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+		graphical := make(map[string]bool)
+		for set := 0; set < 1<<uint(len(pairs)); set++ {
+			deg := make([]int, n)
+			for k, p := range pairs {
+				if set&(1<<uint(k)) != 0 {
+					deg[p[0]]++
+					deg[p[1]]++
+				}
+			}
+			sort.Ints(deg)
+			graphical[fmt.Sprint(deg)] = true
+		}
+
+		seq := make([]int, n)
+		for {
+			sorted := append([]int(nil), seq...)
+			sort.Ints(sorted)
+			want := graphical[fmt.Sprint(sorted)]
+			if got := IsGraphical(seq); got != want {
+				t.Errorf("unexpected result for %v: got:%t want:%t", seq, got, want)
+			}
+
+			// Advance to the next sequence in [0, n]^n.
+			i := 0
+			for ; i < n; i++ {
+				seq[i]++
+				if seq[i] <= n {
+					break
+				}
+				seq[i] = 0
+			}
+			if i == n {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// DensestSubgraphGreedy returns the nodes of a dense subgraph of the
+// undirected graph g and its density, the number of edges of the subgraph
+// divided by its number of nodes. The density of the returned subgraph is at
+// least half the maximum density of any subgraph of g. Self edges are ignored.
+// If g has no edges, DensestSubgraphGreedy returns nil and zero.
+//
+// The subgraph is found by Charikar's greedy peeling algorithm
+// doi:10.1007/3-540-44436-X_10, which repeatedly removes a node of minimum
+// degree, as in the degeneracy ordering of g, and returns the densest of the
+// subgraphs seen. For an exact maximum density subgraph see
+// flow.MaxDensitySubgraph. The returned nodes are ordered by ID.
+func DensestSubgraphGreedy(g graph.Undirected) (nodes []graph.Node, density float64) {
+	order, _ := topo.DegeneracyOrdering(g)
+	// The degeneracy ordering is the reverse of the
+	// order in which nodes were removed.
+	ordered.Reverse(order)
+
+	var m int
+	for _, u := range order {
+		to := g.From(u.ID())
+		for to.Next() {
+			if to.Node().ID() != u.ID() {
+				m++
+			}
+		}
+	}
+	m /= 2
+	if m == 0 {
+		return nil, 0
+	}
+
+	removed := make(map[int64]bool, len(order))
+	best := 0
+	density = float64(m) / float64(len(order))
+	for i, u := range order[:len(order)-1] {
+		uid := u.ID()
+		removed[uid] = true
+		to := g.From(uid)
+		for to.Next() {
+			if !removed[to.Node().ID()] {
+				m--
+			}
+		}
+		if d := float64(m) / float64(len(order)-i-1); d > density {
+			best = i + 1
+			density = d
+		}
+	}
+
+	nodes = make([]graph.Node, len(order)-best)
+	copy(nodes, order[best:])
+	sort.Sort(ordered.ByID(nodes))
+	return nodes, density
+}
@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/flow"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDensestSubgraphGreedy(t *testing.T) {
+	// A 5-clique {0,1,2,3,4} joined by a path 4-5-6-7
+	// to a triangle {7,8,9}.
+	g := simple.NewUndirectedGraph()
+	for u := 0; u < 5; u++ {
+		for v := u + 1; v < 5; v++ {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	for _, e := range []simple.Edge{
+		{F: simple.Node(4), T: simple.Node(5)},
+		{F: simple.Node(5), T: simple.Node(6)},
+		{F: simple.Node(6), T: simple.Node(7)},
+		{F: simple.Node(7), T: simple.Node(8)},
+		{F: simple.Node(8), T: simple.Node(9)},
+		{F: simple.Node(9), T: simple.Node(7)},
+	} {
+		g.SetEdge(e)
+	}
+	nodes, density := DensestSubgraphGreedy(g)
+	if got, want := ids(nodes), []int64{0, 1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected nodes: got:%v want:%v", got, want)
+	}
+	if density != 2 {
+		t.Errorf("unexpected density: got:%v want:2", density)
+	}
+
+	g = simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	nodes, density = DensestSubgraphGreedy(g)
+	if nodes != nil || density != 0 {
+		t.Errorf("unexpected result for edgeless graph: got:%v density:%v", ids(nodes), density)
+	}
+}
+
+func TestDensestSubgraphGreedyApproximation(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 2 + rnd.Intn(30)
+		p := rnd.Float64()
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < p {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		nodes, density := DensestSubgraphGreedy(g)
+		_, exact := flow.MaxDensitySubgraph(g)
+		if density > exact || 2*density < exact {
+			t.Errorf("density outside approximation bound for test %d: got:%v exact:%v", test, density, exact)
+		}
+		if exact == 0 {
+			continue
+		}
+
+		in := make(map[int64]bool)
+		for _, u := range nodes {
+			in[u.ID()] = true
+		}
+		var m int
+		for _, u := range nodes {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				if in[v.ID()] && u.ID() < v.ID() {
+					m++
+				}
+			}
+		}
+		if d := float64(m) / float64(len(nodes)); d != density {
+			t.Errorf("subgraph density does not match for test %d: got:%v want:%v", test, d, density)
+		}
+	}
+}
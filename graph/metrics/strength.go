@@ -0,0 +1,73 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import "gonum.org/v1/gonum/graph"
+
+// Strength returns the strength, or weighted degree, of each node in g. The
+// strength of a node is the sum of the weights of the edges incident to it.
+// The weight of an edge is obtained from the WeightedEdge method of g.
+//
+// A self edge contributes its weight twice to the strength of its node, in
+// the same way that a self edge contributes two to the degree of a node in an
+// undirected graph. With this convention the sum of the strengths of all the
+// nodes is twice the total edge weight of g.
+func Strength(g graph.WeightedUndirected) map[int64]float64 {
+	s := make(map[int64]float64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		var sum float64
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			w := g.WeightedEdge(uid, vid).Weight()
+			if vid == uid {
+				w *= 2
+			}
+			sum += w
+		}
+		s[uid] = sum
+	}
+	return s
+}
+
+// InStrength returns the in-strength of each node in g, the sum of the
+// weights of the edges ending at the node. The weight of an edge is obtained
+// from the WeightedEdge method of g. A self edge contributes its weight once
+// to both the in-strength and the out-strength of its node.
+func InStrength(g graph.WeightedDirected) map[int64]float64 {
+	s := make(map[int64]float64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		vid := nodes.Node().ID()
+		var sum float64
+		from := g.To(vid)
+		for from.Next() {
+			sum += g.WeightedEdge(from.Node().ID(), vid).Weight()
+		}
+		s[vid] = sum
+	}
+	return s
+}
+
+// OutStrength returns the out-strength of each node in g, the sum of the
+// weights of the edges starting from the node. The weight of an edge is
+// obtained from the WeightedEdge method of g. A self edge contributes its
+// weight once to both the in-strength and the out-strength of its node.
+func OutStrength(g graph.WeightedDirected) map[int64]float64 {
+	s := make(map[int64]float64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		var sum float64
+		to := g.From(uid)
+		for to.Next() {
+			sum += g.WeightedEdge(uid, to.Node().ID()).Weight()
+		}
+		s[uid] = sum
+	}
+	return s
+}
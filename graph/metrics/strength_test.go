@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestStrength(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(3))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 2.5})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: -1})
+
+	got := Strength(g)
+	want := map[int64]float64{0: 3.5, 1: 0, 2: 1.5, 3: 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected strength: got:%v want:%v", got, want)
+	}
+}
+
+func TestStrengthSelfLine(t *testing.T) {
+	g := multi.NewWeightedUndirectedGraph()
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(0), T: multi.Node(0), W: 2, UID: 0})
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(0), T: multi.Node(1), W: 1, UID: 1})
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(0), T: multi.Node(1), W: 3, UID: 2})
+
+	got := Strength(g)
+	want := map[int64]float64{0: 8, 1: 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected strength: got:%v want:%v", got, want)
+	}
+	var sum float64
+	for _, s := range got {
+		sum += s
+	}
+	if sum != 2*(2+1+3) {
+		t.Errorf("unexpected total strength: got:%v want:%v", sum, 2*(2+1+3))
+	}
+}
+
+func TestDirectedStrength(t *testing.T) {
+	g := multi.NewWeightedDirectedGraph()
+	g.AddNode(multi.Node(3))
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(0), T: multi.Node(0), W: 2, UID: 0})
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(0), T: multi.Node(1), W: 1, UID: 1})
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(1), T: multi.Node(2), W: 4, UID: 2})
+	g.SetWeightedLine(multi.WeightedLine{F: multi.Node(2), T: multi.Node(0), W: 0.5, UID: 3})
+
+	gotIn := InStrength(g)
+	wantIn := map[int64]float64{0: 2.5, 1: 1, 2: 4, 3: 0}
+	if !reflect.DeepEqual(gotIn, wantIn) {
+		t.Errorf("unexpected in-strength: got:%v want:%v", gotIn, wantIn)
+	}
+	gotOut := OutStrength(g)
+	wantOut := map[int64]float64{0: 3, 1: 4, 2: 0.5, 3: 0}
+	if !reflect.DeepEqual(gotOut, wantOut) {
+		t.Errorf("unexpected out-strength: got:%v want:%v", gotOut, wantOut)
+	}
+}
@@ -38,6 +38,152 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	return pageRankSparse(g, damp, tol)
 }
 
+// PageRankOption is a functional option for PageRankIter.
+type PageRankOption func(*pageRankOptions)
+
+type pageRankOptions struct {
+	tol     float64
+	maxIter int
+	initial map[int64]float64
+}
+
+// PageRankTolerance sets the convergence tolerance of PageRankIter to tol.
+// Iteration stops when the L1 norm of the difference between successive score
+// vectors is below tol. Without a PageRankTolerance option, the tolerance is
+// 1e-10.
+func PageRankTolerance(tol float64) PageRankOption {
+	return func(o *pageRankOptions) { o.tol = tol }
+}
+
+// PageRankMaxIterations sets the maximum number of iterations performed by
+// PageRankIter to n. If n is zero or less, the number of iterations is not
+// limited, which is the default.
+func PageRankMaxIterations(n int) PageRankOption {
+	return func(o *pageRankOptions) { o.maxIter = n }
+}
+
+// PageRankInitialScores sets the starting score vector of PageRankIter to
+// scores, keyed by node ID. Nodes without a starting score are given the mean
+// of the provided scores of the other nodes and the vector is normalized to
+// sum to one. This allows a previous result to be used to warm start the
+// iteration on a graph that has changed slightly. Without a
+// PageRankInitialScores option, all nodes start with the same score.
+func PageRankInitialScores(scores map[int64]float64) PageRankOption {
+	return func(o *pageRankOptions) { o.initial = scores }
+}
+
+// PageRankIter returns the PageRank weights for nodes of the directed graph g
+// using the given damping factor, keyed on the graph node IDs, along with the
+// number of iterations performed and the L1 norm of the difference between
+// the last two iterates. Iteration is controlled by the PageRankTolerance and
+// PageRankMaxIterations options and may be warm started with the
+// PageRankInitialScores option.
+// If g is a graph.WeightedDirected, an edge-weighted PageRank is calculated.
+func PageRankIter(g graph.Directed, damp float64, opts ...PageRankOption) (scores map[int64]float64, iters int, residual float64) {
+	o := pageRankOptions{tol: 1e-10}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return map[int64]float64{}, 0, 0
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// The iteration follows pageRankSparse and
+	// edgeWeightedPageRankSparse.
+	wg, weighted := g.(graph.WeightedDirected)
+	m := make(rowCompressedMatrix, len(nodes))
+	var dangling compressedRow
+	df := damp / float64(len(nodes))
+	for j, u := range nodes {
+		to := graph.NodesOf(g.From(u.ID()))
+		var z float64
+		for _, v := range to {
+			if !weighted {
+				z++
+			} else if w, ok := wg.Weight(u.ID(), v.ID()); ok {
+				z += w
+			}
+		}
+		if z == 0 {
+			dangling.addTo(j, df)
+			continue
+		}
+		for _, v := range to {
+			w := 1.0
+			if weighted {
+				var ok bool
+				w, ok = wg.Weight(u.ID(), v.ID())
+				if !ok {
+					continue
+				}
+			}
+			m.addTo(indexOf[v.ID()], j, (w*damp)/z)
+		}
+	}
+
+	vec := make([]float64, len(nodes))
+	if o.initial == nil {
+		for i := range vec {
+			vec[i] = 1 / float64(len(nodes))
+		}
+	} else {
+		var sum float64
+		var missing []int
+		for i, n := range nodes {
+			s, ok := o.initial[n.ID()]
+			if !ok {
+				missing = append(missing, i)
+				continue
+			}
+			vec[i] = s
+			sum += s
+		}
+		mean := 1 / float64(len(nodes))
+		if known := len(nodes) - len(missing); known != 0 {
+			mean = sum / float64(known)
+		}
+		for _, i := range missing {
+			vec[i] = mean
+			sum += mean
+		}
+		if sum != 0 {
+			floats.Scale(1/sum, vec)
+		}
+	}
+	v := mat.NewVecDense(len(nodes), vec)
+	last := make([]float64, len(nodes))
+	lastV := mat.NewVecDense(len(nodes), last)
+
+	dt := (1 - damp) / float64(len(nodes))
+	for o.maxIter <= 0 || iters < o.maxIter {
+		lastV, v = v, lastV
+		vec, last = last, vec
+
+		m.mulVecUnitary(v, lastV)          // First term of the G matrix equation;
+		with := dangling.dotUnitary(lastV) // Second term;
+		away := onesDotUnitary(dt, lastV)  // Last term.
+
+		floats.AddConst(with+away, vec)
+		iters++
+		residual = floats.Distance(vec, last, 1)
+		if residual < o.tol {
+			break
+		}
+	}
+
+	scores = make(map[int64]float64, len(nodes))
+	for i, r := range vec {
+		scores[nodes[i].ID()] = r
+	}
+	return scores, iters, residual
+}
+
 // edgeWeightedPageRank returns the PageRank weights for nodes of the weighted directed graph g
 // using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
@@ -127,6 +127,54 @@ func TestPageRankSparse(t *testing.T) {
 	}
 }
 
+func TestPageRankIter(t *testing.T) {
+	for i, test := range pageRankTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got, iters, residual := PageRankIter(g, test.damp, PageRankTolerance(test.tol))
+		if iters == 0 || residual >= test.tol {
+			t.Errorf("unexpected convergence for test %d: iters:%d residual:%v", i, iters, residual)
+		}
+		prec := 1 - int(math.Log10(test.wantTol))
+		for n := range test.g {
+			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
+				t.Errorf("unexpected PageRank result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, prec), orderedFloats(test.want, prec))
+				break
+			}
+		}
+
+		_, iters, capped := PageRankIter(g, test.damp, PageRankTolerance(0), PageRankMaxIterations(3))
+		if iters != 3 || capped == 0 {
+			t.Errorf("unexpected capped iteration for test %d: iters:%d residual:%v", i, iters, capped)
+		}
+
+		// Warm starting from the result after adding an edge
+		// converges faster than a cold start.
+		g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+		cold, coldIters, _ := PageRankIter(g, test.damp, PageRankTolerance(test.tol))
+		warm, warmIters, _ := PageRankIter(g, test.damp, PageRankTolerance(test.tol), PageRankInitialScores(got))
+		if warmIters >= coldIters {
+			t.Errorf("warm start did not reduce iterations for test %d: warm:%d cold:%d", i, warmIters, coldIters)
+		}
+		for n := range test.g {
+			if !floats.EqualWithinAbsOrRel(warm[int64(n)], cold[int64(n)], test.wantTol, test.wantTol) {
+				t.Errorf("unexpected warm started PageRank result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(warm, prec), orderedFloats(cold, prec))
+				break
+			}
+		}
+	}
+}
+
 var edgeWeightedPageRankTests = []struct {
 	g            []set
 	self, absent float64
@@ -244,6 +292,37 @@ func TestEdgeWeightedPageRankSparse(t *testing.T) {
 	}
 }
 
+func TestEdgeWeightedPageRankIter(t *testing.T) {
+	for i, test := range edgeWeightedPageRankTests {
+		g := simple.NewWeightedDirectedGraph(test.self, test.absent)
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			ws, ok := test.edges[u]
+			if !ok {
+				t.Errorf("edges not found for %v", u)
+			}
+
+			for v := range e {
+				if w, ok := ws[v]; ok {
+					g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(u), simple.Node(v), w))
+				}
+			}
+		}
+		got, _, _ := PageRankIter(g, test.damp, PageRankTolerance(test.tol))
+		prec := 1 - int(math.Log10(test.wantTol))
+		for n := range test.g {
+			if !floats.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
+				t.Errorf("unexpected PageRank result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, prec), orderedFloats(test.want, prec))
+				break
+			}
+		}
+	}
+}
+
 func orderedFloats(w map[int64]float64, prec int) []keyFloatVal {
 	o := make(orderedFloatsMap, 0, len(w))
 	for k, v := range w {
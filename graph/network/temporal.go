@@ -0,0 +1,133 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/temporal"
+)
+
+// TemporalBetweenness returns the non-zero temporal betweenness centrality for
+// nodes in the sequence of unweighted graphs held by snaps.
+//
+//	C_T(v) = \sum_{s ≠ v ≠ t ∈ V} (\sigma_{st}(v) / \sigma_{st})
+//
+// where V is the set of nodes present at any time step and \sigma_{st} and
+// \sigma_{st}(v) are the number of shortest time-respecting paths from s to t,
+// and the subset of those paths containing v respectively.
+//
+// A time-respecting path is a sequence of edges e_1, e_2, ..., e_k with each
+// e_i traversed at a time step t_i at which it is present in snaps, the head of
+// e_i being the tail of e_{i+1}, and t_1 < t_2 < ... < t_k, so at most one edge
+// is traversed per time step and a path may wait at a node for any number of
+// steps. The path may start at any time step. A shortest time-respecting path
+// from s to t is one with the fewest edges. Paths are distinguished by their
+// sequence of edges and time steps, so two paths through the same nodes that
+// traverse an edge at different times are counted separately. Edges of
+// undirected graphs may be traversed in either direction, but since
+// time-respecting paths are not reversible, each ordered pair of end nodes is
+// counted and the values are not halved as they are for static betweenness.
+//
+// TemporalBetweenness performs a breadth-first search from each node over the
+// (node, time) states of the sequence, taking time O(|V|·T·M) where T is the
+// number of time steps and M is the total number of edges over all steps.
+func TemporalBetweenness(snaps *temporal.Snapshots) map[int64]float64 {
+	// The search is Brandes' algorithm run over the time-expanded graph
+	// whose vertices are the states (v, t) of having arrived at v with an
+	// edge traversed at time t. Shortest time-respecting paths never visit
+	// a node twice, and every state on a shortest path to t is reached by
+	// a shortest path to that state, so the dependencies of the states can
+	// be accumulated in reverse breadth-first order.
+
+	// adj[t][u] holds the nodes reachable from u by an edge at time t.
+	var (
+		adj   []map[int64][]int64
+		nodes []int64
+		seen  = make(map[int64]bool)
+	)
+	snaps.Each(func(t int, g graph.Graph) {
+		a := make(map[int64][]int64)
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			uid := u.ID()
+			if !seen[uid] {
+				seen[uid] = true
+				nodes = append(nodes, uid)
+			}
+			for _, v := range graph.NodesOf(g.From(uid)) {
+				a[uid] = append(a[uid], v.ID())
+			}
+		}
+		adj = append(adj, a)
+	})
+
+	cb := make(map[int64]float64)
+	for _, s := range nodes {
+		start := temporalState{id: s, t: -1}
+		dist := map[temporalState]int{start: 0}
+		sigma := map[temporalState]float64{start: 1}
+		pred := make(map[temporalState][]temporalState)
+		order := []temporalState{start}
+		best := make(map[int64]int)
+		for i := 0; i < len(order); i++ {
+			x := order[i]
+			for t := x.t + 1; t < len(adj); t++ {
+				for _, v := range adj[t][x.id] {
+					if v == s {
+						// No shortest path returns to s.
+						continue
+					}
+					y := temporalState{id: v, t: t}
+					d, ok := dist[y]
+					if !ok {
+						d = dist[x] + 1
+						dist[y] = d
+						order = append(order, y)
+						if b, ok := best[v]; !ok || d < b {
+							best[v] = d
+						}
+					}
+					if d == dist[x]+1 {
+						sigma[y] += sigma[x]
+						pred[y] = append(pred[y], x)
+					}
+				}
+			}
+		}
+
+		// sigmaST holds the number of shortest paths from s to each node.
+		sigmaST := make(map[int64]float64)
+		for _, x := range order[1:] {
+			if dist[x] == best[x.id] {
+				sigmaST[x.id] += sigma[x]
+			}
+		}
+
+		// rho[x] is the sum over end nodes t of the number of shortest
+		// paths from x to t divided by the number from s to t.
+		rho := make(map[temporalState]float64, len(order))
+		for i := len(order) - 1; i > 0; i-- {
+			y := order[i]
+			var end float64
+			if dist[y] == best[y.id] {
+				end = 1 / sigmaST[y.id]
+			}
+			r := rho[y] + end
+			for _, x := range pred[y] {
+				rho[x] += r
+			}
+			if c := sigma[y] * rho[y]; c != 0 {
+				cb[y.id] += c
+			}
+		}
+	}
+	return cb
+}
+
+// temporalState is the state of having arrived at the node id by traversing
+// an edge at time step t.
+type temporalState struct {
+	id int64
+	t  int
+}
@@ -0,0 +1,168 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/temporal"
+)
+
+func TestTemporalBetweenness(t *testing.T) {
+	// The path 0-1-2 with the edge 0-1 present only at time 0 and
+	// the edge 1-2 only at time 1 can only be traversed from 0 to 2.
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.AddNode(simple.Node(2))
+	snaps := temporal.NewSnapshots(g, 1)
+	snaps.Append(temporal.Delta{
+		RemoveEdges: []graph.Edge{simple.Edge{F: simple.Node(0), T: simple.Node(1)}},
+		AddEdges:    []graph.Edge{simple.Edge{F: simple.Node(1), T: simple.Node(2)}},
+	})
+	got := TemporalBetweenness(snaps)
+	want := map[int64]float64{1: 1}
+	if !equalCentrality(got, want) {
+		t.Errorf("unexpected temporal betweenness for path: got:%v want:%v", got, want)
+	}
+
+	// With the edges present at both times, 1 is on the paths from
+	// 0 to 2 and from 2 to 0, each of which can only be traversed
+	// with the first edge at time 0 and the second at time 1.
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	snaps = temporal.NewSnapshots(g, 1)
+	snaps.Append(temporal.Delta{})
+	got = TemporalBetweenness(snaps)
+	want = map[int64]float64{1: 2}
+	if !equalCentrality(got, want) {
+		t.Errorf("unexpected temporal betweenness for static path: got:%v want:%v", got, want)
+	}
+}
+
+func TestTemporalBetweennessRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		for trial := 0; trial < 20; trial++ {
+			const n = 6
+			var base graph.Graph
+			if directed {
+				base = simple.NewDirectedGraph()
+			} else {
+				base = simple.NewUndirectedGraph()
+			}
+			for i := 0; i < n; i++ {
+				base.(graph.NodeAdder).AddNode(simple.Node(i))
+			}
+			snaps := temporal.NewSnapshots(base, 2)
+			steps := 2 + rnd.Intn(3)
+			for k := 1; k < steps; k++ {
+				var d temporal.Delta
+				prev := snaps.At(k - 1)
+				for u := 0; u < n; u++ {
+					for v := 0; v < n; v++ {
+						if u == v || (!directed && v < u) || rnd.Float64() > 0.3 {
+							continue
+						}
+						e := simple.Edge{F: simple.Node(u), T: simple.Node(v)}
+						if hasEdge(prev, int64(u), int64(v)) {
+							d.RemoveEdges = append(d.RemoveEdges, e)
+						} else {
+							d.AddEdges = append(d.AddEdges, e)
+						}
+					}
+				}
+				snaps.Append(d)
+			}
+
+			got := TemporalBetweenness(snaps)
+			want := bruteForceTemporalBetweenness(snaps)
+			if !equalCentrality(got, want) {
+				t.Errorf("unexpected temporal betweenness for directed=%t trial %d:\ngot: %v\nwant:%v",
+					directed, trial, got, want)
+			}
+		}
+	}
+}
+
+func hasEdge(g graph.Graph, uid, vid int64) bool {
+	if d, ok := g.(graph.Directed); ok {
+		return d.HasEdgeFromTo(uid, vid)
+	}
+	return g.HasEdgeBetween(uid, vid)
+}
+
+func equalCentrality(got, want map[int64]float64) bool {
+	for id, v := range got {
+		if !floats.EqualWithinAbsOrRel(v, want[id], 1e-10, 1e-10) {
+			return false
+		}
+	}
+	for id, v := range want {
+		if !floats.EqualWithinAbsOrRel(v, got[id], 1e-10, 1e-10) {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteForceTemporalBetweenness enumerates all time-respecting paths
+// with at most |V|-1 edges from each node.
+func bruteForceTemporalBetweenness(snaps *temporal.Snapshots) map[int64]float64 {
+	var graphs []graph.Graph
+	for i := 0; i < snaps.Len(); i++ {
+		graphs = append(graphs, snaps.At(i))
+	}
+	nodes := graph.NodesOf(graphs[0].Nodes())
+
+	cb := make(map[int64]float64)
+	for _, s := range nodes {
+		paths := make(map[int64][][]int64)
+		var walk func(p []int64, t int)
+		walk = func(p []int64, t int) {
+			u := p[len(p)-1]
+			if len(p) > 1 {
+				paths[u] = append(paths[u], append([]int64(nil), p...))
+			}
+			if len(p) == len(nodes) {
+				return
+			}
+			for next := t + 1; next < len(graphs); next++ {
+				for _, v := range graph.NodesOf(graphs[next].From(u)) {
+					walk(append(p, v.ID()), next)
+				}
+			}
+		}
+		walk([]int64{s.ID()}, -1)
+
+		for v, ps := range paths {
+			if v == s.ID() {
+				continue
+			}
+			min := math.MaxInt32
+			for _, p := range ps {
+				if len(p) < min {
+					min = len(p)
+				}
+			}
+			var shortest [][]int64
+			for _, p := range ps {
+				if len(p) == min {
+					shortest = append(shortest, p)
+				}
+			}
+			for _, p := range shortest {
+				for _, w := range p[1 : len(p)-1] {
+					cb[w] += 1 / float64(len(shortest))
+				}
+			}
+		}
+	}
+	return cb
+}
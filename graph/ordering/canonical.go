@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Canonical returns a deterministic ordering of the nodes of g obtained by
+// color refinement, the 1-dimensional Weisfeiler-Lehman procedure. All nodes
+// start with the same color and in each round the nodes are recolored by
+// their current color and the multisets of colors of their neighbors,
+// distinguishing successors and predecessors when g is directed, until the
+// number of colors no longer increases. The colors are numbered by ranking
+// the lexicographically ordered color signatures, so they depend only on the
+// structure of g. Nodes are ordered by their final color, with nodes of equal
+// color ordered by ID.
+//
+// The ordering of the color classes is invariant under isomorphism, so for
+// two isomorphic graphs the orderings differ only within classes that color
+// refinement cannot split. The first round ranks nodes by their number of
+// neighbors, so nodes of lower degree, or out-degree if g is directed,
+// precede nodes of higher degree.
+func Canonical(g graph.Graph) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	color := refine(g, nodes)
+	sort.Stable(byColor{nodes: nodes, color: color})
+	return nodes
+}
+
+// refine returns the stable color of each node in nodes under color
+// refinement of g.
+func refine(g graph.Graph, nodes []graph.Node) []int {
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	out := make([][]int, len(nodes))
+	var in [][]int
+	d, isDirected := g.(graph.Directed)
+	if isDirected {
+		in = make([][]int, len(nodes))
+	}
+	for i, u := range nodes {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			out[i] = append(out[i], indexOf[v.ID()])
+		}
+		if isDirected {
+			for _, v := range graph.NodesOf(d.To(u.ID())) {
+				in[i] = append(in[i], indexOf[v.ID()])
+			}
+		}
+	}
+
+	color := make([]int, len(nodes))
+	classes := 1
+	if len(nodes) == 0 {
+		classes = 0
+	}
+	sig := make([][]int, len(nodes))
+	for {
+		for i := range nodes {
+			s := append(sig[i][:0], color[i])
+			s = appendColors(s, out[i], color)
+			if isDirected {
+				s = appendColors(s, in[i], color)
+			}
+			sig[i] = s
+		}
+		idx := make([]int, len(nodes))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.Sort(bySignature{idx: idx, sig: sig})
+
+		next := make([]int, len(nodes))
+		n := 0
+		for k, i := range idx {
+			if k != 0 && !equalInts(sig[i], sig[idx[k-1]]) {
+				n++
+			}
+			next[i] = n
+		}
+		if len(nodes) != 0 {
+			n++
+		}
+		color = next
+		if n == classes {
+			return color
+		}
+		classes = n
+	}
+}
+
+// appendColors appends the number of neighbors followed by their sorted
+// colors to dst.
+func appendColors(dst, neighbors, color []int) []int {
+	dst = append(dst, len(neighbors))
+	start := len(dst)
+	for _, v := range neighbors {
+		dst = append(dst, color[v])
+	}
+	sort.Ints(dst[start:])
+	return dst
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bySignature sorts node indices by the lexicographic order of their
+// color signatures.
+type bySignature struct {
+	idx []int
+	sig [][]int
+}
+
+func (s bySignature) Len() int { return len(s.idx) }
+func (s bySignature) Less(i, j int) bool {
+	a, b := s.sig[s.idx[i]], s.sig[s.idx[j]]
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+func (s bySignature) Swap(i, j int) { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
+
+// byColor sorts nodes by their color.
+type byColor struct {
+	nodes []graph.Node
+	color []int
+}
+
+func (s byColor) Len() int           { return len(s.nodes) }
+func (s byColor) Less(i, j int) bool { return s.color[i] < s.color[j] }
+func (s byColor) Swap(i, j int) {
+	s.nodes[i], s.nodes[j] = s.nodes[j], s.nodes[i]
+	s.color[i], s.color[j] = s.color[j], s.color[i]
+}
@@ -0,0 +1,146 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCanonicalTies(t *testing.T) {
+	// All nodes of a cycle have the same color, so
+	// the ordering is by ID.
+	g := simple.NewUndirectedGraph()
+	cycleIDs := []int64{7, 3, 12, 5, 9}
+	for i, id := range cycleIDs {
+		g.SetEdge(simple.Edge{F: simple.Node(id), T: simple.Node(cycleIDs[(i+1)%len(cycleIDs)])})
+	}
+	got := ids(Canonical(g))
+	want := []int64{3, 5, 7, 9, 12}
+	if !equalIDs(got, want) {
+		t.Errorf("unexpected ordering of cycle: got:%v want:%v", got, want)
+	}
+
+	if got := Canonical(simple.NewUndirectedGraph()); len(got) != 0 {
+		t.Errorf("unexpected ordering of empty graph: got:%v", got)
+	}
+}
+
+func TestCanonicalSpider(t *testing.T) {
+	// The spider with legs of length 1, 2 and 3 has no non-trivial
+	// automorphism and color refinement distinguishes all its nodes,
+	// so its ordering is independent of node IDs.
+	legs := [][]int{{1}, {2, 3}, {4, 5, 6}}
+	want := []int{6, 3, 1, 5, 2, 4, 0}
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 10; trial++ {
+		label := rnd.Perm(7)
+		for i := range label {
+			label[i] += 10 * trial
+		}
+		g := simple.NewUndirectedGraph()
+		for _, leg := range legs {
+			prev := 0
+			for _, v := range leg {
+				g.SetEdge(simple.Edge{F: simple.Node(label[prev]), T: simple.Node(label[v])})
+				prev = v
+			}
+		}
+		got := ids(Canonical(g))
+		for i, v := range want {
+			if got[i] != int64(label[v]) {
+				t.Errorf("unexpected ordering for labeling %v: got:%v want nodes:%v", label, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestCanonicalRelabeled(t *testing.T) {
+	// The degree sequences of the orderings of isomorphic graphs
+	// are equal, and repeated calls give the same ordering.
+	for seed := uint64(1); seed <= 20; seed++ {
+		perm := rand.New(rand.NewSource(seed)).Perm(20)
+
+		u := simple.NewUndirectedGraph()
+		gen.Gnp(u, 20, 0.15, rand.NewSource(seed))
+		uh := simple.NewUndirectedGraph()
+		for _, n := range perm {
+			uh.AddNode(simple.Node(n))
+		}
+		for _, e := range graph.EdgesOf(u.Edges()) {
+			uh.SetEdge(simple.Edge{F: simple.Node(perm[e.From().ID()]), T: simple.Node(perm[e.To().ID()])})
+		}
+		checkRelabeled(t, u, uh, seed)
+
+		d := simple.NewDirectedGraph()
+		gen.Gnp(d, 20, 0.15, rand.NewSource(seed))
+		dh := simple.NewDirectedGraph()
+		for _, n := range perm {
+			dh.AddNode(simple.Node(n))
+		}
+		for _, e := range graph.EdgesOf(d.Edges()) {
+			dh.SetEdge(simple.Edge{F: simple.Node(perm[e.From().ID()]), T: simple.Node(perm[e.To().ID()])})
+		}
+		checkRelabeled(t, d, dh, seed)
+	}
+}
+
+func checkRelabeled(t *testing.T, g, h graph.Graph, seed uint64) {
+	t.Helper()
+	_, directed := g.(graph.Directed)
+	og := Canonical(g)
+	if !equalIDs(ids(og), ids(Canonical(g))) {
+		t.Errorf("ordering not deterministic for seed %d directed=%t", seed, directed)
+	}
+	oh := Canonical(h)
+	if len(og) != len(oh) {
+		t.Errorf("unexpected ordering length for seed %d directed=%t: got:%d want:%d",
+			seed, directed, len(oh), len(og))
+		return
+	}
+	for i := range og {
+		if degree(g, og[i]) != degree(h, oh[i]) {
+			t.Errorf("degree sequence mismatch for seed %d directed=%t at %d", seed, directed, i)
+			return
+		}
+	}
+}
+
+func degree(g graph.Graph, n graph.Node) [2]int {
+	d := [2]int{g.From(n.ID()).Len()}
+	if g, ok := g.(graph.Directed); ok {
+		d[1] = g.To(n.ID()).Len()
+	}
+	return d
+}
+
+func ids(nodes []graph.Node) []int64 {
+	if nodes == nil {
+		return nil
+	}
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
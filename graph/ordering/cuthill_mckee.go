@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// ReverseCuthillMcKee returns an ordering of the nodes of g found by the
+// reverse Cuthill-McKee algorithm. Relabeling the nodes of g by their index
+// in the ordering tends to reduce the bandwidth of the adjacency matrix of g.
+//
+// Each connected component of g is ordered by a breadth-first traversal from
+// a pseudo-peripheral node, found by the method of George and Liu, visiting
+// the neighbors of each node in order of increasing degree, and the result
+// is reversed. Ties are broken by node ID, so the ordering is deterministic.
+func ReverseCuthillMcKee(g graph.Undirected) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+
+	degree := make(map[int64]int, len(nodes))
+	for _, n := range nodes {
+		id := n.ID()
+		for _, v := range graph.NodesOf(g.From(id)) {
+			if v.ID() != id {
+				degree[id]++
+			}
+		}
+	}
+	byDegree := func(nodes []graph.Node) {
+		sort.Sort(ordered.ByID(nodes))
+		sort.Stable(byDegreeOf{nodes: nodes, degree: degree})
+	}
+
+	order := make([]graph.Node, 0, len(nodes))
+	visited := make(set.Int64s)
+	for _, n := range nodes {
+		if visited.Has(n.ID()) {
+			continue
+		}
+
+		// Start the search for a peripheral node from
+		// the lowest degree node of the component.
+		var component []graph.Node
+		traverse.WalkLayers(g, n, func(_ int, layer []graph.Node) {
+			component = append(component, layer...)
+		})
+		byDegree(component)
+		start := pseudoPeripheral(g, component[0], byDegree)
+
+		bf := traverse.BreadthFirst{Order: byDegree}
+		visited.Add(start.ID())
+		order = append(order, start)
+		bf.Walk(g, start, func(n graph.Node, _ int) bool {
+			if !visited.Has(n.ID()) {
+				visited.Add(n.ID())
+				order = append(order, n)
+			}
+			return false
+		})
+	}
+
+	ordered.Reverse(order)
+	return order
+}
+
+// pseudoPeripheral returns a pseudo-peripheral node of the connected component
+// of g holding start. The search repeatedly moves to the lowest degree node
+// in the furthest layer of a breadth-first traversal until the eccentricity
+// of the current node does not increase.
+func pseudoPeripheral(g graph.Undirected, start graph.Node, byDegree func([]graph.Node)) graph.Node {
+	u := start
+	ecc := -1
+	for {
+		var (
+			depth int
+			last  []graph.Node
+		)
+		traverse.WalkLayers(g, u, func(d int, layer []graph.Node) {
+			depth = d
+			last = append(last[:0], layer...)
+		})
+		if depth <= ecc {
+			return u
+		}
+		ecc = depth
+		byDegree(last)
+		u = last[0]
+	}
+}
+
+// byDegreeOf sorts nodes by increasing degree.
+type byDegreeOf struct {
+	nodes  []graph.Node
+	degree map[int64]int
+}
+
+func (n byDegreeOf) Len() int { return len(n.nodes) }
+func (n byDegreeOf) Less(i, j int) bool {
+	return n.degree[n.nodes[i].ID()] < n.degree[n.nodes[j].ID()]
+}
+func (n byDegreeOf) Swap(i, j int) { n.nodes[i], n.nodes[j] = n.nodes[j], n.nodes[i] }
+
+// Bandwidth returns the bandwidth of the adjacency matrix of g when its nodes
+// are labeled by their index in order, the maximum difference between the
+// indices of the end points of an edge of g. Bandwidth will panic if order
+// does not hold every node of g that is an end point of an edge.
+func Bandwidth(g graph.Undirected, order []graph.Node) int {
+	index := make(map[int64]int, len(order))
+	for i, n := range order {
+		index[n.ID()] = i
+	}
+	var bw int
+	for _, u := range order {
+		i := index[u.ID()]
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			j, ok := index[v.ID()]
+			if !ok {
+				panic("ordering: node missing from order")
+			}
+			if d := j - i; d > bw {
+				bw = d
+			}
+		}
+	}
+	return bw
+}
@@ -0,0 +1,158 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// scrambled returns a function mapping the integers in [0, n)
+// to distinct random node IDs.
+func scrambled(n int, src rand.Source) func(i int) simple.Node {
+	perm := rand.New(src).Perm(n)
+	return func(i int) simple.Node { return simple.Node(perm[i]) }
+}
+
+var cuthillMcKeeTests = []struct {
+	name string
+	g    func() graph.Undirected
+
+	// maxBandwidth is the largest acceptable bandwidth
+	// of the ordering, or -1 if not known.
+	maxBandwidth int
+}{
+	{
+		name: "path",
+		g: func() graph.Undirected {
+			g := simple.NewUndirectedGraph()
+			id := scrambled(50, rand.NewSource(1))
+			for i := 1; i < 50; i++ {
+				g.SetEdge(simple.Edge{F: id(i - 1), T: id(i)})
+			}
+			return g
+		},
+		maxBandwidth: 1,
+	},
+	{
+		name: "grid",
+		g: func() graph.Undirected {
+			const r, c = 20, 6
+			g := simple.NewUndirectedGraph()
+			id := scrambled(r*c, rand.NewSource(2))
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					if i+1 < r {
+						g.SetEdge(simple.Edge{F: id(i*c + j), T: id((i+1)*c + j)})
+					}
+					if j+1 < c {
+						g.SetEdge(simple.Edge{F: id(i*c + j), T: id(i*c + j + 1)})
+					}
+				}
+			}
+			return g
+		},
+		maxBandwidth: 7,
+	},
+	{
+		name: "banded",
+		g: func() graph.Undirected {
+			const n, band = 200, 4
+			g := simple.NewUndirectedGraph()
+			src := rand.NewSource(3)
+			rnd := rand.New(src)
+			id := scrambled(n, src)
+			for i := 0; i < n; i++ {
+				if g.Node(int64(id(i))) == nil {
+					g.AddNode(id(i))
+				}
+				for j := i + 1; j <= i+band && j < n; j++ {
+					if j == i+1 || rnd.Float64() < 0.5 {
+						g.SetEdge(simple.Edge{F: id(i), T: id(j)})
+					}
+				}
+			}
+			return g
+		},
+		maxBandwidth: 2 * 4,
+	},
+	{
+		name: "components",
+		g: func() graph.Undirected {
+			g := simple.NewUndirectedGraph()
+			id := scrambled(30, rand.NewSource(4))
+			for i := 1; i < 30; i++ {
+				if i%10 != 0 {
+					g.SetEdge(simple.Edge{F: id(i - 1), T: id(i)})
+				}
+			}
+			g.AddNode(simple.Node(100))
+			return g
+		},
+		maxBandwidth: 1,
+	},
+	{
+		name:         "empty",
+		g:            func() graph.Undirected { return simple.NewUndirectedGraph() },
+		maxBandwidth: 0,
+	},
+}
+
+func TestReverseCuthillMcKee(t *testing.T) {
+	for _, test := range cuthillMcKeeTests {
+		g := test.g()
+		order := ReverseCuthillMcKee(g)
+
+		if len(order) != g.Nodes().Len() {
+			t.Errorf("unexpected ordering length for %s: got:%d want:%d", test.name, len(order), g.Nodes().Len())
+			continue
+		}
+		seen := make(map[int64]bool)
+		for _, n := range order {
+			if seen[n.ID()] {
+				t.Errorf("node %d repeated in ordering for %s", n.ID(), test.name)
+			}
+			seen[n.ID()] = true
+		}
+
+		natural := graph.NodesOf(g.Nodes())
+		sort.Sort(ordered.ByID(natural))
+		got := Bandwidth(g, order)
+		if want := Bandwidth(g, natural); got > want {
+			t.Errorf("bandwidth not reduced for %s: got:%d natural:%d", test.name, got, want)
+		}
+		if got > test.maxBandwidth {
+			t.Errorf("unexpected bandwidth for %s: got:%d want<=%d", test.name, got, test.maxBandwidth)
+		}
+
+		again := ReverseCuthillMcKee(g)
+		for i := range order {
+			if order[i].ID() != again[i].ID() {
+				t.Errorf("ordering not deterministic for %s", test.name)
+				break
+			}
+		}
+	}
+}
+
+func TestBandwidth(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	nodes := []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2), simple.Node(3)}
+	if got := Bandwidth(g, nodes); got != 3 {
+		t.Errorf("unexpected bandwidth: got:%d want:3", got)
+	}
+	nodes = []graph.Node{simple.Node(0), simple.Node(3), simple.Node(1), simple.Node(2)}
+	if got := Bandwidth(g, nodes); got != 1 {
+		t.Errorf("unexpected bandwidth: got:%d want:1", got)
+	}
+}
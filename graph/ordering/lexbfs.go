@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// LexBFS returns a lexicographic breadth-first search ordering of the nodes
+// of g. Each node in the ordering is an unvisited node whose set of visited
+// neighbors, ordered by visit time, is lexicographically greatest. The search
+// starts from the node with the lowest ID and ties are broken in favor of the
+// node that was earliest in the previous ordering of its class, so the result
+// is deterministic.
+//
+// The reverse of a LexBFS ordering of a chordal graph is a perfect elimination
+// ordering. LexBFS uses partition refinement and runs in O(|V|+|E|) time after
+// sorting.
+func LexBFS(g graph.Undirected) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+
+	// The unvisited nodes are held in a sequence of classes,
+	// each a list of nodes that are tied in the ordering.
+	items := make(map[int64]*lexItem, len(nodes))
+	first := &lexClass{}
+	for _, n := range nodes {
+		it := &lexItem{node: n}
+		items[n.ID()] = it
+		first.push(it)
+	}
+
+	order := make([]graph.Node, 0, len(nodes))
+	visited := make(map[int64]bool, len(nodes))
+	for round := 1; first != nil; round++ {
+		it := first.head
+		first.remove(it)
+		if first.size == 0 {
+			first = first.next
+			if first != nil {
+				first.prev = nil
+			}
+		}
+		u := it.node
+		visited[u.ID()] = true
+		order = append(order, u)
+
+		to := graph.NodesOf(g.From(u.ID()))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if visited[v.ID()] {
+				continue
+			}
+			w := items[v.ID()]
+			c := w.class
+			if c.stamp != round {
+				// Split c, placing the neighbors of u
+				// in a new class immediately before c.
+				c.stamp = round
+				c.split = &lexClass{prev: c.prev, next: c}
+				if c.prev != nil {
+					c.prev.next = c.split
+				} else {
+					first = c.split
+				}
+				c.prev = c.split
+			}
+			c.remove(w)
+			c.split.push(w)
+			if c.size == 0 {
+				c.split.next = c.next
+				if c.next != nil {
+					c.next.prev = c.split
+				}
+			}
+		}
+	}
+	return order
+}
+
+// lexItem is a node held in a LexBFS class.
+type lexItem struct {
+	node       graph.Node
+	prev, next *lexItem
+	class      *lexClass
+}
+
+// lexClass is a list of nodes that are tied in a LexBFS ordering.
+type lexClass struct {
+	head, tail *lexItem
+	size       int
+
+	prev, next *lexClass
+
+	// split is the class that the nodes of the
+	// class are moved to in the round stamp.
+	split *lexClass
+	stamp int
+}
+
+// push appends it to the end of the class.
+func (c *lexClass) push(it *lexItem) {
+	it.class = c
+	it.next = nil
+	it.prev = c.tail
+	if c.tail != nil {
+		c.tail.next = it
+	} else {
+		c.head = it
+	}
+	c.tail = it
+	c.size++
+}
+
+// remove removes it from the class.
+func (c *lexClass) remove(it *lexItem) {
+	if it.prev != nil {
+		it.prev.next = it.next
+	} else {
+		c.head = it.next
+	}
+	if it.next != nil {
+		it.next.prev = it.prev
+	} else {
+		c.tail = it.prev
+	}
+	it.prev, it.next, it.class = nil, nil, nil
+	c.size--
+}
@@ -0,0 +1,88 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestLexBFS(t *testing.T) {
+	for seed := uint64(1); seed <= 30; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 25, 0.15+0.01*float64(seed), rand.NewSource(seed))
+		order := LexBFS(g)
+		if !isPermutation(g, order) {
+			t.Errorf("ordering for seed %d is not a permutation of the nodes", seed)
+			continue
+		}
+		if order[0].ID() != 0 {
+			t.Errorf("unexpected first node for seed %d: got:%d want:0", seed, order[0].ID())
+		}
+		if a, b, c, ok := isLexBFS(g, order); !ok {
+			t.Errorf("ordering for seed %d violates the LexBFS property at %d<%d<%d", seed, a, b, c)
+		}
+	}
+}
+
+func TestLexBFSPath(t *testing.T) {
+	// On a path starting from an end the LexBFS ordering
+	// follows the path. The components are visited in turn.
+	g := simple.NewUndirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(4)},
+		{F: simple.Node(2), T: simple.Node(5)},
+	} {
+		g.SetEdge(e)
+	}
+	want := []int64{0, 3, 1, 4, 2, 5}
+	got := LexBFS(g)
+	for i, n := range got {
+		if n.ID() != want[i] {
+			t.Fatalf("unexpected ordering: got:%v want:%v", got, want)
+		}
+	}
+	if got := LexBFS(simple.NewUndirectedGraph()); got != nil {
+		t.Errorf("unexpected ordering of empty graph: got:%v want:nil", got)
+	}
+}
+
+// isLexBFS returns whether order satisfies the characterization of LexBFS
+// orderings: for positions a < b < c, if a is adjacent to c and not to b,
+// then there is a position d < a adjacent to b and not to c. If the check
+// fails, the violating positions are returned.
+func isLexBFS(g graph.Undirected, order []graph.Node) (a, b, c int, ok bool) {
+	adj := func(i, j int) bool { return g.HasEdgeBetween(order[i].ID(), order[j].ID()) }
+	for a := range order {
+		for b := a + 1; b < len(order); b++ {
+			if adj(a, b) {
+				continue
+			}
+			for c := b + 1; c < len(order); c++ {
+				if !adj(a, c) {
+					continue
+				}
+				found := false
+				for d := 0; d < a; d++ {
+					if adj(d, b) && !adj(d, c) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return a, b, c, false
+				}
+			}
+		}
+	}
+	return 0, 0, 0, true
+}
@@ -0,0 +1,104 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"container/heap"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// MaximumAdjacency returns a maximum adjacency ordering of the nodes of g
+// starting from start. After start, each node in the ordering is a node not
+// yet ordered with the greatest total weight of edges joining it to the nodes
+// already ordered. Ties are broken by lowest node ID. Edge weights are obtained
+// from the WeightedEdge method of g and self edges are ignored.
+//
+// In a maximum adjacency ordering the last node, t, and the node before it, s,
+// satisfy the property that the cut separating t from all other nodes is a
+// minimum s-t cut of g. This is the basis of the Stoer-Wagner minimum cut
+// algorithm.
+//
+// If start is not in g, MaximumAdjacency returns nil. MaximumAdjacency will
+// panic if g has a negative edge weight.
+func MaximumAdjacency(g graph.WeightedUndirected, start graph.Node) []graph.Node {
+	if g.Node(start.ID()) == nil {
+		return nil
+	}
+	nodes := graph.NodesOf(g.Nodes())
+
+	attach := make(map[int64]float64, len(nodes))
+	q := make(adjacencyQueue, 0, len(nodes))
+	for _, n := range nodes {
+		if n.ID() == start.ID() {
+			continue
+		}
+		q = append(q, adjacency{node: n})
+	}
+	heap.Init(&q)
+
+	ordered := make(set.Int64s, len(nodes))
+	order := make([]graph.Node, 0, len(nodes))
+	u := start
+	for {
+		uid := u.ID()
+		ordered.Add(uid)
+		order = append(order, u)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if ordered.Has(vid) {
+				continue
+			}
+			w := g.WeightedEdge(uid, vid).Weight()
+			if w < 0 {
+				panic("ordering: negative edge weight")
+			}
+			attach[vid] += w
+			heap.Push(&q, adjacency{node: v, weight: attach[vid]})
+		}
+
+		u = nil
+		for q.Len() != 0 {
+			a := heap.Pop(&q).(adjacency)
+			id := a.node.ID()
+			if !ordered.Has(id) && a.weight == attach[id] {
+				u = a.node
+				break
+			}
+		}
+		if u == nil {
+			return order
+		}
+	}
+}
+
+// adjacency is a node and its total edge weight to the ordered nodes.
+type adjacency struct {
+	node   graph.Node
+	weight float64
+}
+
+// adjacencyQueue is a max-priority queue of adjacencies ordered by weight
+// and then by lowest node ID.
+type adjacencyQueue []adjacency
+
+func (q adjacencyQueue) Len() int { return len(q) }
+func (q adjacencyQueue) Less(i, j int) bool {
+	if q[i].weight != q[j].weight {
+		return q[i].weight > q[j].weight
+	}
+	return q[i].node.ID() < q[j].node.ID()
+}
+func (q adjacencyQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *adjacencyQueue) Push(x interface{}) { *q = append(*q, x.(adjacency)) }
+func (q *adjacencyQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
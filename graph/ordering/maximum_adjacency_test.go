@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// randomWeighted returns a random weighted undirected graph with n nodes
+// and integer edge weights in [0, 5).
+func randomWeighted(n int, p float64, seed uint64) *simple.WeightedUndirectedGraph {
+	src := rand.NewSource(seed)
+	topology := simple.NewUndirectedGraph()
+	gen.Gnp(topology, n, p, src)
+	rnd := rand.New(src)
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, u := range graph.NodesOf(topology.Nodes()) {
+		g.AddNode(u)
+	}
+	for _, e := range graph.EdgesOf(topology.Edges()) {
+		g.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: float64(rnd.Intn(5))})
+	}
+	return g
+}
+
+// attachment returns the total weight of edges joining n to the nodes in set.
+func attachment(g graph.WeightedUndirected, n graph.Node, set []graph.Node) float64 {
+	var w float64
+	for _, u := range set {
+		if e := g.WeightedEdge(n.ID(), u.ID()); e != nil {
+			w += e.Weight()
+		}
+	}
+	return w
+}
+
+func TestMaximumAdjacency(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		g := randomWeighted(30, 0.2, seed)
+		start := simple.Node(seed % 30)
+		order := MaximumAdjacency(g, start)
+		if !isPermutation(g, order) {
+			t.Errorf("ordering for seed %d is not a permutation of the nodes", seed)
+			continue
+		}
+		if order[0].ID() != start.ID() {
+			t.Errorf("unexpected first node for seed %d: got:%d want:%d", seed, order[0].ID(), start.ID())
+		}
+		for i := 1; i < len(order); i++ {
+			chosen := attachment(g, order[i], order[:i])
+			for _, v := range order[i+1:] {
+				other := attachment(g, v, order[:i])
+				if other > chosen || (other == chosen && v.ID() < order[i].ID()) {
+					t.Errorf("node %d at position %d chosen over more adjacent node %d for seed %d: %v < %v",
+						order[i].ID(), i, v.ID(), seed, chosen, other)
+				}
+			}
+		}
+	}
+}
+
+func TestMaximumAdjacencyCutOfThePhase(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		const n = 9
+		g := randomWeighted(n, 0.5, seed)
+		order := MaximumAdjacency(g, simple.Node(0))
+		s, last := order[n-2], order[n-1]
+
+		got := attachment(g, last, order[:n-1])
+		want := math.Inf(1)
+		for mask := 0; mask < 1<<n; mask++ {
+			if mask&(1<<uint(s.ID())) == 0 || mask&(1<<uint(last.ID())) != 0 {
+				continue
+			}
+			var cut float64
+			for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+				inF := mask&(1<<uint(e.From().ID())) != 0
+				inT := mask&(1<<uint(e.To().ID())) != 0
+				if inF != inT {
+					cut += e.Weight()
+				}
+			}
+			want = math.Min(want, cut)
+		}
+		if got != want {
+			t.Errorf("cut of the phase is not a minimum %d-%d cut for seed %d: got:%v want:%v",
+				s.ID(), last.ID(), seed, got, want)
+		}
+	}
+}
+
+func TestMaximumAdjacencyMissing(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	g.AddNode(simple.Node(0))
+	if got := MaximumAdjacency(g, simple.Node(1)); got != nil {
+		t.Errorf("unexpected ordering from missing node: got:%v want:nil", got)
+	}
+}
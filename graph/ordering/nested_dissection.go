@@ -0,0 +1,129 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/traverse"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+// NestedDissection returns a nested dissection ordering of the nodes of g.
+// Eliminating the nodes of the adjacency matrix of g in this order tends to
+// reduce the fill-in of sparse Cholesky and LU factorizations.
+//
+// Each connected component of g is split by a vertex separator into two
+// parts that are not joined by any edge. The parts are ordered recursively
+// and placed before the separator. The separator is the middle level of a
+// breadth-first level structure rooted at a pseudo-peripheral node, as in
+// George's automatic nested dissection, with nodes that are not adjacent to
+// the following level moved out of the separator. Components too shallow to
+// be split are ordered by node ID. The ordering is a heuristic; the fill-in
+// it produces is not guaranteed to be minimal.
+func NestedDissection(g graph.Undirected) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil
+	}
+	order := make([]graph.Node, 0, len(nodes))
+	return dissect(g, nodes, order)
+}
+
+// dissect appends the nested dissection ordering of the subgraph of g induced
+// by nodes to order and returns it.
+func dissect(g graph.Undirected, nodes []graph.Node, order []graph.Node) []graph.Node {
+	if len(nodes) == 0 {
+		return order
+	}
+	sort.Sort(ordered.ByID(nodes))
+	in := make(set.Int64s, len(nodes))
+	for _, n := range nodes {
+		in.Add(n.ID())
+	}
+	sub := view.NewFilteredUndirected(g, func(n graph.Node) bool { return in.Has(n.ID()) }, nil)
+
+	degree := make(map[int64]int, len(nodes))
+	for _, n := range nodes {
+		id := n.ID()
+		for _, v := range graph.NodesOf(sub.From(id)) {
+			if v.ID() != id {
+				degree[id]++
+			}
+		}
+	}
+	byDegree := func(nodes []graph.Node) {
+		sort.Sort(ordered.ByID(nodes))
+		sort.Stable(byDegreeOf{nodes: nodes, degree: degree})
+	}
+
+	visited := make(set.Int64s)
+	for _, n := range nodes {
+		if visited.Has(n.ID()) {
+			continue
+		}
+
+		var component []graph.Node
+		traverse.WalkLayers(sub, n, func(_ int, layer []graph.Node) {
+			component = append(component, layer...)
+		})
+		for _, u := range component {
+			visited.Add(u.ID())
+		}
+		byDegree(component)
+		root := pseudoPeripheral(sub, component[0], byDegree)
+
+		var levels [][]graph.Node
+		traverse.WalkLayers(sub, root, func(_ int, layer []graph.Node) {
+			levels = append(levels, append([]graph.Node(nil), layer...))
+		})
+		if len(levels) < 3 {
+			sort.Sort(ordered.ByID(component))
+			order = append(order, component...)
+			continue
+		}
+
+		// Choose the level at which half of the
+		// component has been reached as the separator.
+		m := 1
+		for seen := len(levels[0]); m < len(levels)-2 && seen+len(levels[m]) <= len(component)/2; m++ {
+			seen += len(levels[m])
+		}
+		next := make(set.Int64s, len(levels[m+1]))
+		for _, v := range levels[m+1] {
+			next.Add(v.ID())
+		}
+		var a, b, separator []graph.Node
+		for _, l := range levels[:m] {
+			a = append(a, l...)
+		}
+		for _, u := range levels[m] {
+			adjacent := false
+			for _, v := range graph.NodesOf(sub.From(u.ID())) {
+				if next.Has(v.ID()) {
+					adjacent = true
+					break
+				}
+			}
+			if adjacent {
+				separator = append(separator, u)
+			} else {
+				a = append(a, u)
+			}
+		}
+		for _, l := range levels[m+1:] {
+			b = append(b, l...)
+		}
+
+		order = dissect(g, a, order)
+		order = dissect(g, b, order)
+		sort.Sort(ordered.ByID(separator))
+		order = append(order, separator...)
+	}
+	return order
+}
@@ -0,0 +1,152 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ordering
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// grid returns an r×c grid graph with nodes labeled in row-major order.
+func grid(r, c int) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			u := simple.Node(i*c + j)
+			if i+1 < r {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node((i+1)*c + j)})
+			}
+			if j+1 < c {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node(i*c + j + 1)})
+			}
+		}
+	}
+	return g
+}
+
+// fillIn returns the number of edges added to g when its nodes are
+// eliminated in the given order, the fill-in of the Cholesky factor
+// of a matrix with the sparsity structure of g.
+func fillIn(g graph.Undirected, order []graph.Node) int {
+	adj := make(map[int64]set.Int64s)
+	for _, u := range order {
+		adj[u.ID()] = make(set.Int64s)
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if v.ID() != u.ID() {
+				adj[u.ID()].Add(v.ID())
+			}
+		}
+	}
+	var fill int
+	for _, u := range order {
+		var nbrs []int64
+		for v := range adj[u.ID()] {
+			nbrs = append(nbrs, v)
+		}
+		for i, v := range nbrs {
+			for _, w := range nbrs[i+1:] {
+				if !adj[v].Has(w) {
+					adj[v].Add(w)
+					adj[w].Add(v)
+					fill++
+				}
+			}
+		}
+		for _, v := range nbrs {
+			delete(adj[v], u.ID())
+		}
+		delete(adj, u.ID())
+	}
+	return fill
+}
+
+func TestNestedDissectionGrid(t *testing.T) {
+	for _, dims := range []struct{ r, c int }{{8, 8}, {15, 15}, {10, 30}} {
+		g := grid(dims.r, dims.c)
+		order := NestedDissection(g)
+		if !isPermutation(g, order) {
+			t.Errorf("ordering of %d×%d grid is not a permutation of the nodes", dims.r, dims.c)
+			continue
+		}
+
+		natural := graph.NodesOf(g.Nodes())
+		sort.Sort(ordered.ByID(natural))
+		nd := fillIn(g, order)
+		if naturalFill := fillIn(g, natural); nd >= naturalFill {
+			t.Errorf("nested dissection fill not less than natural fill for %d×%d grid: got:%d natural:%d",
+				dims.r, dims.c, nd, naturalFill)
+		}
+		if rcmFill := fillIn(g, ReverseCuthillMcKee(g)); nd >= rcmFill {
+			t.Errorf("nested dissection fill not less than RCM fill for %d×%d grid: got:%d RCM:%d",
+				dims.r, dims.c, nd, rcmFill)
+		}
+	}
+}
+
+func TestNestedDissectionSeparator(t *testing.T) {
+	// The first separator of a path is its middle node, which
+	// is ordered last, and each half is ordered recursively.
+	g := simple.NewUndirectedGraph()
+	for i := 1; i < 63; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+	}
+	order := NestedDissection(g)
+	if !isPermutation(g, order) {
+		t.Fatal("ordering of path is not a permutation of the nodes")
+	}
+	if got := order[len(order)-1].ID(); got != 31 {
+		t.Errorf("unexpected last node: got:%d want:31", got)
+	}
+	for i, n := range order[:31] {
+		if (n.ID() < 31) != (order[0].ID() < 31) {
+			t.Errorf("node %d at position %d not in the first half of the ordering", n.ID(), i)
+		}
+	}
+}
+
+func TestNestedDissectionRandom(t *testing.T) {
+	for seed := uint64(1); seed <= 10; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 80, 0.04, rand.NewSource(seed))
+		order := NestedDissection(g)
+		if !isPermutation(g, order) {
+			t.Errorf("ordering for seed %d is not a permutation of the nodes", seed)
+		}
+		again := NestedDissection(g)
+		for i := range order {
+			if order[i].ID() != again[i].ID() {
+				t.Errorf("ordering not deterministic for seed %d", seed)
+				break
+			}
+		}
+	}
+
+	if got := NestedDissection(simple.NewUndirectedGraph()); got != nil {
+		t.Errorf("unexpected ordering of empty graph: got:%v want:nil", got)
+	}
+}
+
+// isPermutation returns whether order holds each node of g exactly once.
+func isPermutation(g graph.Graph, order []graph.Node) bool {
+	if len(order) != g.Nodes().Len() {
+		return false
+	}
+	seen := make(set.Int64s)
+	for _, n := range order {
+		if seen.Has(n.ID()) || g.Node(n.ID()) == nil {
+			return false
+		}
+		seen.Add(n.ID())
+	}
+	return true
+}
@@ -24,8 +24,32 @@ import (
 // falling back to NullHeuristic otherwise. If the graph does not implement Weighted,
 // UniformCost is used. AStar will panic if g has an A*-reachable negative edge weight.
 func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded int) {
+	path, stats := AStarWithStats(s, t, g, h)
+	return path, stats.Expanded
+}
+
+// AStarStats holds search statistics for an A* search.
+type AStarStats struct {
+	// Expanded is the number of nodes removed
+	// from the frontier and expanded.
+	Expanded int
+
+	// Relaxed is the number of times the cost
+	// of the path to a node was set or lowered.
+	Relaxed int
+
+	// MaxFrontierSize is the largest number of nodes
+	// held in the frontier during the search.
+	MaxFrontierSize int
+}
+
+// AStarWithStats finds the A*-shortest path from s to t in g using the heuristic h
+// with the same semantics as AStar. In addition to the path, AStarWithStats returns
+// statistics describing the work done during the search. These may be used to
+// compare the effectiveness of heuristics on the same query.
+func AStarWithStats(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, stats AStarStats) {
 	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
-		return Shortest{from: s}, 0
+		return Shortest{from: s}, stats
 	}
 	var weight Weighting
 	if wg, ok := g.(Weighted); ok {
@@ -47,12 +71,13 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 	visited := make(set.Int64s)
 	open := &aStarQueue{indexOf: make(map[int64]int)}
 	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: h(s, t)})
+	stats.MaxFrontierSize = 1
 
 	for open.Len() != 0 {
 		u := heap.Pop(open).(aStarNode)
 		uid := u.node.ID()
 		i := path.indexOf[uid]
-		expanded++
+		stats.Expanded++
 
 		if uid == tid {
 			break
@@ -77,14 +102,19 @@ func AStar(s, t graph.Node, g graph.Graph, h Heuristic) (path Shortest, expanded
 			if n, ok := open.node(vid); !ok {
 				path.set(j, g, i)
 				heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
+				stats.Relaxed++
+				if open.Len() > stats.MaxFrontierSize {
+					stats.MaxFrontierSize = open.Len()
+				}
 			} else if g < n.gscore {
 				path.set(j, g, i)
 				open.update(vid, g, g+h(v, t))
+				stats.Relaxed++
 			}
 		}
 	}
 
-	return path, expanded
+	return path, stats
 }
 
 // NullHeuristic is an admissible, consistent heuristic that will not speed up computation.
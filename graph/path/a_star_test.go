@@ -152,6 +152,58 @@ func TestAStar(t *testing.T) {
 	}
 }
 
+func TestAStarWithStats(t *testing.T) {
+	for _, test := range aStarTests {
+		s, tid := simple.Node(test.s), simple.Node(test.t)
+		pt, expanded := AStar(s, tid, test.g, test.heuristic)
+		ps, stats := AStarWithStats(s, tid, test.g, test.heuristic)
+
+		if stats.Expanded != expanded {
+			t.Errorf("unexpected expanded count for %q: got:%d want:%d", test.name, stats.Expanded, expanded)
+		}
+		p, cost := pt.To(test.t)
+		q, qcost := ps.To(test.t)
+		if !reflect.DeepEqual(p, q) || cost != qcost {
+			t.Errorf("unexpected path for %q: got:%v,%v want:%v,%v", test.name, q, qcost, p, cost)
+		}
+
+		// Every expanded node other than the source must
+		// have been reached by at least one relaxation, and
+		// every node in the frontier was added by a relaxation.
+		if stats.Relaxed < stats.Expanded-1 {
+			t.Errorf("too few relaxations for %q: got:%d expanded:%d", test.name, stats.Relaxed, stats.Expanded)
+		}
+		if stats.MaxFrontierSize < 1 || stats.MaxFrontierSize > stats.Relaxed+1 {
+			t.Errorf("unexpected maximum frontier size for %q: got:%d relaxed:%d", test.name, stats.MaxFrontierSize, stats.Relaxed)
+		}
+	}
+
+	// A line graph has a single node in the frontier at each step.
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 3; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	_, stats := AStarWithStats(simple.Node(0), simple.Node(3), g, nil)
+	want := AStarStats{Expanded: 4, Relaxed: 3, MaxFrontierSize: 1}
+	if stats != want {
+		t.Errorf("unexpected stats for line graph: got:%+v want:%+v", stats, want)
+	}
+
+	// An informative heuristic expands fewer nodes.
+	var null, manhattan AStarStats
+	for _, test := range aStarTests {
+		switch test.name {
+		case "partially obstructed":
+			_, null = AStarWithStats(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic)
+		case "partially obstructed with heuristic":
+			_, manhattan = AStarWithStats(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic)
+		}
+	}
+	if manhattan.Expanded >= null.Expanded {
+		t.Errorf("heuristic did not reduce expansions: got:%d null:%d", manhattan.Expanded, null.Expanded)
+	}
+}
+
 func TestExhaustiveAStar(t *testing.T) {
 	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
 	nodes := []locatedNode{
@@ -12,6 +12,28 @@ import "gonum.org/v1/gonum/graph"
 //
 // The time complexity of BellmanFordFrom is O(|V|.|E|).
 func BellmanFordFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
+	return bellmanFordFrom(u, g, nil)
+}
+
+// BellmanFordFromStats returns a shortest-path tree for a shortest path from u to all
+// nodes in the graph g as described for BellmanFordFrom, and whether a negative cycle
+// exists in the graph. The returned stats hold the number of times the distance to each
+// node of g was improved during relaxation. Nodes that are improved an unusually large
+// number of times may indicate structure, such as near-negative cycles, that makes the
+// search slow.
+func BellmanFordFromStats(u graph.Node, g graph.Weighted) (path Shortest, stats map[int64]int, hasNegCycle bool) {
+	stats = make(map[int64]int)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		stats[nodes.Node().ID()] = 0
+	}
+	path, ok := bellmanFordFrom(u, g, stats)
+	return path, stats, !ok
+}
+
+// bellmanFordFrom returns a shortest-path tree for BellmanFordFrom, counting
+// distance improvements in stats if it is not nil.
+func bellmanFordFrom(u graph.Node, g graph.Graph, stats map[int64]int) (path Shortest, ok bool) {
 	if g.Node(u.ID()) == nil {
 		return Shortest{from: u}, true
 	}
@@ -44,6 +66,9 @@ func BellmanFordFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
 				if joint < path.dist[k] {
 					path.set(k, joint, j)
 					changed = true
+					if stats != nil {
+						stats[vid]++
+					}
 				}
 			}
 		}
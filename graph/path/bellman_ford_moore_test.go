@@ -68,3 +68,43 @@ func TestBellmanFordFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestBellmanFordFromStats(t *testing.T) {
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		want, ok := BellmanFordFrom(test.Query.From(), g.(graph.Graph))
+		got, stats, hasNegCycle := BellmanFordFromStats(test.Query.From(), g.(graph.Weighted))
+		if hasNegCycle == ok {
+			t.Errorf("%q: unexpected negative cycle result: got:%t want:%t", test.Name, hasNegCycle, !ok)
+		}
+		if hasNegCycle {
+			continue
+		}
+
+		nodes := graph.NodesOf(g.(graph.Graph).Nodes())
+		if len(stats) != len(nodes) {
+			t.Errorf("%q: unexpected number of stats: got:%d want:%d", test.Name, len(stats), len(nodes))
+		}
+		for _, n := range nodes {
+			id := n.ID()
+			w := want.WeightTo(id)
+			if gw := got.WeightTo(id); gw != w {
+				t.Errorf("%q: unexpected weight to %d: got:%v want:%v", test.Name, id, gw, w)
+			}
+			switch count, reached := stats[id], !math.IsInf(w, 1); {
+			case id == test.Query.From().ID():
+				if count != 0 {
+					t.Errorf("%q: unexpected relaxation count for source %d: got:%d want:0", test.Name, id, count)
+				}
+			case reached && count < 1:
+				t.Errorf("%q: unexpected relaxation count for reached node %d: got:%d want:>0", test.Name, id, count)
+			case !reached && count != 0:
+				t.Errorf("%q: unexpected relaxation count for unreached node %d: got:%d want:0", test.Name, id, count)
+			}
+		}
+	}
+}
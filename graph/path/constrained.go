@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ConstrainedShortest returns the least cost path from s to t in g whose total
+// resource consumption is no greater than budget, the cost of the path and
+// whether such a path exists. The cost and resource consumption of each edge
+// are given by the cost and resource functions. ConstrainedShortest will panic
+// if cost or resource returns a negative value for an edge reachable from s.
+//
+// ConstrainedShortest solves the resource constrained shortest path problem
+// using a label-setting algorithm. Each node holds a set of labels, each being
+// the cost and resource consumption of a path from s, and labels are expanded
+// in order of increasing cost. A label is discarded if its resource consumption
+// exceeds budget or if it is dominated by a label already held at its node, that
+// is, another path to the node has no greater cost and no greater resource
+// consumption. The first label reaching t is the least cost path.
+//
+// The problem is NP-hard in general, and the number of labels held at a node may
+// grow exponentially with the size of g. When resource consumptions are integers,
+// the number of labels at each node is bounded by budget+1, so the algorithm is
+// pseudo-polynomial, taking O(budget.|E|.log(budget.|E|)) time.
+func ConstrainedShortest(g graph.Graph, s, t graph.Node, cost, resource func(e graph.Edge) float64, budget float64) (path []graph.Node, weight float64, ok bool) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil || budget < 0 {
+		return nil, math.Inf(1), false
+	}
+	tid := t.ID()
+
+	labels := make(map[int64][]*paretoLabel)
+	q := paretoQueue{{node: s}}
+	for q.Len() != 0 {
+		l := heap.Pop(&q).(*paretoLabel)
+		uid := l.node.ID()
+		if uid == tid {
+			var n int
+			for p := l; p != nil; p = p.prev {
+				n++
+			}
+			path = make([]graph.Node, n)
+			for p := l; p != nil; p = p.prev {
+				n--
+				path[n] = p.node
+			}
+			return path, l.cost[0], true
+		}
+		if dominated(labels[uid], l.cost, 0) {
+			continue
+		}
+		labels[uid] = append(labels[uid], l)
+
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			e := g.Edge(uid, vid)
+			c := cost(e)
+			r := resource(e)
+			if c < 0 || r < 0 {
+				panic("constrained shortest: negative edge weight")
+			}
+			next := [2]float64{l.cost[0] + c, l.cost[1] + r}
+			if next[1] > budget || dominated(labels[vid], next, 0) {
+				continue
+			}
+			heap.Push(&q, &paretoLabel{node: v, cost: next, prev: l})
+		}
+	}
+	return nil, math.Inf(1), false
+}
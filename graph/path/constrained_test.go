@@ -0,0 +1,122 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func edgeCost(e graph.Edge) float64     { return e.(paretoEdge).Cost[0] }
+func edgeResource(e graph.Edge) float64 { return e.(paretoEdge).Cost[1] }
+
+func TestConstrainedShortest(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range []paretoEdge{
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1)}, Cost: [2]float64{1, 5}},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(3)}, Cost: [2]float64{1, 5}},
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2)}, Cost: [2]float64{4, 1}},
+		{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3)}, Cost: [2]float64{4, 1}},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2)}, Cost: [2]float64{1, 1}},
+	} {
+		g.SetEdge(e)
+	}
+
+	for _, test := range []struct {
+		budget float64
+		want   []int64
+		cost   float64
+		ok     bool
+	}{
+		{budget: 10, want: []int64{0, 1, 3}, cost: 2, ok: true},
+		{budget: 9, want: []int64{0, 1, 2, 3}, cost: 6, ok: true},
+		{budget: 7, want: []int64{0, 1, 2, 3}, cost: 6, ok: true},
+		{budget: 6, want: []int64{0, 2, 3}, cost: 8, ok: true},
+		{budget: 2, want: []int64{0, 2, 3}, cost: 8, ok: true},
+		{budget: 1, cost: math.Inf(1), ok: false},
+	} {
+		p, cost, ok := ConstrainedShortest(g, simple.Node(0), simple.Node(3), edgeCost, edgeResource, test.budget)
+		if ok != test.ok || cost != test.cost {
+			t.Errorf("unexpected result for budget %v: got:%v,%t want:%v,%t", test.budget, cost, ok, test.cost, test.ok)
+		}
+		var ids []int64
+		for _, n := range p {
+			ids = append(ids, n.ID())
+		}
+		if !equalIDs(ids, test.want) {
+			t.Errorf("unexpected path for budget %v: got:%v want:%v", test.budget, ids, test.want)
+		}
+	}
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConstrainedShortestRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(7)
+		g := simple.NewDirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					g.SetEdge(paretoEdge{
+						Edge: simple.Edge{F: simple.Node(u), T: simple.Node(v)},
+						Cost: [2]float64{float64(rnd.Intn(10)), float64(rnd.Intn(10))},
+					})
+				}
+			}
+		}
+		s, tn := simple.Node(0), simple.Node(n-1)
+		budget := float64(rnd.Intn(20))
+
+		want, wantOK := math.Inf(1), false
+		for _, c := range bruteForcePareto(g, s, tn) {
+			if c[1] <= budget && c[0] < want {
+				want, wantOK = c[0], true
+			}
+		}
+
+		p, got, ok := ConstrainedShortest(g, s, tn, edgeCost, edgeResource, budget)
+		if got != want || ok != wantOK {
+			t.Errorf("unexpected result for test %d with budget %v: got:%v,%t want:%v,%t", i, budget, got, ok, want, wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+			t.Errorf("returned path is not a path from s to t: %v", p)
+			continue
+		}
+		var cost, resource float64
+		for k := 1; k < len(p); k++ {
+			e := g.Edge(p[k-1].ID(), p[k].ID())
+			cost += edgeCost(e)
+			resource += edgeResource(e)
+		}
+		if cost != got || resource > budget {
+			t.Errorf("path does not match result: cost:%v resource:%v want cost:%v budget:%v", cost, resource, got, budget)
+		}
+	}
+}
@@ -4,7 +4,15 @@
 
 package path
 
-import "gonum.org/v1/gonum/graph"
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
 
 // Dominators returns a dominator tree for all nodes in the flow graph
 // g starting from the given root node.
@@ -207,3 +215,43 @@ func (d DominatorTree) DominatorOf(id int64) graph.Node {
 func (d DominatorTree) DominatedBy(id int64) []graph.Node {
 	return d.dominatedBy[id]
 }
+
+// WriteTree writes the tree to w as an indented outline with the root at the
+// left margin and each node on its own line below its immediate dominator,
+// indented by two spaces more than it. Nodes immediately dominated by the same
+// node are written in order of ID. Each node is written as label(n), or as its
+// ID if label is nil. WriteTree returns the first error returned by w.
+func (d DominatorTree) WriteTree(w io.Writer, label func(graph.Node) string) error {
+	if d.root == nil {
+		return nil
+	}
+	if label == nil {
+		label = func(n graph.Node) string { return fmt.Sprint(n.ID()) }
+	}
+	var write func(n graph.Node, depth int) error
+	write = func(n graph.Node, depth int) error {
+		_, err := fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", depth), label(n))
+		if err != nil {
+			return err
+		}
+		children := make([]graph.Node, len(d.dominatedBy[n.ID()]))
+		copy(children, d.dominatedBy[n.ID()])
+		sort.Sort(ordered.ByID(children))
+		for _, c := range children {
+			err = write(c, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return write(d.root, 0)
+}
+
+// String returns the tree as an indented outline of node IDs as written
+// by WriteTree.
+func (d DominatorTree) String() string {
+	var buf strings.Builder
+	d.WriteTree(&buf, nil)
+	return buf.String()
+}
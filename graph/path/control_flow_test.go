@@ -5,6 +5,8 @@
 package path
 
 import (
+	"bytes"
+	"errors"
 	"reflect"
 	"sort"
 	"testing"
@@ -184,3 +186,48 @@ func TestDominators(t *testing.T) {
 		}
 	}
 }
+
+func TestDominatorTreeWriteTree(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: char('R'), T: char('B')},
+		{F: char('R'), T: char('A')},
+		{F: char('A'), T: char('C')},
+		{F: char('B'), T: char('C')},
+		{F: char('C'), T: char('D')},
+	} {
+		g.SetEdge(e)
+	}
+	tree := Dominators(char('R'), g)
+
+	var buf bytes.Buffer
+	err := tree.WriteTree(&buf, func(n graph.Node) string { return n.(char).String() })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `R
+  A
+  B
+  C
+    D
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected tree:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = "82\n  65\n  66\n  67\n    68\n"
+	if got := tree.String(); got != want {
+		t.Errorf("unexpected tree string:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	err = tree.WriteTree(errWriter{}, nil)
+	if err != errWrite {
+		t.Errorf("unexpected error: got:%v want:%v", err, errWrite)
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errWrite }
@@ -0,0 +1,332 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// DenseAllShortest is an all-pairs shortest-path tree for a graph with dense
+// node IDs, created by the FloydWarshallDense or JohnsonAllPathsDense
+// functions. The node IDs of the analysed graph must be 0 to n-1 for a
+// graph with n nodes, so that the ID of each node is its index into the
+// tree's flat distance and path tables and no map lookups are needed when
+// querying the tree. DenseRelabel can be used to obtain a graph with dense
+// node IDs from an arbitrary graph.
+type DenseAllShortest struct {
+	// nodes holds the nodes of the
+	// analysed graph indexed by ID.
+	nodes []graph.Node
+
+	// dist and next represent the
+	// shortest paths between nodes
+	// as for AllShortest, indexed
+	// from*len(nodes)+to.
+	dist []float64
+	next [][]int
+
+	// forward indicates the direction
+	// of path reconstruction.
+	forward bool
+}
+
+// FloydWarshallDense returns a shortest-path tree for the graph g or false
+// indicating that a negative cycle exists in the graph, as described for
+// FloydWarshall. FloydWarshallDense will panic if the node IDs of g are not
+// dense.
+//
+// The paths are found by FloydWarshall and then reindexed. The path tables
+// are reused, but the distance table is copied, so peak memory use for the
+// distances is twice that needed by the returned tree.
+func FloydWarshallDense(g graph.Graph) (paths DenseAllShortest, ok bool) {
+	checkDense(g)
+	all, ok := FloydWarshall(g)
+	return newDenseAllShortest(all), ok
+}
+
+// JohnsonAllPathsDense returns a shortest-path tree for shortest paths in
+// the graph g, as described for JohnsonAllPaths. JohnsonAllPathsDense will
+// panic if the node IDs of g are not dense.
+//
+// As for FloydWarshallDense, the distance table found by JohnsonAllPaths is
+// copied, so peak memory use for the distances is twice that needed by the
+// returned tree.
+func JohnsonAllPathsDense(g graph.Graph) (paths DenseAllShortest, ok bool) {
+	checkDense(g)
+	all, ok := JohnsonAllPaths(g)
+	return newDenseAllShortest(all), ok
+}
+
+// checkDense panics if the node IDs of g are not 0 to n-1.
+func checkDense(g graph.Graph) {
+	nodes := g.Nodes()
+	n := int64(nodes.Len())
+	for nodes.Next() {
+		id := nodes.Node().ID()
+		if id < 0 || n <= id {
+			panic("path: node IDs not dense")
+		}
+	}
+}
+
+// newDenseAllShortest returns the paths of p reindexed by node ID. The node
+// IDs of p must be dense. The path slices of p are reused and their
+// contents are rewritten in place, so p must not be used afterwards.
+func newDenseAllShortest(p AllShortest) DenseAllShortest {
+	n := len(p.nodes)
+	if n == 0 {
+		return DenseAllShortest{forward: p.forward}
+	}
+	nodes := make([]graph.Node, n)
+	for _, u := range p.nodes {
+		nodes[u.ID()] = u
+	}
+	d := DenseAllShortest{
+		nodes:   nodes,
+		dist:    make([]float64, n*n),
+		next:    make([][]int, n*n),
+		forward: p.forward,
+	}
+	for i, u := range p.nodes {
+		from := int(u.ID())
+		for j, v := range p.nodes {
+			to := int(v.ID())
+			d.dist[from*n+to] = p.dist.At(i, j)
+			mid := p.at(i, j)
+			if len(mid) == 0 {
+				continue
+			}
+			for k, m := range mid {
+				mid[k] = int(p.nodes[m].ID())
+			}
+			d.next[from*n+to] = mid
+		}
+	}
+	return d
+}
+
+// At returns the weight of the minimum path from the node with ID i to the
+// node with ID j. At does not check that i and j are nodes of the analysed
+// graph and will panic if either is out of range. At returns -Inf if the path
+// includes a negative cycle.
+func (p DenseAllShortest) At(i, j int) float64 {
+	w := p.dist[i*len(p.nodes)+j]
+	if math.Float64bits(w) == defacedBits {
+		return math.Inf(-1)
+	}
+	return w
+}
+
+// Node returns the node with the given ID in the analysed graph, or nil if
+// the node does not exist.
+func (p DenseAllShortest) Node(id int64) graph.Node {
+	if !p.has(id) {
+		return nil
+	}
+	return p.nodes[id]
+}
+
+// has returns whether id is the ID of a node in the analysed graph.
+func (p DenseAllShortest) has(id int64) bool {
+	return 0 <= id && id < int64(len(p.nodes))
+}
+
+// WeightTo returns the weight of the minimum path from u to v. If either
+// node is not in the analysed graph, WeightTo returns +Inf.
+func (p DenseAllShortest) WeightTo(uid, vid int64) float64 {
+	if !p.has(uid) || !p.has(vid) {
+		return math.Inf(1)
+	}
+	return p.At(int(uid), int(vid))
+}
+
+// Between returns a shortest path from u to v and the weight of the path,
+// with the same semantics as AllShortest.Between.
+func (p DenseAllShortest) Between(uid, vid int64) (path []graph.Node, weight float64, unique bool) {
+	if !p.has(uid) || !p.has(vid) {
+		return nil, math.Inf(1), false
+	}
+	n := len(p.nodes)
+	from, to := int(uid), int(vid)
+	if len(p.next[from*n+to]) == 0 {
+		if uid == vid {
+			return []graph.Node{p.nodes[from]}, 0, true
+		}
+		return nil, math.Inf(1), false
+	}
+
+	weight = p.dist[from*n+to]
+	if math.Float64bits(weight) == defacedBits {
+		return nil, math.Inf(-1), false
+	}
+
+	seen := make([]int, n)
+	for i := range seen {
+		seen[i] = -1
+	}
+	var u graph.Node
+	if p.forward {
+		u = p.nodes[from]
+		seen[from] = 0
+	} else {
+		u = p.nodes[to]
+		seen[to] = 0
+	}
+
+	path = []graph.Node{u}
+	unique = true
+
+	var next int
+	for from != to {
+		c := p.next[from*n+to]
+		if len(c) != 1 {
+			unique = false
+			next = c[rand.Intn(len(c))]
+		} else {
+			next = c[0]
+		}
+		if seen[next] >= 0 {
+			path = path[:seen[next]]
+		}
+		seen[next] = len(path)
+		path = append(path, p.nodes[next])
+		if p.forward {
+			from = next
+		} else {
+			to = next
+		}
+	}
+	if !p.forward {
+		ordered.Reverse(path)
+	}
+
+	return path, weight, unique
+}
+
+// DenseRelabel returns a view of g with dense node IDs suitable for use with
+// FloydWarshallDense and JohnsonAllPathsDense, and the table of IDs of the
+// nodes of g indexed by their dense IDs. Nodes of g are assigned dense IDs in
+// order of their IDs in g. The nodes of the returned graph are simple.Node
+// values and the returned graph implements Weighted, using the weights of g
+// if g implements Weighted and UniformCost otherwise. If g implements
+// graph.Directed, so does the returned graph.
+func DenseRelabel(g graph.Graph) (dense graph.Graph, ids []int64) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	ids = make([]int64, len(nodes))
+	indexOf := make(map[int64]int64, len(nodes))
+	for i, u := range nodes {
+		ids[i] = u.ID()
+		indexOf[u.ID()] = int64(i)
+	}
+	r := relabeled{g: g, ids: ids, indexOf: indexOf}
+	if wg, ok := g.(Weighted); ok {
+		r.weight = wg.Weight
+	} else {
+		r.weight = UniformCost(g)
+	}
+	if dg, ok := g.(graph.Directed); ok {
+		return relabeledDirected{relabeled: r, g: dg}, ids
+	}
+	return r, ids
+}
+
+// relabeled is a view of a graph with dense node IDs.
+type relabeled struct {
+	g       graph.Graph
+	ids     []int64
+	indexOf map[int64]int64
+	weight  Weighting
+}
+
+var (
+	_ graph.Graph    = relabeled{}
+	_ Weighted       = relabeled{}
+	_ graph.Directed = relabeledDirected{}
+	_ Weighted       = relabeledDirected{}
+)
+
+func (g relabeled) has(id int64) bool {
+	return 0 <= id && id < int64(len(g.ids))
+}
+
+func (g relabeled) Node(id int64) graph.Node {
+	if !g.has(id) {
+		return nil
+	}
+	return simple.Node(id)
+}
+
+func (g relabeled) Nodes() graph.Nodes {
+	return iterator.NewImplicitNodes(0, len(g.ids), func(id int) graph.Node { return simple.Node(id) })
+}
+
+func (g relabeled) From(id int64) graph.Nodes {
+	if !g.has(id) {
+		return graph.Empty
+	}
+	return g.relabel(g.g.From(g.ids[id]))
+}
+
+// relabel returns the nodes of it with dense IDs.
+func (g relabeled) relabel(it graph.Nodes) graph.Nodes {
+	var nodes []graph.Node
+	for it.Next() {
+		nodes = append(nodes, simple.Node(g.indexOf[it.Node().ID()]))
+	}
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+func (g relabeled) HasEdgeBetween(xid, yid int64) bool {
+	if !g.has(xid) || !g.has(yid) {
+		return false
+	}
+	return g.g.HasEdgeBetween(g.ids[xid], g.ids[yid])
+}
+
+func (g relabeled) Edge(uid, vid int64) graph.Edge {
+	if !g.has(uid) || !g.has(vid) || g.g.Edge(g.ids[uid], g.ids[vid]) == nil {
+		return nil
+	}
+	return simple.Edge{F: simple.Node(uid), T: simple.Node(vid)}
+}
+
+func (g relabeled) Weight(xid, yid int64) (w float64, ok bool) {
+	if !g.has(xid) || !g.has(yid) {
+		return math.Inf(1), false
+	}
+	return g.weight(g.ids[xid], g.ids[yid])
+}
+
+// relabeledDirected is a view of a directed graph with dense node IDs.
+type relabeledDirected struct {
+	relabeled
+	g graph.Directed
+}
+
+func (g relabeledDirected) HasEdgeFromTo(uid, vid int64) bool {
+	if !g.has(uid) || !g.has(vid) {
+		return false
+	}
+	return g.g.HasEdgeFromTo(g.ids[uid], g.ids[vid])
+}
+
+func (g relabeledDirected) To(id int64) graph.Nodes {
+	if !g.has(id) {
+		return graph.Empty
+	}
+	return g.relabel(g.g.To(g.ids[id]))
+}
@@ -0,0 +1,202 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func randomWeightedDirected(rnd *rand.Rand, ids []int64, p float64) *simple.WeightedDirectedGraph {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, id := range ids {
+		g.AddNode(simple.Node(id))
+	}
+	for _, u := range ids {
+		for _, v := range ids {
+			if u != v && rnd.Float64() < p {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(5))})
+			}
+		}
+	}
+	return g
+}
+
+func TestDenseAllShortest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 20; test++ {
+		n := 1 + rnd.Intn(15)
+		ids := make([]int64, n)
+		for i := range ids {
+			ids[i] = int64(i)
+		}
+		g := randomWeightedDirected(rnd, ids, 0.3)
+
+		want, _ := FloydWarshall(g)
+		for _, fn := range []struct {
+			name string
+			apsp func(graph.Graph) (DenseAllShortest, bool)
+		}{
+			{name: "FloydWarshallDense", apsp: FloydWarshallDense},
+			{name: "JohnsonAllPathsDense", apsp: JohnsonAllPathsDense},
+		} {
+			got, ok := fn.apsp(g)
+			if !ok {
+				t.Fatalf("unexpected negative cycle for %s test %d", fn.name, test)
+			}
+			for _, u := range ids {
+				if got.Node(u) != g.Node(u) {
+					t.Errorf("unexpected node for %s test %d: got:%v want:%v", fn.name, test, got.Node(u), g.Node(u))
+				}
+				for _, v := range ids {
+					w := want.Weight(u, v)
+					if gw := got.WeightTo(u, v); gw != w {
+						t.Errorf("unexpected weight from %d to %d for %s test %d: got:%v want:%v", u, v, fn.name, test, gw, w)
+					}
+					if gw := got.At(int(u), int(v)); gw != w {
+						t.Errorf("unexpected At(%d, %d) for %s test %d: got:%v want:%v", u, v, fn.name, test, gw, w)
+					}
+					path, pw, _ := got.Between(u, v)
+					if pw != w {
+						t.Errorf("unexpected path weight from %d to %d for %s test %d: got:%v want:%v", u, v, fn.name, test, pw, w)
+					}
+					if math.IsInf(w, 1) {
+						if path != nil {
+							t.Errorf("unexpected path from %d to %d for %s test %d: %v", u, v, fn.name, test, pathIDs([][]graph.Node{path}))
+						}
+						continue
+					}
+					var sum float64
+					for i := 1; i < len(path); i++ {
+						e := g.WeightedEdge(path[i-1].ID(), path[i].ID())
+						if e == nil {
+							t.Fatalf("path from %d to %d for %s test %d uses missing edge", u, v, fn.name, test)
+						}
+						sum += e.Weight()
+					}
+					if path[0].ID() != u || path[len(path)-1].ID() != v || sum != w {
+						t.Errorf("invalid path from %d to %d for %s test %d: %v weight:%v", u, v, fn.name, test, pathIDs([][]graph.Node{path}), sum)
+					}
+				}
+			}
+			if w := got.WeightTo(-1, 0); !math.IsInf(w, 1) {
+				t.Errorf("unexpected weight for missing node for %s: got:%v want:+Inf", fn.name, w)
+			}
+		}
+	}
+}
+
+func TestDenseRelabel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ids := []int64{-7, 3, 12, 40, 41, 1000}
+	g := randomWeightedDirected(rnd, ids, 0.4)
+
+	dense, table := DenseRelabel(g)
+	for i, id := range table {
+		if id != ids[i] {
+			t.Fatalf("unexpected ID table: got:%v want:%v", table, ids)
+		}
+	}
+	dg, ok := dense.(graph.Directed)
+	if !ok {
+		t.Fatal("relabeled directed graph does not implement graph.Directed")
+	}
+	for i, u := range table {
+		var to []int64
+		for _, n := range graph.NodesOf(dg.To(int64(i))) {
+			to = append(to, table[n.ID()])
+		}
+		want := graph.NodesOf(g.To(u))
+		if len(to) != len(want) {
+			t.Errorf("unexpected number of nodes to %d: got:%d want:%d", u, len(to), len(want))
+		}
+		for _, v := range to {
+			if !g.HasEdgeFromTo(v, u) {
+				t.Errorf("unexpected edge %d→%d", v, u)
+			}
+		}
+		for j, v := range table {
+			if got, want := dg.HasEdgeFromTo(int64(j), int64(i)), g.HasEdgeFromTo(v, u); got != want {
+				t.Errorf("unexpected edge %d→%d status: got:%t want:%t", v, u, got, want)
+			}
+		}
+	}
+	undirected, _ := DenseRelabel(simple.NewUndirectedGraph())
+	if _, ok := undirected.(graph.Directed); ok {
+		t.Error("relabeled undirected graph implements graph.Directed")
+	}
+
+	got, ok := FloydWarshallDense(dense)
+	if !ok {
+		t.Fatal("unexpected negative cycle")
+	}
+	want, _ := FloydWarshall(g)
+	for i, u := range table {
+		for j, v := range table {
+			if w, gw := want.Weight(u, v), got.At(i, j); gw != w {
+				t.Errorf("unexpected weight from %d to %d: got:%v want:%v", u, v, gw, w)
+			}
+		}
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		FloydWarshallDense(g)
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for graph without dense IDs")
+	}
+}
+
+var (
+	denseBenchGraph = randomWeightedDirected(rand.New(rand.NewSource(1)), denseIDs(200), 0.1)
+	denseBenchAll   AllShortest
+	denseBenchDense DenseAllShortest
+	denseBenchSink  float64
+)
+
+func denseIDs(n int) []int64 {
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	return ids
+}
+
+func BenchmarkAllShortestWeight(b *testing.B) {
+	if denseBenchAll.nodes == nil {
+		denseBenchAll, _ = FloydWarshall(denseBenchGraph)
+	}
+	n := int64(len(denseBenchAll.nodes))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for u := int64(0); u < n; u++ {
+			for v := int64(0); v < n; v++ {
+				denseBenchSink = denseBenchAll.Weight(u, v)
+			}
+		}
+	}
+}
+
+func BenchmarkDenseAllShortestWeightTo(b *testing.B) {
+	if denseBenchDense.nodes == nil {
+		denseBenchDense, _ = FloydWarshallDense(denseBenchGraph)
+	}
+	n := int64(len(denseBenchDense.nodes))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for u := int64(0); u < n; u++ {
+			for v := int64(0); v < n; v++ {
+				denseBenchSink = denseBenchDense.WeightTo(u, v)
+			}
+		}
+	}
+}
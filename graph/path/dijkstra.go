@@ -6,6 +6,7 @@ package path
 
 import (
 	"container/heap"
+	"math"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/traverse"
@@ -163,3 +164,64 @@ func (q *priorityQueue) Pop() interface{} {
 	n, *q = t[len(t)-1], t[:len(t)-1]
 	return n
 }
+
+// DijkstraNodeWeighted returns a shortest-path tree for a shortest path from u
+// to all nodes in the graph g where the cost of a path is the sum of the costs
+// of the nodes it visits, given by nodeCost. The cost of the start node u is
+// included in the cost of each path if includeStart is true, and the cost of
+// the end node of each path is included if includeEnd is true. The path from
+// u to itself visits u once, so its cost is the cost of u only when both end
+// nodes are included. DijkstraNodeWeighted will panic if a node reachable
+// from u, other than u, has a negative cost.
+//
+// The shortest paths are found using DijkstraFrom with the cost of each node
+// used as the weight of each edge leading to the node.
+//
+// The time complexity of DijkstraNodeWeighted is O(|E|.log|V|).
+func DijkstraNodeWeighted(u graph.Node, g graph.Graph, nodeCost func(graph.Node) float64, includeStart, includeEnd bool) Shortest {
+	path := DijkstraFrom(u, nodeCostGraph{Graph: g, cost: nodeCost, from: u.ID()})
+	if path.nodes == nil {
+		return path
+	}
+	from := path.indexOf[u.ID()]
+	for i, d := range path.dist {
+		if math.IsInf(d, 1) {
+			continue
+		}
+		if includeStart {
+			d += nodeCost(u)
+		}
+		if !includeEnd && i != from {
+			d -= nodeCost(path.nodes[i])
+		}
+		path.dist[i] = d
+	}
+	if !includeStart || !includeEnd {
+		path.dist[from] = 0
+	}
+	return path
+}
+
+// nodeCostGraph is a graph weighted by the cost of the
+// node that each edge leads to. Edges leading to the
+// source node, from, have zero weight since they are
+// never on a shortest path, so the cost of the source
+// is not evaluated.
+type nodeCostGraph struct {
+	graph.Graph
+	cost func(graph.Node) float64
+	from int64
+}
+
+func (g nodeCostGraph) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return 0, true
+	}
+	if g.Edge(xid, yid) == nil {
+		return math.Inf(1), false
+	}
+	if yid == g.from {
+		return 0, true
+	}
+	return g.cost(g.Node(yid)), true
+}
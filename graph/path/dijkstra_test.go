@@ -10,9 +10,12 @@ import (
 	"sort"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/traverse"
 )
 
@@ -194,3 +197,109 @@ func TestDijkstraAllPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestDijkstraNodeWeighted(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 50; test++ {
+		n := 1 + rnd.Intn(12)
+		g := simple.NewDirectedGraph()
+		costs := make(map[int64]float64)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+			costs[int64(i)] = float64(rnd.Intn(10))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.25 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		cost := func(n graph.Node) float64 { return costs[n.ID()] }
+
+		// Reduce the node costs to explicit edge weights.
+		reduced := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			reduced.AddNode(u)
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			reduced.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: cost(e.To())})
+		}
+
+		src := simple.Node(rnd.Intn(n))
+		want := DijkstraFrom(src, reduced)
+		for _, includeStart := range []bool{false, true} {
+			for _, includeEnd := range []bool{false, true} {
+				got := DijkstraNodeWeighted(src, g, cost, includeStart, includeEnd)
+				for _, v := range graph.NodesOf(g.Nodes()) {
+					vid := v.ID()
+					w := want.WeightTo(vid)
+					if !math.IsInf(w, 1) {
+						if includeStart {
+							w += cost(src)
+						}
+						if !includeEnd {
+							w -= cost(v)
+						}
+						if vid == src.ID() && (!includeStart || !includeEnd) {
+							w = 0
+						}
+					}
+					if gw := got.WeightTo(vid); gw != w {
+						t.Errorf("unexpected weight to %d from %d for test %d start=%t end=%t: got:%v want:%v",
+							vid, src.ID(), test, includeStart, includeEnd, gw, w)
+					}
+					path, pw := got.To(vid)
+					if pw != w {
+						t.Errorf("unexpected path weight to %d from %d for test %d start=%t end=%t: got:%v want:%v",
+							vid, src.ID(), test, includeStart, includeEnd, pw, w)
+					}
+					var sum float64
+					for i, n := range path {
+						if (i == 0 && !includeStart) || (i == len(path)-1 && !includeEnd) {
+							continue
+						}
+						sum += cost(n)
+					}
+					if path != nil && sum != w {
+						t.Errorf("unexpected sum of node costs on path to %d from %d for test %d start=%t end=%t: got:%v want:%v",
+							vid, src.ID(), test, includeStart, includeEnd, sum, w)
+					}
+				}
+			}
+		}
+	}
+
+	// Including and excluding the costs of the end nodes of the path 0-1-2.
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	cost := func(n graph.Node) float64 { return float64(n.ID() + 1) }
+	for _, test := range []struct {
+		includeStart, includeEnd bool
+		want                     float64
+	}{
+		{includeStart: false, includeEnd: false, want: 2},
+		{includeStart: false, includeEnd: true, want: 5},
+		{includeStart: true, includeEnd: false, want: 3},
+		{includeStart: true, includeEnd: true, want: 6},
+	} {
+		pt := DijkstraNodeWeighted(simple.Node(0), g, cost, test.includeStart, test.includeEnd)
+		if w := pt.WeightTo(2); w != test.want {
+			t.Errorf("unexpected weight with start=%t end=%t: got:%v want:%v", test.includeStart, test.includeEnd, w, test.want)
+		}
+	}
+
+	// A negative cost for the start node is allowed
+	// when edges lead back to it.
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(2)})
+	pt := DijkstraNodeWeighted(simple.Node(0), g, func(n graph.Node) float64 {
+		if n.ID() == 0 {
+			return -1
+		}
+		return 5
+	}, true, true)
+	if w := pt.WeightTo(2); w != 4 {
+		t.Errorf("unexpected weight with negative start cost: got:%v want:4", w)
+	}
+}
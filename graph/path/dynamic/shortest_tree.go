@@ -0,0 +1,283 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// ShortestPathTree is a single source shortest path tree that is repaired
+// as edge weights change, rather than being recomputed.
+//
+// The repair of a weight decrease only visits the nodes whose distance from
+// the source improves. The repair of a weight increase on a tree edge only
+// visits the subtree below the edge and the edges entering it, following
+// the approach of Ramalingam and Reps doi:10.1006/jagm.1996.0046.
+type ShortestPathTree struct {
+	from graph.Node
+
+	model WorldModel
+
+	dist     map[int64]float64
+	parent   map[int64]int64
+	children map[int64]set.Int64s
+}
+
+// NewShortestPathTree returns a new ShortestPathTree holding the shortest
+// paths from u to all nodes in g. The world model, m, is used to store the
+// edge weights as they are changed and must be an empty graph when
+// NewShortestPathTree is called. If g is undirected each of its edges is
+// held as a pair of opposing edges in m.
+//
+// If the graph does not implement graph.Weighter, path.UniformCost is used.
+// NewShortestPathTree will panic if g has a negative edge weight.
+func NewShortestPathTree(u graph.Node, g graph.Graph, m WorldModel) *ShortestPathTree {
+	var weight path.Weighting
+	if wg, ok := g.(graph.Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = path.UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	for _, n := range nodes {
+		m.AddNode(n)
+	}
+	for _, n := range nodes {
+		uid := n.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("dynamic: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dynamic: negative edge weight")
+			}
+			m.SetWeightedEdge(simple.WeightedEdge{F: n, T: m.Node(vid), W: w})
+		}
+	}
+
+	t := &ShortestPathTree{
+		from:     u,
+		model:    m,
+		dist:     make(map[int64]float64, len(nodes)),
+		parent:   make(map[int64]int64),
+		children: make(map[int64]set.Int64s),
+	}
+	if m.Node(u.ID()) == nil {
+		return t
+	}
+	t.dist[u.ID()] = 0
+	t.relax(sptQueue{{id: u.ID(), dist: 0}})
+	return t
+}
+
+// From returns the starting node of the paths held by the ShortestPathTree.
+func (t *ShortestPathTree) From() graph.Node { return t.from }
+
+// WeightTo returns the weight of the minimum path to v. If the node v is not
+// reachable from the source, WeightTo returns +Inf.
+func (t *ShortestPathTree) WeightTo(vid int64) float64 {
+	d, ok := t.dist[vid]
+	if !ok {
+		return math.Inf(1)
+	}
+	return d
+}
+
+// To returns a shortest path to v and the weight of the path. If the node v
+// is not reachable from the source, To returns nil and +Inf.
+func (t *ShortestPathTree) To(vid int64) (path []graph.Node, weight float64) {
+	weight = t.WeightTo(vid)
+	if math.IsInf(weight, 1) {
+		return nil, weight
+	}
+	for {
+		path = append(path, t.model.Node(vid))
+		p, ok := t.parent[vid]
+		if !ok {
+			break
+		}
+		vid = p
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, weight
+}
+
+// DecreaseEdge sets the weight of the edge from u to v to newWeight, adding
+// the edge if it is not held by the tree's world model, and repairs the tree.
+// Both nodes must be held by the world model. DecreaseEdge will panic if
+// newWeight is negative or greater than the current weight of the edge.
+func (t *ShortestPathTree) DecreaseEdge(u, v graph.Node, newWeight float64) {
+	uid, vid := u.ID(), v.ID()
+	if newWeight < 0 {
+		panic("dynamic: negative edge weight")
+	}
+	if newWeight > t.edgeWeight(uid, vid) {
+		panic("dynamic: edge weight increased")
+	}
+	t.setWeight(uid, vid, newWeight)
+
+	joint := t.WeightTo(uid) + newWeight
+	if joint >= t.WeightTo(vid) {
+		return
+	}
+	t.setParent(vid, uid)
+	t.dist[vid] = joint
+	t.relax(sptQueue{{id: vid, dist: joint}})
+}
+
+// IncreaseEdge sets the weight of the edge from u to v to newWeight and
+// repairs the tree. An edge with weight +Inf is not traversable. The edge
+// must be held by the tree's world model. IncreaseEdge will panic if
+// newWeight is less than the current weight of the edge.
+func (t *ShortestPathTree) IncreaseEdge(u, v graph.Node, newWeight float64) {
+	uid, vid := u.ID(), v.ID()
+	if !t.model.HasEdgeFromTo(uid, vid) {
+		panic("dynamic: no edge to increase")
+	}
+	old := t.edgeWeight(uid, vid)
+	if newWeight < old {
+		panic("dynamic: edge weight decreased")
+	}
+	t.setWeight(uid, vid, newWeight)
+	if p, ok := t.parent[vid]; !ok || p != uid || newWeight == old {
+		return
+	}
+
+	// Detach the subtree below v and give each of its nodes its
+	// best distance from the nodes outside the subtree.
+	affected := make(set.Int64s)
+	stack := []int64{vid}
+	for len(stack) != 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		affected.Add(n)
+		for c := range t.children[n] {
+			stack = append(stack, c)
+		}
+	}
+	for n := range affected {
+		t.detach(n)
+		delete(t.dist, n)
+	}
+	var q sptQueue
+	for n := range affected {
+		best := math.Inf(1)
+		var bestFrom int64
+		for _, p := range graph.NodesOf(t.model.To(n)) {
+			pid := p.ID()
+			if affected.Has(pid) {
+				continue
+			}
+			d := t.WeightTo(pid) + t.weight(pid, n)
+			if d < best || (d == best && pid < bestFrom) {
+				best = d
+				bestFrom = pid
+			}
+		}
+		if math.IsInf(best, 1) {
+			continue
+		}
+		t.setParent(n, bestFrom)
+		t.dist[n] = best
+		q = append(q, sptItem{id: n, dist: best})
+	}
+	heap.Init(&q)
+	t.relax(q)
+}
+
+// relax performs Dijkstra's algorithm from the nodes in q, updating the
+// distances and parents of nodes that are improved.
+func (t *ShortestPathTree) relax(q sptQueue) {
+	for q.Len() != 0 {
+		mid := heap.Pop(&q).(sptItem)
+		if mid.dist > t.dist[mid.id] {
+			continue
+		}
+		for _, v := range graph.NodesOf(t.model.From(mid.id)) {
+			vid := v.ID()
+			joint := mid.dist + t.weight(mid.id, vid)
+			if joint < t.WeightTo(vid) {
+				t.setParent(vid, mid.id)
+				t.dist[vid] = joint
+				heap.Push(&q, sptItem{id: vid, dist: joint})
+			}
+		}
+	}
+}
+
+// edgeWeight returns the weight of the edge from uid to vid in the world
+// model, or +Inf if there is no such edge.
+func (t *ShortestPathTree) edgeWeight(uid, vid int64) float64 {
+	if !t.model.HasEdgeFromTo(uid, vid) {
+		return math.Inf(1)
+	}
+	return t.weight(uid, vid)
+}
+
+// setWeight sets the weight of the edge from uid to vid in the world model.
+func (t *ShortestPathTree) setWeight(uid, vid int64, w float64) {
+	u := t.model.Node(uid)
+	v := t.model.Node(vid)
+	if u == nil || v == nil {
+		panic("dynamic: node not in world model")
+	}
+	t.model.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: w})
+}
+
+// weight returns the weight of the edge from uid to vid in the world model.
+func (t *ShortestPathTree) weight(uid, vid int64) float64 {
+	return t.model.WeightedEdge(uid, vid).Weight()
+}
+
+// setParent makes pid the parent of vid in the tree.
+func (t *ShortestPathTree) setParent(vid, pid int64) {
+	t.detach(vid)
+	t.parent[vid] = pid
+	c, ok := t.children[pid]
+	if !ok {
+		c = make(set.Int64s)
+		t.children[pid] = c
+	}
+	c.Add(vid)
+}
+
+// detach removes vid from the children of its parent in the tree.
+func (t *ShortestPathTree) detach(vid int64) {
+	if p, ok := t.parent[vid]; ok {
+		t.children[p].Remove(vid)
+		delete(t.parent, vid)
+	}
+}
+
+// sptItem is a node and its tentative distance from the source.
+type sptItem struct {
+	id   int64
+	dist float64
+}
+
+// sptQueue is a priority queue of tentative distances.
+type sptQueue []sptItem
+
+func (q sptQueue) Len() int            { return len(q) }
+func (q sptQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q sptQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *sptQueue) Push(n interface{}) { *q = append(*q, n.(sptItem)) }
+func (q *sptQueue) Pop() interface{} {
+	t := *q
+	var n sptItem
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
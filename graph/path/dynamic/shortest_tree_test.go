@@ -0,0 +1,139 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dynamic
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestShortestPathTree(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 5},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	tree := NewShortestPathTree(simple.Node(0), g, simple.NewWeightedDirectedGraph(0, math.Inf(1)))
+	checkPath(t, tree, 3, []int64{0, 1, 2, 3}, 3)
+
+	tree.IncreaseEdge(simple.Node(1), simple.Node(2), 10)
+	checkPath(t, tree, 3, []int64{0, 2, 3}, 6)
+
+	tree.DecreaseEdge(simple.Node(1), simple.Node(3), 1)
+	checkPath(t, tree, 3, []int64{0, 1, 3}, 2)
+
+	tree.IncreaseEdge(simple.Node(0), simple.Node(1), math.Inf(1))
+	checkPath(t, tree, 3, []int64{0, 2, 3}, 6)
+	checkPath(t, tree, 1, nil, math.Inf(1))
+}
+
+func checkPath(t *testing.T, tree *ShortestPathTree, vid int64, want []int64, wantWeight float64) {
+	t.Helper()
+	p, w := tree.To(vid)
+	if w != wantWeight {
+		t.Errorf("unexpected weight to %d: got:%v want:%v", vid, w, wantWeight)
+	}
+	if len(p) != len(want) {
+		t.Errorf("unexpected path to %d: got:%v want:%v", vid, p, want)
+		return
+	}
+	for i, n := range p {
+		if n.ID() != want[i] {
+			t.Errorf("unexpected path to %d: got:%v want:%v", vid, p, want)
+			return
+		}
+	}
+}
+
+func TestShortestPathTreeRandom(t *testing.T) {
+	const n = 25
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		// ref is updated in step with the tree and used
+		// to compute the expected distances afresh.
+		ref := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			ref.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			ref.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+		}
+		src := simple.Node(rnd.Intn(n))
+		tree := NewShortestPathTree(src, ref, simple.NewWeightedDirectedGraph(0, math.Inf(1)))
+		checkTree(t, tree, ref, trial, -1)
+
+		for step := 0; step < 100; step++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			old := math.Inf(1)
+			if e := ref.WeightedEdge(int64(u), int64(v)); e != nil {
+				old = e.Weight()
+			}
+			var w float64
+			switch {
+			case math.IsInf(old, 1) || rnd.Intn(2) == 0:
+				w = math.Floor(rnd.Float64() * math.Min(old, 10))
+				tree.DecreaseEdge(simple.Node(u), simple.Node(v), w)
+			case rnd.Intn(10) == 0:
+				w = math.Inf(1)
+				tree.IncreaseEdge(simple.Node(u), simple.Node(v), w)
+			default:
+				w = old + float64(rnd.Intn(10))
+				tree.IncreaseEdge(simple.Node(u), simple.Node(v), w)
+			}
+			ref.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+			checkTree(t, tree, ref, trial, step)
+		}
+	}
+}
+
+func checkTree(t *testing.T, tree *ShortestPathTree, ref *simple.WeightedDirectedGraph, trial, step int) {
+	t.Helper()
+	want := path.DijkstraFrom(tree.From(), ref)
+	for _, n := range graph.NodesOf(ref.Nodes()) {
+		id := n.ID()
+		wantWeight := want.WeightTo(id)
+		if got := tree.WeightTo(id); got != wantWeight {
+			t.Fatalf("unexpected weight to %d in trial %d step %d: got:%v want:%v", id, trial, step, got, wantWeight)
+		}
+		p, w := tree.To(id)
+		if math.IsInf(wantWeight, 1) {
+			if p != nil {
+				t.Fatalf("unexpected path to unreachable %d in trial %d step %d: %v", id, trial, step, p)
+			}
+			continue
+		}
+		if p[0].ID() != tree.From().ID() || p[len(p)-1].ID() != id {
+			t.Fatalf("path to %d in trial %d step %d has wrong ends: %v", id, trial, step, p)
+		}
+		var sum float64
+		for i, u := range p[1:] {
+			e := ref.WeightedEdge(p[i].ID(), u.ID())
+			if e == nil {
+				t.Fatalf("path to %d in trial %d step %d uses missing edge: %v", id, trial, step, p)
+			}
+			sum += e.Weight()
+		}
+		if sum != w {
+			t.Fatalf("path weight to %d in trial %d step %d does not match: got:%v want:%v", id, trial, step, sum, w)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// PathEditDistance returns the edit distance between the paths a and b, the
+// minimum number of node insertions, deletions and substitutions needed to
+// transform a into b. Nodes are compared by ID.
+//
+// The time complexity of PathEditDistance is O(|a|.|b|).
+func PathEditDistance(a, b []graph.Node) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			sub := prev[j-1]
+			if a[i-1].ID() != b[j-1].ID() {
+				sub++
+			}
+			del, ins := prev[j]+1, curr[j-1]+1
+			switch {
+			case del < sub && del <= ins:
+				curr[j] = del
+			case ins < sub:
+				curr[j] = ins
+			default:
+				curr[j] = sub
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// PathEditDistanceWeighted returns the weighted edit distance between the
+// paths a and b. Inserting or deleting a node costs indel. Substituting a
+// node x with a distinct node y costs the length of the edge from x to y
+// given by weight, and is only allowed if weight returns true for the pair,
+// so a path that deviates from another through nearby nodes is close to it.
+// Substituting a node with itself costs zero. If weight is nil, substitutions
+// of distinct nodes are not allowed.
+//
+// The time complexity of PathEditDistanceWeighted is O(|a|.|b|).
+func PathEditDistanceWeighted(a, b []graph.Node, weight Weighting, indel float64) float64 {
+	prev := make([]float64, len(b)+1)
+	curr := make([]float64, len(b)+1)
+	for j := range prev {
+		prev[j] = float64(j) * indel
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = float64(i) * indel
+		for j := 1; j <= len(b); j++ {
+			sub := math.Inf(1)
+			xid, yid := a[i-1].ID(), b[j-1].ID()
+			if xid == yid {
+				sub = prev[j-1]
+			} else if weight != nil {
+				if w, ok := weight(xid, yid); ok {
+					sub = prev[j-1] + w
+				}
+			}
+			curr[j] = math.Min(sub, math.Min(prev[j], curr[j-1])+indel)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,92 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func nodesOf(ids ...int64) []graph.Node {
+	nodes := make([]graph.Node, len(ids))
+	for i, id := range ids {
+		nodes[i] = simple.Node(id)
+	}
+	return nodes
+}
+
+func TestPathEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b []int64
+		want int
+	}{
+		{a: nil, b: nil, want: 0},
+		{a: []int64{0, 1, 2}, b: nil, want: 3},
+		{a: nil, b: []int64{0, 1}, want: 2},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 1, 2, 3}, want: 0},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 4, 2, 3}, want: 1},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 2, 3}, want: 1},
+		{a: []int64{0, 2, 3}, b: []int64{0, 1, 5, 2, 3}, want: 2},
+		{a: []int64{0, 1, 2, 3}, b: []int64{3, 2, 1, 0}, want: 4},
+		{a: []int64{0, 1, 2, 3, 4}, b: []int64{1, 2, 3, 4, 5}, want: 2},
+	} {
+		got := PathEditDistance(nodesOf(test.a...), nodesOf(test.b...))
+		if got != test.want {
+			t.Errorf("unexpected edit distance between %v and %v: got:%d want:%d", test.a, test.b, got, test.want)
+		}
+		if rev := PathEditDistance(nodesOf(test.b...), nodesOf(test.a...)); rev != got {
+			t.Errorf("edit distance not symmetric for %v and %v: got:%d and %d", test.a, test.b, got, rev)
+		}
+	}
+}
+
+func TestPathEditDistanceWeighted(t *testing.T) {
+	// A ladder of two parallel routes 0-1-2-3 and 4-5-6-7
+	// joined by rungs of differing lengths.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(4), T: simple.Node(5), W: 1},
+		{F: simple.Node(5), T: simple.Node(6), W: 1},
+		{F: simple.Node(6), T: simple.Node(7), W: 1},
+		{F: simple.Node(1), T: simple.Node(5), W: 0.5},
+		{F: simple.Node(2), T: simple.Node(6), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	for _, test := range []struct {
+		a, b  []int64
+		indel float64
+		want  float64
+	}{
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 1, 2, 3}, indel: 1, want: 0},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 5, 2, 3}, indel: 1, want: 0.5},
+		// Substituting 2 with 6 costs more than a deletion and an insertion.
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 1, 6, 3}, indel: 1, want: 2},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 1, 6, 3}, indel: 2, want: 3},
+		// Nodes 0 and 7 are not adjacent so cannot be substituted.
+		{a: []int64{0}, b: []int64{7}, indel: 10, want: 20},
+		{a: []int64{0, 1, 2, 3}, b: []int64{0, 2, 3}, indel: 1.5, want: 1.5},
+	} {
+		a, b := nodesOf(test.a...), nodesOf(test.b...)
+		got := PathEditDistanceWeighted(a, b, g.Weight, test.indel)
+		if got != test.want {
+			t.Errorf("unexpected weighted edit distance between %v and %v: got:%v want:%v", test.a, test.b, got, test.want)
+		}
+	}
+
+	// Without substitutions and with unit indel cost the distance is the
+	// number of nodes not in a longest common subsequence of the paths.
+	a, b := nodesOf(0, 1, 2, 3), nodesOf(0, 5, 2, 3)
+	if got := PathEditDistanceWeighted(a, b, nil, 1); got != 2 {
+		t.Errorf("unexpected weighted edit distance without weights: got:%v want:2", got)
+	}
+}
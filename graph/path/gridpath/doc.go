@@ -0,0 +1,6 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gridpath provides path finding routines for 2D grid graphs.
+package gridpath // import "gonum.org/v1/gonum/graph/path/gridpath"
@@ -0,0 +1,313 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+const (
+	Closed = '*' // Closed is the closed grid cell representation.
+	Open   = '.' // Open is the open grid cell representation.
+)
+
+var _ graph.WeightedUndirected = (*Grid)(nil)
+
+// Cell is a position in a grid.
+type Cell struct {
+	Row, Col int
+}
+
+// Grid is a 2D grid planar undirected graph. Each open cell of the grid
+// is a node with ID Row*c+Col, where c is the number of columns in the
+// grid. Edges join open cells that are horizontally or vertically adjacent
+// and, if AllowDiagonal is true, diagonally adjacent open cells where both
+// of the cells sharing a side with the two cells are also open, so paths
+// may not cut corners. Edge weights are the Euclidean distance between the
+// centers of the cells.
+type Grid struct {
+	// AllowDiagonal specifies whether
+	// diagonally adjacent cells can
+	// be connected by an edge.
+	AllowDiagonal bool
+
+	open []bool
+	r, c int
+}
+
+// NewGrid returns an r by c grid with all cells
+// set to the specified open state.
+func NewGrid(r, c int, open bool) *Grid {
+	states := make([]bool, r*c)
+	if open {
+		for i := range states {
+			states[i] = true
+		}
+	}
+	return &Grid{open: states, r: r, c: c}
+}
+
+// NewGridFrom returns a grid specified by the rows strings. All rows must
+// be the same length and must only contain the Open or Closed characters,
+// NewGridFrom will panic otherwise.
+func NewGridFrom(rows ...string) *Grid {
+	if len(rows) == 0 {
+		return &Grid{}
+	}
+	states := make([]bool, 0, len(rows)*len(rows[0]))
+	for _, r := range rows {
+		if len(r) != len(rows[0]) {
+			panic("gridpath: unequal row lengths")
+		}
+		for _, b := range r {
+			switch b {
+			case Closed:
+				states = append(states, false)
+			case Open:
+				states = append(states, true)
+			default:
+				panic(fmt.Sprintf("gridpath: invalid state: %q", r))
+			}
+		}
+	}
+	return &Grid{open: states, r: len(rows), c: len(rows[0])}
+}
+
+// Dims returns the dimensions of the grid.
+func (g *Grid) Dims() (r, c int) {
+	return g.r, g.c
+}
+
+// Set sets the cell at c to the specified open state. Set will
+// panic if c is outside the grid.
+func (g *Grid) Set(c Cell, open bool) {
+	if !g.contains(c) {
+		panic("gridpath: cell out of range")
+	}
+	g.open[c.Row*g.c+c.Col] = open
+}
+
+// IsOpen returns whether c is an open cell in the grid.
+func (g *Grid) IsOpen(c Cell) bool {
+	return g.contains(c) && g.open[c.Row*g.c+c.Col]
+}
+
+// contains returns whether c is within the bounds of the grid.
+func (g *Grid) contains(c Cell) bool {
+	return 0 <= c.Row && c.Row < g.r && 0 <= c.Col && c.Col < g.c
+}
+
+// CellOf returns the cell of the node with the given ID. CellOf will
+// panic if the ID is outside the range of the grid.
+func (g *Grid) CellOf(id int64) Cell {
+	if id < 0 || int64(len(g.open)) <= id {
+		panic("gridpath: illegal node id")
+	}
+	return Cell{Row: int(id) / g.c, Col: int(id) % g.c}
+}
+
+// NodeAt returns the node at c if c is an open cell in the grid,
+// and nil otherwise.
+func (g *Grid) NodeAt(c Cell) graph.Node {
+	if !g.IsOpen(c) {
+		return nil
+	}
+	return simple.Node(c.Row*g.c + c.Col)
+}
+
+// Node returns the node with the given ID if it exists in the graph,
+// and nil otherwise.
+func (g *Grid) Node(id int64) graph.Node {
+	if !g.has(id) {
+		return nil
+	}
+	return simple.Node(id)
+}
+
+// has returns whether id represents an open cell in the grid.
+func (g *Grid) has(id int64) bool {
+	return 0 <= id && id < int64(len(g.open)) && g.open[id]
+}
+
+// Nodes returns all the open cells in the grid.
+func (g *Grid) Nodes() graph.Nodes {
+	var nodes []graph.Node
+	for id, ok := range g.open {
+		if ok {
+			nodes = append(nodes, simple.Node(id))
+		}
+	}
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+// From returns all the nodes reachable directly from the node with
+// the given ID.
+func (g *Grid) From(id int64) graph.Nodes {
+	if !g.has(id) {
+		return graph.Empty
+	}
+	u := g.CellOf(id)
+	var to []graph.Node
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			v := Cell{Row: u.Row + dr, Col: u.Col + dc}
+			if g.adjacent(u, v) {
+				to = append(to, simple.Node(v.Row*g.c+v.Col))
+			}
+		}
+	}
+	if len(to) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(to)
+}
+
+// adjacent returns whether there is an edge between cells u and v.
+func (g *Grid) adjacent(u, v Cell) bool {
+	if u == v || !g.IsOpen(u) || !g.IsOpen(v) {
+		return false
+	}
+	dr := v.Row - u.Row
+	dc := v.Col - u.Col
+	if dr < -1 || 1 < dr || dc < -1 || 1 < dc {
+		return false
+	}
+	if dr == 0 || dc == 0 {
+		return true
+	}
+	return g.AllowDiagonal && g.IsOpen(Cell{Row: u.Row + dr, Col: u.Col}) && g.IsOpen(Cell{Row: u.Row, Col: u.Col + dc})
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes with IDs
+// xid and yid.
+func (g *Grid) HasEdgeBetween(xid, yid int64) bool {
+	if !g.has(xid) || !g.has(yid) {
+		return false
+	}
+	return g.adjacent(g.CellOf(xid), g.CellOf(yid))
+}
+
+// Edge returns the edge between nodes with IDs uid and vid if such an
+// edge exists and nil otherwise.
+func (g *Grid) Edge(uid, vid int64) graph.Edge {
+	return g.WeightedEdgeBetween(uid, vid)
+}
+
+// EdgeBetween returns the edge between nodes with IDs xid and yid if such
+// an edge exists and nil otherwise.
+func (g *Grid) EdgeBetween(xid, yid int64) graph.Edge {
+	return g.WeightedEdgeBetween(xid, yid)
+}
+
+// WeightedEdge returns the weighted edge between nodes with IDs uid and
+// vid if such an edge exists and nil otherwise.
+func (g *Grid) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	return g.WeightedEdgeBetween(uid, vid)
+}
+
+// WeightedEdgeBetween returns the weighted edge between nodes with IDs
+// xid and yid if such an edge exists and nil otherwise.
+func (g *Grid) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	if !g.HasEdgeBetween(xid, yid) {
+		return nil
+	}
+	return simple.WeightedEdge{F: simple.Node(xid), T: simple.Node(yid), W: distance(g.CellOf(xid), g.CellOf(yid))}
+}
+
+// Weight returns the weight for the edge between nodes with IDs xid and
+// yid if Edge(xid, yid) returns a non-nil Edge. If xid == yid, Weight
+// returns zero and true. Otherwise Weight returns +Inf and false.
+func (g *Grid) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return 0, true
+	}
+	if !g.HasEdgeBetween(xid, yid) {
+		return math.Inf(1), false
+	}
+	return distance(g.CellOf(xid), g.CellOf(yid)), true
+}
+
+// LineOfSight returns whether the straight line between the centers of
+// cells a and b passes only through open cells. The cells on the line
+// are those returned by Bresenham. Consistent with the movement rules of
+// the grid, a diagonal step along the line requires both of the cells
+// sharing a side with the two cells of the step to be open.
+func (g *Grid) LineOfSight(a, b Cell) bool {
+	line := Bresenham(a, b)
+	for i, c := range line {
+		if !g.IsOpen(c) {
+			return false
+		}
+		if i == 0 {
+			continue
+		}
+		p := line[i-1]
+		if p.Row != c.Row && p.Col != c.Col {
+			if !g.IsOpen(Cell{Row: p.Row, Col: c.Col}) || !g.IsOpen(Cell{Row: c.Row, Col: p.Col}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Bresenham returns the cells on the line from a to b, inclusive of the
+// end points, computed using Bresenham's line algorithm.
+func Bresenham(a, b Cell) []Cell {
+	dr := abs(b.Row - a.Row)
+	dc := abs(b.Col - a.Col)
+	sr := 1
+	if b.Row < a.Row {
+		sr = -1
+	}
+	sc := 1
+	if b.Col < a.Col {
+		sc = -1
+	}
+	line := make([]Cell, 0, max(dr, dc)+1)
+	err := dc - dr
+	for c := a; ; {
+		line = append(line, c)
+		if c == b {
+			return line
+		}
+		e2 := 2 * err
+		if e2 > -dr {
+			err -= dr
+			c.Col += sc
+		}
+		if e2 < dc {
+			err += dc
+			c.Row += sr
+		}
+	}
+}
+
+// distance returns the Euclidean distance between the centers of a and b.
+func distance(a, b Cell) float64 {
+	return math.Hypot(float64(a.Row-b.Row), float64(a.Col-b.Col))
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
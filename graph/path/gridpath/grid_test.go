@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var bresenhamTests = []struct {
+	a, b Cell
+	want []Cell
+}{
+	{a: Cell{0, 0}, b: Cell{0, 0}, want: []Cell{{0, 0}}},
+	{a: Cell{0, 0}, b: Cell{0, 3}, want: []Cell{{0, 0}, {0, 1}, {0, 2}, {0, 3}}},
+	{a: Cell{3, 1}, b: Cell{0, 1}, want: []Cell{{3, 1}, {2, 1}, {1, 1}, {0, 1}}},
+	{a: Cell{0, 0}, b: Cell{2, 2}, want: []Cell{{0, 0}, {1, 1}, {2, 2}}},
+	{a: Cell{0, 0}, b: Cell{1, 4}, want: []Cell{{0, 0}, {0, 1}, {0, 2}, {1, 3}, {1, 4}}},
+	{a: Cell{2, 4}, b: Cell{0, 0}, want: []Cell{{2, 4}, {2, 3}, {1, 2}, {1, 1}, {0, 0}}},
+}
+
+func TestBresenham(t *testing.T) {
+	for _, test := range bresenhamTests {
+		got := Bresenham(test.a, test.b)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected line from %v to %v: got:%v want:%v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestLineOfSight(t *testing.T) {
+	g := NewGridFrom(
+		"....",
+		".*..",
+		"....",
+		"..*.",
+	)
+	for _, test := range []struct {
+		a, b Cell
+		want bool
+	}{
+		{a: Cell{0, 0}, b: Cell{0, 3}, want: true},
+		{a: Cell{0, 0}, b: Cell{2, 2}, want: false},
+		{a: Cell{1, 0}, b: Cell{2, 1}, want: false},
+		{a: Cell{0, 2}, b: Cell{2, 3}, want: true},
+		{a: Cell{3, 0}, b: Cell{3, 3}, want: false},
+		{a: Cell{2, 0}, b: Cell{2, 3}, want: true},
+	} {
+		if got := g.LineOfSight(test.a, test.b); got != test.want {
+			t.Errorf("unexpected line of sight from %v to %v: got:%t want:%t", test.a, test.b, got, test.want)
+		}
+		if got := g.LineOfSight(test.b, test.a); got != test.want {
+			t.Errorf("unexpected line of sight from %v to %v: got:%t want:%t", test.b, test.a, got, test.want)
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := NewGridFrom(
+		"...",
+		".*.",
+		"...",
+	)
+	if got := g.Nodes().Len(); got != 8 {
+		t.Errorf("unexpected number of nodes: got:%d want:8", got)
+	}
+	if g.Node(4) != nil {
+		t.Error("unexpected node for closed cell")
+	}
+	if got := g.From(0).Len(); got != 2 {
+		t.Errorf("unexpected number of neighbors without diagonals: got:%d want:2", got)
+	}
+
+	g.AllowDiagonal = true
+	if got := g.From(0).Len(); got != 2 {
+		t.Errorf("unexpected number of neighbors with blocked corner: got:%d want:2", got)
+	}
+	g.Set(Cell{1, 1}, true)
+	if got := g.From(4).Len(); got != 8 {
+		t.Errorf("unexpected number of neighbors of center: got:%d want:8", got)
+	}
+	if w, ok := g.Weight(0, 4); w != math.Sqrt2 || !ok {
+		t.Errorf("unexpected diagonal weight: got:%v,%t want:%v,true", w, ok, math.Sqrt2)
+	}
+	if w, ok := g.Weight(0, 1); w != 1 || !ok {
+		t.Errorf("unexpected orthogonal weight: got:%v,%t want:1,true", w, ok)
+	}
+	if w, ok := g.Weight(0, 8); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected weight for absent edge: got:%v,%t want:+Inf,false", w, ok)
+	}
+	if c := g.CellOf(5); c != (Cell{1, 2}) {
+		t.Errorf("unexpected cell: got:%v want:{1 2}", c)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// JumpPointSearch finds the shortest path from s to t in g using jump point
+// search. Jump point search is an optimization of A* for grids of uniform
+// traversal cost that prunes the expansion of nodes that lie on paths that
+// are symmetric to other paths of equal length, expanding only the jump
+// points where the direction of an optimal path may need to change. It
+// typically expands far fewer nodes than A* on the same grid.
+//
+// The movement rules of g are respected: if g.AllowDiagonal is false only
+// horizontal and vertical moves are made and otherwise diagonal moves are
+// made only where they do not cut a corner of a closed cell. The returned
+// path holds every cell along the path from s to t and is a path in g. The
+// returned length is the sum of the edge weights along the path and is equal
+// to the length of the shortest path found by A*. If t is not reachable from
+// s, JumpPointSearch returns a nil path and +Inf.
+func JumpPointSearch(g *Grid, s, t graph.Node) (path []graph.Node, length float64) {
+	path, length, _ = jumpPointSearch(g, s, t)
+	return path, length
+}
+
+// jumpPointSearch returns the shortest path from s to t in g and its
+// length, and the number of jump points that were expanded.
+func jumpPointSearch(g *Grid, s, t graph.Node) (path []graph.Node, length float64, expanded int) {
+	if !g.has(s.ID()) || !g.has(t.ID()) {
+		return nil, math.Inf(1), 0
+	}
+	j := jumper{g: g, t: g.CellOf(t.ID())}
+	sid, tid := s.ID(), t.ID()
+	h := func(id int64) float64 { return octile(g.CellOf(id), j.t, g.AllowDiagonal) }
+
+	gscore := map[int64]float64{sid: 0}
+	parent := map[int64]int64{sid: sid}
+	closed := make(set.Int64s)
+	open := newSearchQueue()
+	open.set(sid, h(sid))
+	for open.Len() != 0 {
+		uid := heap.Pop(open).(searchNode).id
+		expanded++
+		if uid == tid {
+			break
+		}
+		closed.Add(uid)
+
+		u := g.CellOf(uid)
+		var p *Cell
+		if pid := parent[uid]; pid != uid {
+			pc := g.CellOf(pid)
+			p = &pc
+		}
+		for _, n := range j.neighbors(u, p) {
+			jp, ok := j.jump(n, u)
+			if !ok {
+				continue
+			}
+			vid := int64(jp.Row*g.c + jp.Col)
+			if closed.Has(vid) {
+				continue
+			}
+			cost := gscore[uid] + octile(u, jp, g.AllowDiagonal)
+			if gv, ok := gscore[vid]; ok && gv <= cost {
+				continue
+			}
+			gscore[vid] = cost
+			parent[vid] = uid
+			open.set(vid, cost+h(vid))
+		}
+	}
+
+	length, ok := gscore[tid]
+	if !ok {
+		return nil, math.Inf(1), expanded
+	}
+
+	// Expand the jump points into the cells of the path.
+	path = []graph.Node{t}
+	for id := tid; id != sid; {
+		pid := parent[id]
+		a, b := g.CellOf(pid), g.CellOf(id)
+		dr, dc := sign(a.Row-b.Row), sign(a.Col-b.Col)
+		for c := (Cell{Row: b.Row + dr, Col: b.Col + dc}); c != a; c.Row, c.Col = c.Row+dr, c.Col+dc {
+			path = append(path, simple.Node(c.Row*g.c+c.Col))
+		}
+		path = append(path, simple.Node(pid))
+		id = pid
+	}
+	path[len(path)-1] = s
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, length, expanded
+}
+
+// jumper implements the neighbor pruning and jumping rules of
+// jump point search.
+type jumper struct {
+	g *Grid
+	t Cell
+}
+
+// open returns whether the cell at (r, c) is open.
+func (j jumper) open(r, c int) bool {
+	return j.g.IsOpen(Cell{Row: r, Col: c})
+}
+
+// neighbors returns the pruned neighbors of u when reached from the
+// jump point p. If p is nil, all neighbors of u are returned.
+func (j jumper) neighbors(u Cell, p *Cell) []Cell {
+	if p == nil {
+		var n []Cell
+		to := j.g.From(int64(u.Row*j.g.c + u.Col))
+		for to.Next() {
+			n = append(n, j.g.CellOf(to.Node().ID()))
+		}
+		return n
+	}
+
+	r, c := u.Row, u.Col
+	dr, dc := sign(r-p.Row), sign(c-p.Col)
+	var n []Cell
+	add := func(r, c int) {
+		if j.open(r, c) {
+			n = append(n, Cell{Row: r, Col: c})
+		}
+	}
+	switch {
+	case !j.g.AllowDiagonal:
+		if dc != 0 {
+			add(r-1, c)
+			add(r+1, c)
+			add(r, c+dc)
+		} else {
+			add(r, c-1)
+			add(r, c+1)
+			add(r+dr, c)
+		}
+	case dr != 0 && dc != 0:
+		add(r+dr, c)
+		add(r, c+dc)
+		if j.open(r+dr, c) && j.open(r, c+dc) {
+			add(r+dr, c+dc)
+		}
+	case dc != 0:
+		next := j.open(r, c+dc)
+		above := j.open(r-1, c)
+		below := j.open(r+1, c)
+		if next {
+			add(r, c+dc)
+			if above {
+				add(r-1, c+dc)
+			}
+			if below {
+				add(r+1, c+dc)
+			}
+		}
+		if above {
+			add(r-1, c)
+		}
+		if below {
+			add(r+1, c)
+		}
+	default:
+		next := j.open(r+dr, c)
+		left := j.open(r, c-1)
+		right := j.open(r, c+1)
+		if next {
+			add(r+dr, c)
+			if left {
+				add(r+dr, c-1)
+			}
+			if right {
+				add(r+dr, c+1)
+			}
+		}
+		if left {
+			add(r, c-1)
+		}
+		if right {
+			add(r, c+1)
+		}
+	}
+	return n
+}
+
+// jump searches from the cell u in the direction of the step from p to u,
+// returning the next jump point and true if one is found.
+func (j jumper) jump(u, p Cell) (Cell, bool) {
+	dr, dc := u.Row-p.Row, u.Col-p.Col
+	for {
+		r, c := u.Row, u.Col
+		if !j.open(r, c) {
+			return Cell{}, false
+		}
+		if u == j.t {
+			return u, true
+		}
+
+		switch {
+		case dr != 0 && dc != 0:
+			if _, ok := j.jump(Cell{Row: r, Col: c + dc}, u); ok {
+				return u, true
+			}
+			if _, ok := j.jump(Cell{Row: r + dr, Col: c}, u); ok {
+				return u, true
+			}
+			if !j.open(r+dr, c) || !j.open(r, c+dc) {
+				return Cell{}, false
+			}
+		case dc != 0:
+			if (j.open(r-1, c) && !j.open(r-1, c-dc)) || (j.open(r+1, c) && !j.open(r+1, c-dc)) {
+				return u, true
+			}
+		default:
+			if (j.open(r, c-1) && !j.open(r-dr, c-1)) || (j.open(r, c+1) && !j.open(r-dr, c+1)) {
+				return u, true
+			}
+			if !j.g.AllowDiagonal {
+				// Vertical moves on grids without diagonal
+				// moves must check for horizontal jump points.
+				if _, ok := j.jump(Cell{Row: r, Col: c - 1}, u); ok {
+					return u, true
+				}
+				if _, ok := j.jump(Cell{Row: r, Col: c + 1}, u); ok {
+					return u, true
+				}
+			}
+		}
+
+		p = u
+		u = Cell{Row: r + dr, Col: c + dc}
+	}
+}
+
+// octile returns the length of the shortest path between a and b on an
+// open grid, allowing diagonal moves if diagonal is true.
+func octile(a, b Cell, diagonal bool) float64 {
+	dr := float64(abs(a.Row - b.Row))
+	dc := float64(abs(a.Col - b.Col))
+	if !diagonal {
+		return dr + dc
+	}
+	return math.Max(dr, dc) + (math.Sqrt2-1)*math.Min(dr, dc)
+}
+
+func sign(a int) int {
+	switch {
+	case a < 0:
+		return -1
+	case a > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// scattered returns an r by c grid with approximately the given
+// fraction of cells closed at random.
+func scattered(r, c int, fraction float64, src rand.Source) *Grid {
+	rnd := rand.New(src)
+	g := NewGrid(r, c, true)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if rnd.Float64() < fraction {
+				g.Set(Cell{Row: i, Col: j}, false)
+			}
+		}
+	}
+	return g
+}
+
+func TestJumpPointSearch(t *testing.T) {
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	for _, diagonal := range []bool{false, true} {
+		for i := 0; i < 200; i++ {
+			r, c := 1+rnd.Intn(20), 1+rnd.Intn(20)
+			g := scattered(r, c, 0.3*rnd.Float64(), src)
+			g.AllowDiagonal = diagonal
+			s := Cell{Row: rnd.Intn(r), Col: rnd.Intn(c)}
+			tc := Cell{Row: rnd.Intn(r), Col: rnd.Intn(c)}
+			g.Set(s, true)
+			g.Set(tc, true)
+			sn, tn := g.NodeAt(s), g.NodeAt(tc)
+
+			got, length := JumpPointSearch(g, sn, tn)
+			pt, _ := path.AStar(sn, tn, g, nil)
+			_, want := pt.To(tn.ID())
+
+			if math.IsInf(want, 1) {
+				if got != nil || !math.IsInf(length, 1) {
+					t.Errorf("unexpected path for unreachable target: got:%v %v\n%v", got, length, g)
+				}
+				continue
+			}
+			if !floats.EqualWithinAbsOrRel(length, want, 1e-9, 1e-9) {
+				t.Errorf("unexpected path length from %v to %v with diagonal=%t: got:%v want:%v",
+					s, tc, diagonal, length, want)
+				continue
+			}
+			if len(got) == 0 || got[0].ID() != sn.ID() || got[len(got)-1].ID() != tn.ID() {
+				t.Errorf("unexpected path end points: got:%v", got)
+				continue
+			}
+			if !topo.IsPathIn(g, got) {
+				t.Errorf("returned path is not a path in the grid: %v", got)
+			}
+			var sum float64
+			for k := 1; k < len(got); k++ {
+				w, _ := g.Weight(got[k-1].ID(), got[k].ID())
+				sum += w
+			}
+			if !floats.EqualWithinAbsOrRel(sum, length, 1e-9, 1e-9) {
+				t.Errorf("path weight does not match length: got:%v want:%v", sum, length)
+			}
+		}
+	}
+}
+
+func TestJumpPointSearchExpansions(t *testing.T) {
+	g := scattered(200, 200, 0.1, rand.NewSource(1))
+	g.AllowDiagonal = true
+	s, tc := Cell{0, 0}, Cell{199, 199}
+	g.Set(s, true)
+	g.Set(tc, true)
+	sn, tn := g.NodeAt(s), g.NodeAt(tc)
+
+	_, _, jps := jumpPointSearch(g, sn, tn)
+	_, astar := path.AStarWithStats(sn, tn, g, heuristic(g))
+	if jps >= astar.Expanded {
+		t.Errorf("jump point search did not reduce expansions: got:%d A*:%d", jps, astar.Expanded)
+	}
+}
+
+// heuristic returns the octile distance heuristic for A* on g.
+func heuristic(g *Grid) path.Heuristic {
+	return func(u, v graph.Node) float64 {
+		return octile(g.CellOf(u.ID()), g.CellOf(v.ID()), g.AllowDiagonal)
+	}
+}
+
+func benchmarkGrid() (*Grid, graph.Node, graph.Node) {
+	g := scattered(500, 500, 0.1, rand.NewSource(1))
+	g.AllowDiagonal = true
+	s, t := Cell{0, 0}, Cell{499, 499}
+	g.Set(s, true)
+	g.Set(t, true)
+	return g, g.NodeAt(s), g.NodeAt(t)
+}
+
+func BenchmarkJumpPointSearch(b *testing.B) {
+	g, s, t := benchmarkGrid()
+	var expanded int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, expanded = jumpPointSearch(g, s, t)
+	}
+	b.ReportMetric(float64(expanded), "expanded/op")
+}
+
+func BenchmarkAStarGrid(b *testing.B) {
+	g, s, t := benchmarkGrid()
+	h := heuristic(g)
+	var stats path.AStarStats
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, stats = path.AStarWithStats(s, t, g, h)
+	}
+	b.ReportMetric(float64(stats.Expanded), "expanded/op")
+}
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import "container/heap"
+
+// searchNode is a node in a best-first search frontier.
+type searchNode struct {
+	id     int64
+	fscore float64
+}
+
+// searchQueue is a best-first search priority queue
+// ordered by increasing fscore.
+type searchQueue struct {
+	indexOf map[int64]int
+	nodes   []searchNode
+}
+
+func newSearchQueue() *searchQueue {
+	return &searchQueue{indexOf: make(map[int64]int)}
+}
+
+func (q *searchQueue) Less(i, j int) bool {
+	return q.nodes[i].fscore < q.nodes[j].fscore
+}
+
+func (q *searchQueue) Swap(i, j int) {
+	q.indexOf[q.nodes[i].id] = j
+	q.indexOf[q.nodes[j].id] = i
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *searchQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *searchQueue) Push(x interface{}) {
+	n := x.(searchNode)
+	q.indexOf[n.id] = len(q.nodes)
+	q.nodes = append(q.nodes, n)
+}
+
+func (q *searchQueue) Pop() interface{} {
+	n := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	delete(q.indexOf, n.id)
+	return n
+}
+
+// set adds the node with the given ID to the queue with the
+// given fscore, or updates its fscore if it is already held.
+func (q *searchQueue) set(id int64, f float64) {
+	i, ok := q.indexOf[id]
+	if !ok {
+		heap.Push(q, searchNode{id: id, fscore: f})
+		return
+	}
+	q.nodes[i].fscore = f
+	heap.Fix(q, i)
+}
@@ -0,0 +1,98 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// ThetaStar finds an any-angle path from s to t in g using the Theta*
+// algorithm. Theta* is a variant of A* that, when relaxing the edge from
+// a node u to its neighbor v, connects v directly to the parent of u if
+// lineOfSight reports that the parent of u can see v. The returned paths
+// are therefore not constrained to follow the edges of g and are usually
+// shorter and more natural than grid-constrained A* paths. The paths are
+// not guaranteed to be the shortest any-angle paths.
+//
+// The returned path holds the vertices of the path, beginning with s and
+// ending with t. Consecutive vertices are connected by straight lines that
+// are not necessarily edges of g. The returned length is the sum of the
+// Euclidean distances between consecutive vertices. If t is not reachable
+// from s, ThetaStar returns a nil path and +Inf.
+//
+// If lineOfSight is nil, g.LineOfSight is used. The Euclidean distance to t
+// is used as the search heuristic.
+func ThetaStar(g *Grid, s, t graph.Node, lineOfSight func(a, b Cell) bool) (path []graph.Node, length float64) {
+	if !g.has(s.ID()) || !g.has(t.ID()) {
+		return nil, math.Inf(1)
+	}
+	if lineOfSight == nil {
+		lineOfSight = g.LineOfSight
+	}
+
+	sid, tid := s.ID(), t.ID()
+	tc := g.CellOf(tid)
+	h := func(id int64) float64 { return distance(g.CellOf(id), tc) }
+
+	gscore := map[int64]float64{sid: 0}
+	parent := map[int64]int64{sid: sid}
+	closed := make(set.Int64s)
+	open := newSearchQueue()
+	open.set(sid, h(sid))
+	for open.Len() != 0 {
+		uid := heap.Pop(open).(searchNode).id
+		if uid == tid {
+			break
+		}
+		closed.Add(uid)
+
+		u := g.CellOf(uid)
+		pid := parent[uid]
+		p := g.CellOf(pid)
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if closed.Has(vid) {
+				continue
+			}
+			v := g.CellOf(vid)
+
+			// Path 2: connect v to the parent of u if
+			// the parent has line of sight to v.
+			from, cost := uid, gscore[uid]+distance(u, v)
+			if pid != uid && lineOfSight(p, v) {
+				from, cost = pid, gscore[pid]+distance(p, v)
+			}
+			if gv, ok := gscore[vid]; ok && gv <= cost {
+				continue
+			}
+			gscore[vid] = cost
+			parent[vid] = from
+			open.set(vid, cost+h(vid))
+		}
+	}
+
+	length, ok := gscore[tid]
+	if !ok {
+		return nil, math.Inf(1)
+	}
+	for id := tid; ; id = parent[id] {
+		path = append(path, simple.Node(id))
+		if id == sid {
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	path[0] = s
+	path[len(path)-1] = t
+	return path, length
+}
@@ -0,0 +1,126 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridpath
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+var thetaStarTests = []struct {
+	name string
+	g    *Grid
+	s, t Cell
+
+	shorter bool
+	noPath  bool
+}{
+	{
+		name: "open",
+		g:    NewGrid(10, 20, true),
+		s:    Cell{0, 0}, t: Cell{3, 19},
+
+		shorter: true,
+	},
+	{
+		name: "same",
+		g:    NewGrid(3, 3, true),
+		s:    Cell{1, 1}, t: Cell{1, 1},
+	},
+	{
+		name: "wall",
+		g: NewGridFrom(
+			"..........",
+			"..........",
+			"*********.",
+			"..........",
+			"..........",
+		),
+		s: Cell{0, 0}, t: Cell{4, 0},
+
+		shorter: true,
+	},
+	{
+		name: "blocked",
+		g: NewGridFrom(
+			".....",
+			"*****",
+			".....",
+		),
+		s: Cell{0, 0}, t: Cell{2, 4},
+
+		noPath: true,
+	},
+}
+
+func TestThetaStar(t *testing.T) {
+	for _, test := range thetaStarTests {
+		test.g.AllowDiagonal = true
+		s := test.g.NodeAt(test.s)
+		tn := test.g.NodeAt(test.t)
+
+		p, length := ThetaStar(test.g, s, tn, nil)
+		pt, _ := path.AStar(s, tn, test.g, nil)
+		_, want := pt.To(tn.ID())
+
+		if test.noPath {
+			if p != nil || !math.IsInf(length, 1) {
+				t.Errorf("unexpected path for %q: got:%v %v", test.name, p, length)
+			}
+			continue
+		}
+		if len(p) == 0 || p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+			t.Errorf("unexpected path end points for %q: got:%v", test.name, p)
+			continue
+		}
+		var sum float64
+		for i := 1; i < len(p); i++ {
+			a := test.g.CellOf(p[i-1].ID())
+			b := test.g.CellOf(p[i].ID())
+			if !test.g.LineOfSight(a, b) {
+				t.Errorf("no line of sight between path vertices %v and %v for %q", a, b, test.name)
+			}
+			sum += distance(a, b)
+		}
+		if !floats.EqualWithinAbsOrRel(sum, length, 1e-12, 1e-12) {
+			t.Errorf("unexpected path length for %q: got:%v want:%v", test.name, length, sum)
+		}
+		if length > want+1e-12 {
+			t.Errorf("any-angle path longer than A* path for %q: got:%v A*:%v", test.name, length, want)
+		}
+		if test.shorter && length >= want {
+			t.Errorf("any-angle path not shorter than A* path for %q: got:%v A*:%v", test.name, length, want)
+		}
+	}
+}
+
+func TestThetaStarOpenGridStraight(t *testing.T) {
+	g := NewGrid(10, 20, true)
+	g.AllowDiagonal = true
+	p, length := ThetaStar(g, g.NodeAt(Cell{0, 0}), g.NodeAt(Cell{3, 19}), nil)
+	if len(p) != 2 {
+		t.Errorf("unexpected number of path vertices on open grid: got:%d want:2", len(p))
+	}
+	if want := math.Hypot(3, 19); !floats.EqualWithinAbsOrRel(length, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected path length: got:%v want:%v", length, want)
+	}
+}
+
+func TestThetaStarCustomLineOfSight(t *testing.T) {
+	g := NewGrid(5, 5, true)
+	g.AllowDiagonal = true
+	never := func(a, b Cell) bool { return false }
+	s, tn := g.NodeAt(Cell{0, 0}), g.NodeAt(Cell{4, 2})
+	_, length := ThetaStar(g, s, tn, never)
+
+	// Without line of sight Theta* reduces to A*.
+	pt, _ := path.AStar(s, tn, g, nil)
+	if _, want := pt.To(tn.ID()); !floats.EqualWithinAbsOrRel(length, want, 1e-12, 1e-12) {
+		t.Errorf("unexpected path length without line of sight: got:%v want:%v", length, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// LongestIncreasingPath returns a longest path in g along which the node
+// values given by value strictly increase, and the number of nodes in the
+// path. Since node values strictly increase along the path, the path can not
+// revisit a node even when g has cycles. When more than one longest path
+// exists, the path whose sequence of node IDs is lexically least among those
+// starting at the least node ID is returned. If g has no nodes,
+// LongestIncreasingPath returns nil and zero.
+//
+// The length of the longest increasing path starting from each node is the
+// memoized solution of a depth-first recursion over the successors of the
+// node with greater values. LongestIncreasingPath evaluates the recursion
+// directly by visiting nodes in order of decreasing value, taking
+// O(|V|log|V|+|E|) time.
+func LongestIncreasingPath(g graph.Directed, value func(graph.Node) float64) ([]graph.Node, int) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil, 0
+	}
+	values := make([]float64, len(nodes))
+	for i, n := range nodes {
+		values[i] = value(n)
+	}
+	sort.Sort(byValueDesc{nodes: nodes, values: values})
+	valueOf := make(map[int64]float64, len(nodes))
+	for i, n := range nodes {
+		valueOf[n.ID()] = values[i]
+	}
+
+	// length[id] is the number of nodes in the longest increasing
+	// path starting from the node with the given ID, and next[id]
+	// is the node following it on that path.
+	length := make(map[int64]int, len(nodes))
+	next := make(map[int64]graph.Node)
+	var start graph.Node
+	for _, u := range nodes {
+		uid := u.ID()
+		length[uid] = 1
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if !(valueOf[vid] > valueOf[uid]) {
+				continue
+			}
+			l := length[vid] + 1
+			if l > length[uid] || (l == length[uid] && vid < next[uid].ID()) {
+				length[uid] = l
+				next[uid] = v
+			}
+		}
+		if start == nil || length[uid] > length[start.ID()] || (length[uid] == length[start.ID()] && uid < start.ID()) {
+			start = u
+		}
+	}
+
+	p := make([]graph.Node, 0, length[start.ID()])
+	for u := start; u != nil; u = next[u.ID()] {
+		p = append(p, u)
+	}
+	return p, len(p)
+}
+
+// byValueDesc sorts nodes by decreasing value and then by ascending ID.
+type byValueDesc struct {
+	nodes  []graph.Node
+	values []float64
+}
+
+func (s byValueDesc) Len() int { return len(s.nodes) }
+func (s byValueDesc) Less(i, j int) bool {
+	if s.values[i] != s.values[j] {
+		return s.values[i] > s.values[j]
+	}
+	return s.nodes[i].ID() < s.nodes[j].ID()
+}
+func (s byValueDesc) Swap(i, j int) {
+	s.nodes[i], s.nodes[j] = s.nodes[j], s.nodes[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
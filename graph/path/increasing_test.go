@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestLongestIncreasingPath(t *testing.T) {
+	// The 3×3 grid
+	//
+	//  9 9 4
+	//  6 6 8
+	//  2 1 1
+	//
+	// has the longest increasing path 1, 2, 6, 9.
+	vals := []float64{9, 9, 4, 6, 6, 8, 2, 1, 1}
+	g := simple.NewDirectedGraph()
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			u := simple.Node(3*r + c)
+			if c < 2 {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node(3*r + c + 1)})
+				g.SetEdge(simple.Edge{F: simple.Node(3*r + c + 1), T: u})
+			}
+			if r < 2 {
+				g.SetEdge(simple.Edge{F: u, T: simple.Node(3*(r+1) + c)})
+				g.SetEdge(simple.Edge{F: simple.Node(3*(r+1) + c), T: u})
+			}
+		}
+	}
+	value := func(n graph.Node) float64 { return vals[n.ID()] }
+	p, n := LongestIncreasingPath(g, value)
+	if want := []int64{7, 6, 3, 0}; n != 4 || !equalIDs(nodeIDs(p), want) {
+		t.Errorf("unexpected result: got:%v,%d want:%v,4", nodeIDs(p), n, want)
+	}
+
+	if p, n := LongestIncreasingPath(simple.NewDirectedGraph(), value); p != nil || n != 0 {
+		t.Errorf("unexpected result for empty graph: got:%v,%d", nodeIDs(p), n)
+	}
+}
+
+func TestLongestIncreasingPathRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(8)
+		g := simple.NewDirectedGraph()
+		vals := make(map[int64]float64)
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+			vals[int64(j)] = float64(rnd.Intn(5))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		value := func(n graph.Node) float64 { return vals[n.ID()] }
+
+		var want int
+		var walk func(u graph.Node, l int)
+		walk = func(u graph.Node, l int) {
+			if l > want {
+				want = l
+			}
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				if vals[v.ID()] > vals[u.ID()] {
+					walk(v, l+1)
+				}
+			}
+		}
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			walk(u, 1)
+		}
+
+		p, got := LongestIncreasingPath(g, value)
+		if got != want || len(p) != got {
+			t.Errorf("unexpected length for test %d: got:%d (path %v) want:%d", i, got, nodeIDs(p), want)
+			continue
+		}
+		for k := 1; k < len(p); k++ {
+			if !g.HasEdgeFromTo(p[k-1].ID(), p[k].ID()) || !(vals[p[k].ID()] > vals[p[k-1].ID()]) {
+				t.Errorf("returned path is not increasing in test %d: %v", i, nodeIDs(p))
+				break
+			}
+		}
+	}
+}
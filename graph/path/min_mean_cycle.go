@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// MinMeanCycle returns a cycle in g with the minimum mean edge weight and its
+// mean weight, using Karp's algorithm. The cycle is returned as a sequence of
+// nodes beginning and ending with the same node, as returned by
+// topo.DirectedCyclesIn. If g has no cycle, ok is false.
+//
+// The running time of MinMeanCycle is O(|V||E|) and it uses O(|V|^2) space.
+//
+// See Karp "A characterization of the minimum cycle mean in a digraph"
+// doi:10.1016/0012-365X(78)90011-0 for details of the algorithm.
+func MinMeanCycle(g graph.WeightedDirected) (mean float64, cycle []graph.Node, ok bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	if n == 0 {
+		return math.NaN(), nil, false
+	}
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	type arc struct {
+		u, v int
+		w    float64
+	}
+	var arcs []arc
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			w, _ := g.Weight(u.ID(), v.ID())
+			arcs = append(arcs, arc{u: i, v: indexOf[v.ID()], w: w})
+		}
+	}
+
+	// dist[k][v] is the minimum weight of a walk of exactly k
+	// edges ending at v starting from any node, and parent[k][v]
+	// is the node preceding v on such a walk.
+	dist := make([][]float64, n+1)
+	parent := make([][]int, n+1)
+	for k := range dist {
+		dist[k] = make([]float64, n)
+		parent[k] = make([]int, n)
+		for v := range dist[k] {
+			if k != 0 {
+				dist[k][v] = math.Inf(1)
+			}
+			parent[k][v] = -1
+		}
+	}
+	for k := 1; k <= n; k++ {
+		for _, a := range arcs {
+			if math.IsInf(dist[k-1][a.u], 1) {
+				continue
+			}
+			if d := dist[k-1][a.u] + a.w; d < dist[k][a.v] {
+				dist[k][a.v] = d
+				parent[k][a.v] = a.u
+			}
+		}
+	}
+
+	mean = math.Inf(1)
+	best := -1
+	for v := 0; v < n; v++ {
+		if math.IsInf(dist[n][v], 1) {
+			continue
+		}
+		worst := math.Inf(-1)
+		for k := 0; k < n; k++ {
+			if math.IsInf(dist[k][v], 1) {
+				continue
+			}
+			worst = math.Max(worst, (dist[n][v]-dist[k][v])/float64(n-k))
+		}
+		if worst < mean {
+			mean = worst
+			best = v
+		}
+	}
+	if best < 0 {
+		return math.NaN(), nil, false
+	}
+
+	// The minimum weight walk of n edges ending at best contains
+	// a cycle, and every cycle it contains has the minimum mean.
+	walk := make([]int, n+1)
+	walk[n] = best
+	for k := n; k > 0; k-- {
+		walk[k-1] = parent[k][walk[k]]
+	}
+	last := make(map[int]int, n)
+	for i, v := range walk {
+		j, seen := last[v]
+		if !seen {
+			last[v] = i
+			continue
+		}
+		cycle = make([]graph.Node, 0, i-j+1)
+		var w float64
+		for k := j; k <= i; k++ {
+			cycle = append(cycle, nodes[walk[k]])
+			if k > j {
+				e, _ := g.Weight(nodes[walk[k-1]].ID(), nodes[walk[k]].ID())
+				w += e
+			}
+		}
+		return w / float64(i-j), cycle, true
+	}
+	panic("path: no cycle in walk")
+}
@@ -0,0 +1,103 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestMinMeanCycle(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(0), W: 4},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(3), T: simple.Node(1), W: 0},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	mean, cycle, ok := MinMeanCycle(g)
+	if !ok || mean != 1 {
+		t.Errorf("unexpected result: got:%v,%t want:1,true", mean, ok)
+	}
+	var ids []int64
+	for _, n := range cycle {
+		ids = append(ids, n.ID())
+	}
+	if len(ids) != 4 || ids[0] != ids[3] {
+		t.Errorf("unexpected cycle: got:%v want rotation of [1 2 3 1]", ids)
+	}
+
+	// A DAG has no cycle.
+	g.RemoveEdge(2, 0)
+	g.RemoveEdge(3, 1)
+	if _, _, ok := MinMeanCycle(g); ok {
+		t.Error("unexpected cycle in acyclic graph")
+	}
+	if _, _, ok := MinMeanCycle(simple.NewWeightedDirectedGraph(0, math.Inf(1))); ok {
+		t.Error("unexpected cycle in empty graph")
+	}
+}
+
+func TestMinMeanCycleRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(7)
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(2 * j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.3 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2 * u), T: simple.Node(2 * v), W: float64(rnd.Intn(21) - 10)})
+				}
+			}
+		}
+
+		want, wantOK := math.Inf(1), false
+		for _, c := range topo.DirectedCyclesIn(g) {
+			want = math.Min(want, cycleMean(g, c))
+			wantOK = true
+		}
+
+		got, cycle, ok := MinMeanCycle(g)
+		if ok != wantOK {
+			t.Errorf("unexpected ok for test %d: got:%t want:%t", i, ok, wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("unexpected mean for test %d: got:%v want:%v", i, got, want)
+		}
+		if len(cycle) < 2 || cycle[0].ID() != cycle[len(cycle)-1].ID() || !topo.IsPathIn(g, cycle) {
+			t.Errorf("returned cycle is not a cycle in g for test %d: %v", i, cycle)
+			continue
+		}
+		if m := cycleMean(g, cycle); math.Abs(m-got) > 1e-9 {
+			t.Errorf("mean of returned cycle does not match for test %d: got:%v want:%v", i, m, got)
+		}
+	}
+}
+
+// cycleMean returns the mean edge weight of the closed cycle c in g.
+func cycleMean(g graph.Weighted, c []graph.Node) float64 {
+	var w float64
+	for k := 1; k < len(c); k++ {
+		e, _ := g.Weight(c[k-1].ID(), c[k].ID())
+		w += e
+	}
+	return w / float64(len(c)-1)
+}
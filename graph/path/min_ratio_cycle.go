@@ -0,0 +1,184 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// MinRatioCycle returns a cycle in g that minimizes the ratio of the sum of
+// its edge costs to the sum of its edge times, and that ratio. This is the
+// minimum cost-to-time ratio cycle, or tramp steamer, problem. The cycle is
+// returned as a sequence of nodes beginning and ending with the same node, as
+// returned by topo.DirectedCyclesIn. If g has no cycle, ok is false.
+//
+// The ratio is found by Lawler's parametric search, a binary search on the
+// ratio λ that tests for a negative cycle in g with edge weights cost-λ·time.
+// Each negative cycle that is found tightens the upper bound of the search to
+// its own ratio, and the search stops when the bounds agree to within a
+// relative tolerance of 1e-12. A cycle is only considered negative when its
+// weight is below a tolerance of 1e-12 relative to the largest edge weight,
+// so cycles with a ratio within rounding error of λ are not taken as
+// improvements.
+//
+// Every cycle in g must have a positive total time. MinRatioCycle will panic
+// if it finds a cycle with a total time that is not positive.
+func MinRatioCycle(g graph.Directed, cost, time func(e graph.Edge) float64) (ratio float64, cycle []graph.Node, ok bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	var arcs []ratioArc
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			e := g.Edge(u.ID(), v.ID())
+			arcs = append(arcs, ratioArc{u: i, v: indexOf[v.ID()], cost: cost(e), time: time(e)})
+		}
+	}
+
+	// Any cycle has negative weight when all edges
+	// have negative weight.
+	best := negativeCycle(len(nodes), arcs, func(ratioArc) float64 { return -1 })
+	if best == nil {
+		return math.NaN(), nil, false
+	}
+	hi := cycleRatio(best, arcs)
+
+	// search returns whether a cycle with a ratio less than
+	// lambda exists, recording it as the best found if it
+	// improves on the upper bound.
+	search := func(lambda float64) bool {
+		c := negativeCycle(len(nodes), arcs, func(a ratioArc) float64 { return a.cost - lambda*a.time })
+		if c == nil {
+			return false
+		}
+		r := cycleRatio(c, arcs)
+		if r >= lambda {
+			// The cycle was found due to rounding error.
+			return false
+		}
+		best, hi = c, r
+		return true
+	}
+
+	// Find a lower bound for the ratio.
+	step := math.Max(1, math.Abs(hi))
+	lo := hi - step
+	for search(lo) {
+		step *= 2
+		lo = hi - step
+	}
+
+	for hi-lo > 1e-12*math.Max(1, math.Abs(hi)) {
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if !search(mid) {
+			lo = mid
+		}
+	}
+
+	cycle = make([]graph.Node, 0, len(best)+1)
+	cycle = append(cycle, nodes[arcs[best[0]].u])
+	for _, a := range best {
+		cycle = append(cycle, nodes[arcs[a].v])
+	}
+	return hi, cycle, true
+}
+
+// ratioArc is an edge of a graph with a cost and a time.
+type ratioArc struct {
+	u, v       int
+	cost, time float64
+}
+
+// cycleRatio returns the ratio of total cost to total time of the cycle
+// of arcs indexed by c. It panics if the total time is not positive.
+func cycleRatio(c []int, arcs []ratioArc) float64 {
+	var cost, time float64
+	for _, i := range c {
+		cost += arcs[i].cost
+		time += arcs[i].time
+	}
+	if time <= 0 {
+		panic("path: cycle with non-positive total time")
+	}
+	return cost / time
+}
+
+// negativeCycle returns the indexes of the arcs of a cycle with negative
+// total weight in the graph with n nodes and the given arcs, or nil if
+// there is no such cycle. The search is the Bellman-Ford algorithm from
+// a virtual source joined to every node with zero weight edges. An arc
+// is only relaxed when it shortens a distance by more than 1e-12 times
+// the largest absolute arc weight, so that cycles with weights that are
+// zero up to rounding error are not reported.
+func negativeCycle(n int, arcs []ratioArc, weight func(ratioArc) float64) []int {
+	if n == 0 {
+		return nil
+	}
+	w := make([]float64, len(arcs))
+	var max float64
+	for j, a := range arcs {
+		w[j] = weight(a)
+		max = math.Max(max, math.Abs(w[j]))
+	}
+	tol := 1e-12 * math.Max(1, max)
+
+	dist := make([]float64, n)
+	via := make([]int, n)
+	for i := range via {
+		via[i] = -1
+	}
+	last := -1
+	for i := 0; i < n; i++ {
+		last = -1
+		for j, a := range arcs {
+			if d := dist[a.u] + w[j]; d < dist[a.v]-tol {
+				dist[a.v] = d
+				via[a.v] = j
+				last = a.v
+			}
+		}
+		if last < 0 {
+			return nil
+		}
+	}
+
+	// A node relaxed in the nth round is expected to lie on or
+	// be reachable from a negative cycle in the predecessor graph.
+	// Walk back from it until a node is seen twice.
+	seen := make([]bool, n)
+	v := last
+	for !seen[v] {
+		seen[v] = true
+		if via[v] < 0 {
+			return nil
+		}
+		v = arcs[via[v]].u
+	}
+	var c []int
+	for u := v; ; {
+		j := via[u]
+		c = append(c, j)
+		u = arcs[j].u
+		if u == v {
+			break
+		}
+	}
+	for i, j := 0, len(c)-1; i < j; i, j = i+1, j-1 {
+		c[i], c[j] = c[j], c[i]
+	}
+	return c
+}
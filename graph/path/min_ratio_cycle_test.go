@@ -0,0 +1,164 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func edgeTime(e graph.Edge) float64 { return e.(paretoEdge).Cost[1] }
+
+func TestMinRatioCycle(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range []paretoEdge{
+		// Cycle 0-1-0 has ratio 4/2.
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1)}, Cost: [2]float64{2, 1}},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(0)}, Cost: [2]float64{2, 1}},
+		// Cycle 1-2-3-1 has ratio 6/5.
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2)}, Cost: [2]float64{3, 1}},
+		{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3)}, Cost: [2]float64{3, 0}},
+		{Edge: simple.Edge{F: simple.Node(3), T: simple.Node(1)}, Cost: [2]float64{0, 4}},
+	} {
+		g.SetEdge(e)
+	}
+	ratio, cycle, ok := MinRatioCycle(g, edgeCost, edgeTime)
+	if !ok || math.Abs(ratio-1.2) > 1e-12 {
+		t.Errorf("unexpected result: got:%v,%t want:1.2,true", ratio, ok)
+	}
+	if len(cycle) != 4 || cycle[0].ID() != cycle[3].ID() || !topo.IsPathIn(g, cycle) {
+		t.Errorf("unexpected cycle: got:%v want rotation of [1 2 3 1]", cycle)
+	}
+
+	g.RemoveEdge(1, 0)
+	g.RemoveEdge(3, 1)
+	if _, _, ok := MinRatioCycle(g, edgeCost, edgeTime); ok {
+		t.Error("unexpected cycle in acyclic graph")
+	}
+}
+
+func TestMinRatioCycleRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(7)
+		g := simple.NewDirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.3 {
+					g.SetEdge(paretoEdge{
+						Edge: simple.Edge{F: simple.Node(u), T: simple.Node(v)},
+						Cost: [2]float64{float64(rnd.Intn(41) - 20), float64(1 + rnd.Intn(5))},
+					})
+				}
+			}
+		}
+
+		want, wantOK := math.Inf(1), false
+		for _, c := range topo.DirectedCyclesIn(g) {
+			want = math.Min(want, cycleRatioOf(g, c))
+			wantOK = true
+		}
+
+		got, cycle, ok := MinRatioCycle(g, edgeCost, edgeTime)
+		if ok != wantOK {
+			t.Errorf("unexpected ok for test %d: got:%t want:%t", i, ok, wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("unexpected ratio for test %d: got:%v want:%v", i, got, want)
+		}
+		if len(cycle) < 2 || cycle[0].ID() != cycle[len(cycle)-1].ID() || !topo.IsPathIn(g, cycle) {
+			t.Errorf("returned cycle is not a cycle in g for test %d: %v", i, cycle)
+			continue
+		}
+		if r := cycleRatioOf(g, cycle); r != got {
+			t.Errorf("ratio of returned cycle does not match for test %d: got:%v want:%v", i, r, got)
+		}
+	}
+}
+
+func TestMinRatioCycleNearZeroCycle(t *testing.T) {
+	// Cycle 1-3-2-1 has ratio 7/3, and is found with weight
+	// zero up to rounding when the upper bound is 7/3, but
+	// cycle 0-1-3-2-0 has the smaller ratio 5/4.
+	g := simple.NewDirectedGraph()
+	for _, e := range []struct {
+		u, v int64
+		cost float64
+	}{
+		{0, 1, 5}, {1, 3, 4}, {2, 0, -2}, {2, 1, 5}, {3, 2, -2},
+	} {
+		g.SetEdge(paretoEdge{Edge: simple.Edge{F: simple.Node(e.u), T: simple.Node(e.v)}, Cost: [2]float64{e.cost, 1}})
+	}
+	ratio, cycle, ok := MinRatioCycle(g, edgeCost, edgeTime)
+	if !ok || math.Abs(ratio-1.25) > 1e-12 {
+		t.Errorf("unexpected result: got:%v,%t want:1.25,true", ratio, ok)
+	}
+	if len(cycle) != 5 || !topo.IsPathIn(g, cycle) {
+		t.Errorf("unexpected cycle: got:%v want rotation of [0 1 3 2 0]", cycle)
+	}
+}
+
+func TestMinRatioCycleUnitTimes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		n := 2 + rnd.Intn(5)
+		g := simple.NewDirectedGraph()
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					g.SetEdge(paretoEdge{
+						Edge: simple.Edge{F: simple.Node(u), T: simple.Node(v)},
+						Cost: [2]float64{float64(rnd.Intn(11) - 3), 1},
+					})
+				}
+			}
+		}
+
+		want := math.Inf(1)
+		for _, c := range topo.DirectedCyclesIn(g) {
+			want = math.Min(want, cycleRatioOf(g, c))
+		}
+
+		got, cycle, ok := MinRatioCycle(g, edgeCost, edgeTime)
+		if ok != !math.IsInf(want, 1) {
+			t.Errorf("unexpected ok for test %d: got:%t want:%t", i, ok, !ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("unexpected ratio for test %d: got:%v want:%v", i, got, want)
+		}
+		if !topo.IsPathIn(g, cycle) || cycleRatioOf(g, cycle) != got {
+			t.Errorf("unexpected cycle for test %d: %v", i, cycle)
+		}
+	}
+}
+
+// cycleRatioOf returns the ratio of total cost to total time
+// of the closed cycle c in g.
+func cycleRatioOf(g graph.Graph, c []graph.Node) float64 {
+	var cost, time float64
+	for k := 1; k < len(c); k++ {
+		e := g.Edge(c[k-1].ID(), c[k].ID())
+		cost += edgeCost(e)
+		time += edgeTime(e)
+	}
+	return cost / time
+}
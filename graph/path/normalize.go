@@ -0,0 +1,109 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/weights"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// NormalizeMode specifies the edge weight normalization performed
+// by NormalizeWeights.
+type NormalizeMode int
+
+const (
+	// NormalizeMinMax linearly scales weights to the interval [0, 1]
+	// by subtracting the minimum weight and dividing by the range of
+	// the weights. If all weights are equal, they are set to 1.
+	NormalizeMinMax NormalizeMode = iota
+
+	// NormalizeMax divides weights by the maximum absolute weight.
+	// If all weights are zero, they are left unaltered.
+	NormalizeMax
+
+	// NormalizeZScore standardizes weights by subtracting the mean
+	// weight and dividing by the population standard deviation of
+	// the weights. If all weights are equal, they are set to 0.
+	NormalizeZScore
+)
+
+// NormalizeWeights returns a copy of g with its edge weights normalized
+// according to mode. The weights of edges are obtained from the WeightedEdge
+// method of g. The returned graph is a *simple.WeightedUndirectedGraph holding
+// the nodes of g and edges between the same nodes as g, and has the same self
+// and absent weights as g. Self edges in g are not represented in the returned
+// graph. NormalizeWeights will panic if mode is not a valid NormalizeMode.
+func NormalizeWeights(g graph.WeightedUndirected, mode NormalizeMode) graph.WeightedUndirected {
+	nodes := graph.NodesOf(g.Nodes())
+	var edges []graph.WeightedEdge
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			if v.ID() <= uid {
+				continue
+			}
+			edges = append(edges, g.WeightedEdgeBetween(uid, v.ID()))
+		}
+	}
+
+	var scale func(float64) float64
+	switch mode {
+	case NormalizeMinMax:
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, e := range edges {
+			min = math.Min(min, e.Weight())
+			max = math.Max(max, e.Weight())
+		}
+		if min == max {
+			scale = func(float64) float64 { return 1 }
+		} else {
+			scale = func(w float64) float64 { return (w - min) / (max - min) }
+		}
+	case NormalizeMax:
+		var max float64
+		for _, e := range edges {
+			max = math.Max(max, math.Abs(e.Weight()))
+		}
+		if max == 0 {
+			scale = func(w float64) float64 { return w }
+		} else {
+			scale = func(w float64) float64 { return w / max }
+		}
+	case NormalizeZScore:
+		var mean float64
+		for _, e := range edges {
+			mean += e.Weight()
+		}
+		mean /= float64(len(edges))
+		var variance float64
+		for _, e := range edges {
+			d := e.Weight() - mean
+			variance += d * d
+		}
+		std := math.Sqrt(variance / float64(len(edges)))
+		if std == 0 {
+			scale = func(float64) float64 { return 0 }
+		} else {
+			scale = func(w float64) float64 { return (w - mean) / std }
+		}
+	default:
+		panic("path: invalid normalization mode")
+	}
+
+	self, absent := weights.Params(g, nodes)
+	dst := simple.NewWeightedUndirectedGraph(self, absent)
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+	for _, e := range edges {
+		dst.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: scale(e.Weight())})
+	}
+	return dst
+}
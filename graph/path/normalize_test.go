@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var normalizeWeightsTests = []struct {
+	name    string
+	weights []float64
+	mode    NormalizeMode
+	want    []float64
+}{
+	{name: "minmax", weights: []float64{2, 4, 6, 10}, mode: NormalizeMinMax, want: []float64{0, 0.25, 0.5, 1}},
+	{name: "minmax equal", weights: []float64{3, 3, 3}, mode: NormalizeMinMax, want: []float64{1, 1, 1}},
+	{name: "max", weights: []float64{2, -4, 6, 8}, mode: NormalizeMax, want: []float64{0.25, -0.5, 0.75, 1}},
+	{name: "max zero", weights: []float64{0, 0}, mode: NormalizeMax, want: []float64{0, 0}},
+	{name: "zscore", weights: []float64{2, 4, 4, 4, 5, 5, 7, 9}, mode: NormalizeZScore, want: []float64{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2}},
+	{name: "zscore equal", weights: []float64{5, 5}, mode: NormalizeZScore, want: []float64{0, 0}},
+}
+
+func TestNormalizeWeights(t *testing.T) {
+	for _, test := range normalizeWeightsTests {
+		// Construct a path graph with the test weights.
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		g.AddNode(simple.Node(-10))
+		for i, w := range test.weights {
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: w})
+		}
+
+		got := NormalizeWeights(g, test.mode)
+		if n, want := got.Nodes().Len(), g.Nodes().Len(); n != want {
+			t.Errorf("unexpected number of nodes for %q: got:%d want:%d", test.name, n, want)
+		}
+		for _, n := range graph.NodesOf(g.Nodes()) {
+			if got.Node(n.ID()) == nil {
+				t.Errorf("missing node %d for %q", n.ID(), test.name)
+			}
+		}
+		for i, want := range test.want {
+			w, ok := got.Weight(int64(i), int64(i+1))
+			if !ok || !floats.EqualWithinAbsOrRel(w, want, 1e-12, 1e-12) {
+				t.Errorf("unexpected weight for edge %d--%d for %q: got:%v,%t want:%v", i, i+1, test.name, w, ok, want)
+			}
+		}
+		if n, want := len(graph.EdgesOf(got.(*simple.WeightedUndirectedGraph).Edges())), len(test.weights); n != want {
+			t.Errorf("unexpected number of edges for %q: got:%d want:%d", test.name, n, want)
+		}
+		if w, ok := got.Weight(0, 0); w != 0 || !ok {
+			t.Errorf("unexpected self weight for %q: got:%v,%t", test.name, w, ok)
+		}
+		if w, ok := got.Weight(0, 2); !math.IsInf(w, 1) || ok {
+			t.Errorf("unexpected absent weight for %q: got:%v,%t", test.name, w, ok)
+		}
+	}
+}
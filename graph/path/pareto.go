@@ -0,0 +1,153 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ParetoPath is a path with a two-objective cost.
+type ParetoPath struct {
+	// Cost is the sum of the edge
+	// costs along the path.
+	Cost [2]float64
+
+	// Path holds the nodes of the path
+	// from the source to the target.
+	Path []graph.Node
+}
+
+// ParetoShortest returns the costs of the Pareto-optimal paths from s to t in g
+// under the two additive objectives given by weight. A path is Pareto-optimal if
+// no other path has a cost that is no greater in both objectives and less in at
+// least one. The returned costs are sorted by increasing first objective, and so
+// by decreasing second objective. If t is not reachable from s, ParetoShortest
+// returns nil. ParetoShortest will panic if weight returns a negative cost for
+// an edge reachable from s.
+//
+// The number of Pareto-optimal paths, and so the time taken by ParetoShortest,
+// may grow exponentially with the size of g. ParetoShortestPaths may be used to
+// bound the size of the frontier with epsilon-dominance and to obtain the paths.
+func ParetoShortest(g graph.Graph, s, t graph.Node, weight func(e graph.Edge) [2]float64) [][2]float64 {
+	paths := ParetoShortestPaths(g, s, t, weight, 0)
+	if paths == nil {
+		return nil
+	}
+	costs := make([][2]float64, len(paths))
+	for i, p := range paths {
+		costs[i] = p.Cost
+	}
+	return costs
+}
+
+// ParetoShortestPaths returns the Pareto-optimal paths from s to t in g under the
+// two additive objectives given by weight, along with their costs, with the same
+// semantics as ParetoShortest. Where more than one path has the same cost, only
+// one of them is returned.
+//
+// If eps is positive, a path whose cost c is epsilon-dominated by the cost c' of
+// a path that has already been found, that is c'[i] <= (1+eps)*c[i] for both
+// objectives, is discarded. Every Pareto-optimal cost is then epsilon-dominated
+// by a returned cost, while the number of returned paths is bounded by a function
+// of the ranges of the costs and eps rather than the size of g.
+//
+// ParetoShortestPaths uses a multi-objective label-setting algorithm. Each node
+// holds a set of mutually non-dominated labels, each corresponding to the cost of
+// a path from s. Labels are expanded in lexicographic order of their costs and are
+// discarded when they are dominated by a label already held at the same node or,
+// since costs are non-decreasing along a path, by a label held at t.
+func ParetoShortestPaths(g graph.Graph, s, t graph.Node, weight func(e graph.Edge) [2]float64, eps float64) []ParetoPath {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil
+	}
+	tid := t.ID()
+
+	labels := make(map[int64][]*paretoLabel)
+	q := paretoQueue{{node: s}}
+	for q.Len() != 0 {
+		l := heap.Pop(&q).(*paretoLabel)
+		uid := l.node.ID()
+		if dominated(labels[uid], l.cost, 0) {
+			continue
+		}
+		labels[uid] = append(labels[uid], l)
+		if uid == tid {
+			continue
+		}
+
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w := weight(g.Edge(uid, vid))
+			if w[0] < 0 || w[1] < 0 {
+				panic("pareto: negative edge weight")
+			}
+			cost := [2]float64{l.cost[0] + w[0], l.cost[1] + w[1]}
+			if dominated(labels[vid], cost, 0) || dominated(labels[tid], cost, eps) {
+				continue
+			}
+			heap.Push(&q, &paretoLabel{node: v, cost: cost, prev: l})
+		}
+	}
+
+	if len(labels[tid]) == 0 {
+		return nil
+	}
+	paths := make([]ParetoPath, len(labels[tid]))
+	for i, l := range labels[tid] {
+		var n int
+		for p := l; p != nil; p = p.prev {
+			n++
+		}
+		path := make([]graph.Node, n)
+		for p := l; p != nil; p = p.prev {
+			n--
+			path[n] = p.node
+		}
+		paths[i] = ParetoPath{Cost: l.cost, Path: path}
+	}
+	return paths
+}
+
+// dominated returns whether cost is epsilon-dominated by any of the labels.
+func dominated(labels []*paretoLabel, cost [2]float64, eps float64) bool {
+	for _, l := range labels {
+		if l.cost[0] <= (1+eps)*cost[0] && l.cost[1] <= (1+eps)*cost[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// paretoLabel is the cost of a path from the source to node
+// in a multi-objective shortest path search.
+type paretoLabel struct {
+	node graph.Node
+	cost [2]float64
+	prev *paretoLabel
+}
+
+// paretoQueue is a priority queue of labels ordered lexicographically
+// by cost.
+type paretoQueue []*paretoLabel
+
+func (q paretoQueue) Len() int { return len(q) }
+func (q paretoQueue) Less(i, j int) bool {
+	if q[i].cost[0] != q[j].cost[0] {
+		return q[i].cost[0] < q[j].cost[0]
+	}
+	return q[i].cost[1] < q[j].cost[1]
+}
+func (q paretoQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *paretoQueue) Push(n interface{}) { *q = append(*q, n.(*paretoLabel)) }
+func (q *paretoQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
@@ -0,0 +1,174 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// paretoEdge is an edge with two costs.
+type paretoEdge struct {
+	simple.Edge
+	Cost [2]float64
+}
+
+func paretoCost(e graph.Edge) [2]float64 { return e.(paretoEdge).Cost }
+
+func TestParetoShortest(t *testing.T) {
+	// Three routes from 0 to 3: fast and expensive, slow and
+	// cheap, and a dominated route.
+	g := simple.NewDirectedGraph()
+	for _, e := range []paretoEdge{
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1)}, Cost: [2]float64{1, 5}},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(3)}, Cost: [2]float64{1, 5}},
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2)}, Cost: [2]float64{4, 1}},
+		{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3)}, Cost: [2]float64{4, 1}},
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(4)}, Cost: [2]float64{3, 5}},
+		{Edge: simple.Edge{F: simple.Node(4), T: simple.Node(3)}, Cost: [2]float64{3, 5}},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(2)}, Cost: [2]float64{1, 1}},
+	} {
+		g.SetEdge(e)
+	}
+
+	got := ParetoShortest(g, simple.Node(0), simple.Node(3), paretoCost)
+	want := [][2]float64{{2, 10}, {6, 7}, {8, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected frontier: got:%v want:%v", got, want)
+	}
+
+	paths := ParetoShortestPaths(g, simple.Node(0), simple.Node(3), paretoCost, 0)
+	wantPaths := [][]int64{{0, 1, 3}, {0, 1, 2, 3}, {0, 2, 3}}
+	for i, p := range paths {
+		var ids []int64
+		for _, n := range p.Path {
+			ids = append(ids, n.ID())
+		}
+		if !reflect.DeepEqual(ids, wantPaths[i]) {
+			t.Errorf("unexpected path for cost %v: got:%v want:%v", p.Cost, ids, wantPaths[i])
+		}
+	}
+
+	if got := ParetoShortest(g, simple.Node(3), simple.Node(0), paretoCost); got != nil {
+		t.Errorf("unexpected frontier for unreachable target: got:%v", got)
+	}
+	if got := ParetoShortest(g, simple.Node(2), simple.Node(2), paretoCost); !reflect.DeepEqual(got, [][2]float64{{0, 0}}) {
+		t.Errorf("unexpected frontier for source target: got:%v", got)
+	}
+}
+
+func TestParetoShortestRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + rnd.Intn(7)
+		g := simple.NewDirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.4 {
+					g.SetEdge(paretoEdge{
+						Edge: simple.Edge{F: simple.Node(u), T: simple.Node(v)},
+						Cost: [2]float64{float64(rnd.Intn(10)), float64(rnd.Intn(10))},
+					})
+				}
+			}
+		}
+		s, tn := simple.Node(0), simple.Node(n-1)
+
+		want := bruteForcePareto(g, s, tn)
+		got := ParetoShortest(g, s, tn, paretoCost)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected frontier for test %d: got:%v want:%v", i, got, want)
+		}
+
+		for _, p := range ParetoShortestPaths(g, s, tn, paretoCost, 0) {
+			if !topo.IsPathIn(g, p.Path) {
+				t.Errorf("returned path is not a path in g: %v", p.Path)
+			}
+			var cost [2]float64
+			for k := 1; k < len(p.Path); k++ {
+				c := paretoCost(g.Edge(p.Path[k-1].ID(), p.Path[k].ID()))
+				cost[0] += c[0]
+				cost[1] += c[1]
+			}
+			if cost != p.Cost {
+				t.Errorf("path cost mismatch: got:%v want:%v", p.Cost, cost)
+			}
+		}
+
+		const eps = 0.25
+		approx := ParetoShortestPaths(g, s, tn, paretoCost, eps)
+		if len(approx) > len(want) {
+			t.Errorf("epsilon frontier larger than exact frontier: %d > %d", len(approx), len(want))
+		}
+		for _, c := range want {
+			var covered bool
+			for _, p := range approx {
+				if p.Cost[0] <= (1+eps)*c[0] && p.Cost[1] <= (1+eps)*c[1] {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				t.Errorf("Pareto cost %v not epsilon-dominated by approximate frontier %v", c, approx)
+			}
+		}
+	}
+}
+
+// bruteForcePareto returns the Pareto frontier of the costs of all simple
+// paths from s to t in g.
+func bruteForcePareto(g graph.Graph, s, t graph.Node) [][2]float64 {
+	var costs [][2]float64
+	onPath := make(map[int64]bool)
+	var walk func(u graph.Node, cost [2]float64)
+	walk = func(u graph.Node, cost [2]float64) {
+		if u.ID() == t.ID() {
+			costs = append(costs, cost)
+			return
+		}
+		onPath[u.ID()] = true
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if onPath[v.ID()] {
+				continue
+			}
+			c := paretoCost(g.Edge(u.ID(), v.ID()))
+			walk(v, [2]float64{cost[0] + c[0], cost[1] + c[1]})
+		}
+		onPath[u.ID()] = false
+	}
+	walk(s, [2]float64{})
+
+	var front [][2]float64
+	for i, c := range costs {
+		ok := true
+		for j, d := range costs {
+			if d[0] <= c[0] && d[1] <= c[1] && (d != c || j < i) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			front = append(front, c)
+		}
+	}
+	sort.Sort(byFirstCost(front))
+	return front
+}
+
+type byFirstCost [][2]float64
+
+func (c byFirstCost) Len() int           { return len(c) }
+func (c byFirstCost) Less(i, j int) bool { return c[i][0] < c[j][0] }
+func (c byFirstCost) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
@@ -0,0 +1,112 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// QuickestPath returns the path from s to t in g that minimizes the time
+// taken to transmit a message of the given size, and that time. The
+// transmission time of a path is the sum of the delays of its edges plus
+// size divided by the minimum capacity of its edges, where delay and capacity
+// return the lead time and bandwidth of an edge. Edges with a non-positive
+// capacity are not used. If t is not reachable from s, QuickestPath returns
+// a nil path and +Inf.
+//
+// QuickestPath finds the delay-shortest path using only edges with capacity
+// at least c for each distinct edge capacity c, so it requires O(r) shortest
+// path searches where r is the number of distinct capacities.
+//
+// QuickestPath will panic if a reachable edge has a negative delay.
+func QuickestPath(g graph.Graph, s, t graph.Node, delay, capacity func(e graph.Edge) float64, size float64) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	if s.ID() == t.ID() {
+		return []graph.Node{g.Node(s.ID())}, 0
+	}
+
+	seen := make(map[float64]bool)
+	var caps []float64
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			c := capacity(g.Edge(uid, v.ID()))
+			if c > 0 && !seen[c] {
+				seen[c] = true
+				caps = append(caps, c)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(caps)))
+
+	var (
+		best     []graph.Node
+		bestTime = math.Inf(1)
+	)
+	for _, c := range caps {
+		// Thresholds are in decreasing order, so the
+		// bandwidth term only grows from here on.
+		if size/c >= bestTime {
+			break
+		}
+		paths := DijkstraFrom(s, thresholdGraph{g: g, delay: delay, capacity: capacity, min: c})
+		p, d := paths.To(t.ID())
+		if p == nil {
+			continue
+		}
+		if time := d + size/c; time < bestTime {
+			best, bestTime = p, time
+		}
+	}
+	return best, bestTime
+}
+
+// thresholdGraph is a view of a graph holding only edges with a capacity of
+// at least min and weighted by their delay.
+type thresholdGraph struct {
+	g               graph.Graph
+	delay, capacity func(graph.Edge) float64
+	min             float64
+}
+
+func (g thresholdGraph) From(id int64) graph.Nodes {
+	var nodes []graph.Node
+	to := g.g.From(id)
+	for to.Next() {
+		v := to.Node()
+		if g.Edge(id, v.ID()) != nil {
+			nodes = append(nodes, v)
+		}
+	}
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+func (g thresholdGraph) Edge(uid, vid int64) graph.Edge {
+	e := g.g.Edge(uid, vid)
+	if e == nil || g.capacity(e) < g.min {
+		return nil
+	}
+	return e
+}
+
+func (g thresholdGraph) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return 0, true
+	}
+	e := g.Edge(xid, yid)
+	if e == nil {
+		return math.Inf(1), false
+	}
+	return g.delay(e), true
+}
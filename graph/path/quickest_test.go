@@ -0,0 +1,147 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// linkEdge is an edge with a delay and a capacity.
+type linkEdge struct {
+	simple.Edge
+	Delay, Capacity float64
+}
+
+func linkDelay(e graph.Edge) float64    { return e.(linkEdge).Delay }
+func linkCapacity(e graph.Edge) float64 { return e.(linkEdge).Capacity }
+
+func TestQuickestPath(t *testing.T) {
+	// Path 0-1-3 is fast but narrow, path 0-2-3 is slow but wide.
+	g := simple.NewDirectedGraph()
+	for _, e := range []linkEdge{
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(1)}, Delay: 1, Capacity: 1},
+		{Edge: simple.Edge{F: simple.Node(1), T: simple.Node(3)}, Delay: 1, Capacity: 10},
+		{Edge: simple.Edge{F: simple.Node(0), T: simple.Node(2)}, Delay: 5, Capacity: 10},
+		{Edge: simple.Edge{F: simple.Node(2), T: simple.Node(3)}, Delay: 5, Capacity: 10},
+	} {
+		g.SetEdge(e)
+	}
+	for _, test := range []struct {
+		size float64
+		path []int64
+		time float64
+	}{
+		{size: 0, path: []int64{0, 1, 3}, time: 2},
+		{size: 5, path: []int64{0, 1, 3}, time: 7},
+		{size: 100, path: []int64{0, 2, 3}, time: 20},
+	} {
+		p, time := QuickestPath(g, simple.Node(0), simple.Node(3), linkDelay, linkCapacity, test.size)
+		if time != test.time {
+			t.Errorf("unexpected time for size %v: got:%v want:%v", test.size, time, test.time)
+		}
+		if got := nodeIDs(p); !equalIDs(got, test.path) {
+			t.Errorf("unexpected path for size %v: got:%v want:%v", test.size, got, test.path)
+		}
+	}
+
+	p, time := QuickestPath(g, simple.Node(3), simple.Node(0), linkDelay, linkCapacity, 1)
+	if p != nil || !math.IsInf(time, 1) {
+		t.Errorf("unexpected result for unreachable target: got:%v %v want:nil +Inf", p, time)
+	}
+}
+
+func TestQuickestPathBruteForce(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		src := rand.NewSource(seed)
+		topology := simple.NewDirectedGraph()
+		gen.Gnp(topology, 8, 0.35, src)
+		rnd := rand.New(src)
+		g := simple.NewDirectedGraph()
+		for _, n := range graph.NodesOf(topology.Nodes()) {
+			g.AddNode(n)
+		}
+		for _, e := range graph.EdgesOf(topology.Edges()) {
+			g.SetEdge(linkEdge{
+				Edge:     simple.Edge{F: e.From(), T: e.To()},
+				Delay:    float64(rnd.Intn(5)),
+				Capacity: float64(1 + rnd.Intn(4)),
+			})
+		}
+		for _, size := range []float64{0, 1, 6, 30} {
+			s, tn := simple.Node(0), simple.Node(7)
+			p, got := QuickestPath(g, s, tn, linkDelay, linkCapacity, size)
+			want := bruteForceQuickest(g, s, tn, size)
+			if got != want {
+				t.Errorf("unexpected time for seed %d size %v: got:%v want:%v", seed, size, got, want)
+				continue
+			}
+			if p == nil {
+				continue
+			}
+			if !topo.IsPathIn(g, p) || p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+				t.Errorf("invalid path for seed %d size %v: %v", seed, size, p)
+				continue
+			}
+			if pathTime(g, p, size) != got {
+				t.Errorf("path time mismatch for seed %d size %v: got:%v want:%v", seed, size, pathTime(g, p, size), got)
+			}
+		}
+	}
+}
+
+// bruteForceQuickest returns the minimum transmission time over all simple
+// paths from s to t in g.
+func bruteForceQuickest(g graph.Directed, s, t graph.Node, size float64) float64 {
+	best := math.Inf(1)
+	onPath := map[int64]bool{s.ID(): true}
+	var walk func(u graph.Node, delay, capacity float64)
+	walk = func(u graph.Node, delay, capacity float64) {
+		if u.ID() == t.ID() {
+			if time := delay + size/capacity; time < best {
+				best = time
+			}
+			return
+		}
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if onPath[v.ID()] {
+				continue
+			}
+			e := g.Edge(u.ID(), v.ID())
+			onPath[v.ID()] = true
+			walk(v, delay+linkDelay(e), math.Min(capacity, linkCapacity(e)))
+			delete(onPath, v.ID())
+		}
+	}
+	walk(s, 0, math.Inf(1))
+	return best
+}
+
+// pathTime returns the transmission time of the path p in g.
+func pathTime(g graph.Graph, p []graph.Node, size float64) float64 {
+	var delay float64
+	capacity := math.Inf(1)
+	for i := 1; i < len(p); i++ {
+		e := g.Edge(p[i-1].ID(), p[i].ID())
+		delay += linkDelay(e)
+		capacity = math.Min(capacity, linkCapacity(e))
+	}
+	return delay + size/capacity
+}
+
+func nodeIDs(p []graph.Node) []int64 {
+	var ids []int64
+	for _, n := range p {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
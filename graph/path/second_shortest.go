@@ -0,0 +1,172 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// SecondShortestPath returns the second shortest path from s to t in g, the
+// shortest path from s to t that differs from a shortest path, and its weight.
+// If loopless is true, the returned path does not visit any node more than
+// once, otherwise it may be a walk that revisits nodes. If no such path exists,
+// ok is returned false. The returned weight may equal the weight of the
+// shortest path when there is more than one shortest path.
+//
+// A shortest path tree to t is found with a single run of Dijkstra's algorithm
+// and the alternatives are the deviations from the shortest path from s that
+// leave it at some node and then follow the tree to t. If loopless is true,
+// a deviation whose tree suffix revisits a node of the shortest path is
+// replaced by a shortest spur path avoiding those nodes, as in Yen's
+// algorithm, so a further Dijkstra search is only needed where the tree does
+// not already give a loopless deviation.
+//
+// SecondShortestPath will panic if g has a negative edge weight.
+func SecondShortestPath(g graph.Weighted, s, t graph.Node, loopless bool) (path []graph.Node, weight float64, ok bool) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1), false
+	}
+	var back traverse.Graph = g
+	if d, isDirected := g.(graph.Directed); isDirected {
+		back = reverseWeighted{g: g, to: d.To}
+	}
+	tree := DijkstraFrom(t, back)
+	toTarget := func(v graph.Node) []graph.Node {
+		p, _ := tree.To(v.ID())
+		ordered.Reverse(p)
+		return p
+	}
+
+	shortest := toTarget(s)
+	if shortest == nil {
+		return nil, math.Inf(1), false
+	}
+
+	weight = math.Inf(1)
+	var (
+		prefix float64
+		banned = make(set.Int64s)
+	)
+	for i, u := range shortest {
+		uid := u.ID()
+		var nid int64
+		last := i == len(shortest)-1
+		if !last {
+			nid = shortest[i+1].ID()
+		} else if loopless {
+			// A loopless path cannot leave t.
+			break
+		}
+
+		// Find the lowest cost deviation from u that follows
+		// the tree to t.
+		var (
+			best graph.Node
+			cost = math.Inf(1)
+		)
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if (!last && vid == nid) || (loopless && (vid == uid || banned.Has(vid))) {
+				continue
+			}
+			c := edgeWeight(g, uid, vid) + tree.WeightTo(vid)
+			if c < cost {
+				best, cost = v, c
+			}
+		}
+
+		if best != nil && prefix+cost < weight {
+			suffix := toTarget(best)
+			if !loopless || avoids(suffix, banned, uid) {
+				path = append(append([]graph.Node(nil), shortest[:i+1]...), suffix...)
+				weight = prefix + cost
+			} else {
+				spur, w := DijkstraFrom(u, spurAdjuster{Weighted: g, banned: banned, from: uid, to: nid}).To(t.ID())
+				if spur != nil && prefix+w < weight {
+					path = append(append([]graph.Node(nil), shortest[:i]...), spur...)
+					weight = prefix + w
+				}
+			}
+		}
+
+		if !last {
+			banned.Add(uid)
+			prefix += edgeWeight(g, uid, nid)
+		}
+	}
+	if path == nil {
+		return nil, math.Inf(1), false
+	}
+	return path, weight, true
+}
+
+// edgeWeight returns the weight of the edge from u to v in g, panicking if
+// the weight is not valid.
+func edgeWeight(g graph.Weighted, uid, vid int64) float64 {
+	w, ok := g.Weight(uid, vid)
+	if !ok {
+		panic("path: unexpected invalid weight")
+	}
+	return w
+}
+
+// avoids returns whether path does not contain the node with ID uid or any
+// node in banned.
+func avoids(path []graph.Node, banned set.Int64s, uid int64) bool {
+	for _, n := range path {
+		if n.ID() == uid || banned.Has(n.ID()) {
+			return false
+		}
+	}
+	return true
+}
+
+// reverseWeighted is a weighted directed graph with its edges reversed.
+type reverseWeighted struct {
+	g  graph.Weighted
+	to func(id int64) graph.Nodes
+}
+
+func (g reverseWeighted) From(id int64) graph.Nodes { return g.to(id) }
+func (g reverseWeighted) Edge(uid, vid int64) graph.Edge {
+	return g.g.Edge(vid, uid)
+}
+func (g reverseWeighted) Weight(xid, yid int64) (w float64, ok bool) {
+	return g.g.Weight(yid, xid)
+}
+
+// spurAdjuster is a weighted graph with the banned nodes and the
+// edge between from and to removed.
+type spurAdjuster struct {
+	graph.Weighted
+	banned   set.Int64s
+	from, to int64
+}
+
+func (g spurAdjuster) From(id int64) graph.Nodes {
+	if g.banned.Has(id) {
+		return graph.Empty
+	}
+	var nodes []graph.Node
+	it := g.Weighted.From(id)
+	for it.Next() {
+		v := it.Node()
+		vid := v.ID()
+		if g.banned.Has(vid) || (id == g.from && vid == g.to) {
+			continue
+		}
+		nodes = append(nodes, v)
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
@@ -0,0 +1,180 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSecondShortestPath(t *testing.T) {
+	// https://en.wikipedia.org/w/index.php?title=Yen%27s_algorithm&oldid=841018784#Example
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node('C'), T: simple.Node('D'), W: 3},
+		{F: simple.Node('C'), T: simple.Node('E'), W: 2},
+		{F: simple.Node('E'), T: simple.Node('D'), W: 1},
+		{F: simple.Node('D'), T: simple.Node('F'), W: 4},
+		{F: simple.Node('E'), T: simple.Node('F'), W: 2},
+		{F: simple.Node('E'), T: simple.Node('G'), W: 3},
+		{F: simple.Node('F'), T: simple.Node('G'), W: 2},
+		{F: simple.Node('F'), T: simple.Node('H'), W: 1},
+		{F: simple.Node('G'), T: simple.Node('H'), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	for _, loopless := range []bool{true, false} {
+		p, w, ok := SecondShortestPath(g, simple.Node('C'), simple.Node('H'), loopless)
+		if !ok {
+			t.Fatalf("unexpected failure for loopless=%t", loopless)
+		}
+		if got, want := nodeIDs(p), []int64{'C', 'E', 'G', 'H'}; !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected path for loopless=%t: got:%v want:%v", loopless, got, want)
+		}
+		if w != 7 {
+			t.Errorf("unexpected weight for loopless=%t: got:%v want:7", loopless, w)
+		}
+	}
+
+	// A single edge has no loopless alternative, but may
+	// be extended by a walk around a cycle through the target.
+	g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 2})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(1), W: 3})
+	if _, _, ok := SecondShortestPath(g, simple.Node(0), simple.Node(1), true); ok {
+		t.Error("unexpected loopless second shortest path")
+	}
+	p, w, ok := SecondShortestPath(g, simple.Node(0), simple.Node(1), false)
+	if !ok {
+		t.Fatal("unexpected failure for walk")
+	}
+	if got, want := nodeIDs(p), []int64{0, 1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected walk: got:%v want:%v", got, want)
+	}
+	if w != 6 {
+		t.Errorf("unexpected walk weight: got:%v want:6", w)
+	}
+
+	if _, _, ok := SecondShortestPath(g, simple.Node(1), simple.Node(0), false); ok {
+		t.Error("unexpected path to unreachable node")
+	}
+}
+
+func TestSecondShortestPathRandom(t *testing.T) {
+	const limit = 40
+
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 200; test++ {
+		n := 2 + rnd.Intn(6)
+		var g interface {
+			graph.Weighted
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if test%2 == 0 {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+		for i := 0; i < n; i++ {
+			g.(graph.NodeAdder).AddNode(simple.Node(i))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.3 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(5))})
+				}
+			}
+		}
+		s, d := simple.Node(rnd.Intn(n)), simple.Node(rnd.Intn(n))
+
+		for _, loopless := range []bool{true, false} {
+			weights := bruteForcePathWeights(g, s.ID(), d.ID(), loopless, limit)
+			p, w, ok := SecondShortestPath(g, s, d, loopless)
+			if len(weights) < 2 {
+				if ok {
+					t.Errorf("unexpected second shortest path for test %d loopless=%t: %v", test, loopless, nodeIDs(p))
+				}
+				continue
+			}
+			if !ok {
+				t.Errorf("missing second shortest path for test %d loopless=%t", test, loopless)
+				continue
+			}
+			if w != weights[1] {
+				t.Errorf("unexpected weight for test %d loopless=%t: got:%v want:%v", test, loopless, w, weights[1])
+			}
+			if p[0].ID() != s.ID() || p[len(p)-1].ID() != d.ID() {
+				t.Errorf("unexpected path ends for test %d loopless=%t: %v", test, loopless, nodeIDs(p))
+			}
+			var sum float64
+			seen := make(map[int64]bool)
+			for i, u := range p {
+				if loopless && seen[u.ID()] {
+					t.Errorf("repeated node in loopless path for test %d: %v", test, nodeIDs(p))
+				}
+				seen[u.ID()] = true
+				if i == 0 {
+					continue
+				}
+				e, ok := g.Weight(p[i-1].ID(), u.ID())
+				if !ok {
+					t.Errorf("missing edge %d-%d for test %d loopless=%t", p[i-1].ID(), u.ID(), test, loopless)
+				}
+				sum += e
+			}
+			if sum != w {
+				t.Errorf("path weight mismatch for test %d loopless=%t: got:%v want:%v", test, loopless, sum, w)
+			}
+		}
+	}
+}
+
+// bruteForcePathWeights returns the sorted weights of the two lightest paths
+// from s to t in g with weight no greater than limit, allowing repeated nodes
+// unless loopless is true. Edge weights must be positive.
+func bruteForcePathWeights(g graph.Weighted, s, t int64, loopless bool, limit float64) []float64 {
+	var (
+		weights []float64
+		onPath  = make(map[int64]bool)
+		walk    func(u int64, w float64)
+	)
+	bound := func() float64 {
+		if len(weights) < 2 {
+			return limit
+		}
+		return weights[1]
+	}
+	walk = func(u int64, w float64) {
+		if u == t {
+			weights = append(weights, w)
+			sort.Float64s(weights)
+			if len(weights) > 2 {
+				weights = weights[:2]
+			}
+		}
+		onPath[u] = true
+		for _, v := range graph.NodesOf(g.From(u)) {
+			vid := v.ID()
+			if loopless && onPath[vid] {
+				continue
+			}
+			e, _ := g.Weight(u, vid)
+			if w+e <= bound() {
+				walk(vid, w+e)
+			}
+		}
+		onPath[u] = false
+	}
+	walk(s, 0)
+	return weights
+}
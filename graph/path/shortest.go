@@ -6,6 +6,7 @@ package path
 
 import (
 	"math"
+	"sort"
 
 	"golang.org/x/exp/rand"
 
@@ -370,6 +371,41 @@ func (p AllShortest) AllBetween(uid, vid int64) (paths [][]graph.Node, weight fl
 	return paths, weight
 }
 
+// AllBetweenFunc returns all shortest paths from u to v and the weight of the
+// paths as for AllBetween, with the paths sorted by the provided less function.
+// The sort is stable. If less is nil, paths are ordered lexically by the IDs of
+// their nodes, so the ordering is deterministic and the first path may be used
+// as a canonical representative of the shortest paths.
+func (p AllShortest) AllBetweenFunc(uid, vid int64, less func(p, q []graph.Node) bool) (paths [][]graph.Node, weight float64) {
+	paths, weight = p.AllBetween(uid, vid)
+	if less == nil {
+		less = lexicalPath
+	}
+	sort.Stable(byPath{paths: paths, less: less})
+	return paths, weight
+}
+
+// lexicalPath returns whether the node IDs of p are lexically less than
+// those of q.
+func lexicalPath(p, q []graph.Node) bool {
+	for i := 0; i < len(p) && i < len(q); i++ {
+		if p[i].ID() != q[i].ID() {
+			return p[i].ID() < q[i].ID()
+		}
+	}
+	return len(p) < len(q)
+}
+
+// byPath sorts paths using a less function.
+type byPath struct {
+	paths [][]graph.Node
+	less  func(p, q []graph.Node) bool
+}
+
+func (s byPath) Len() int           { return len(s.paths) }
+func (s byPath) Less(i, j int) bool { return s.less(s.paths[i], s.paths[j]) }
+func (s byPath) Swap(i, j int)      { s.paths[i], s.paths[j] = s.paths[j], s.paths[i] }
+
 func (p AllShortest) allBetween(from, to int, seen []bool, path []graph.Node, paths [][]graph.Node) [][]graph.Node {
 	if p.forward {
 		seen[from] = true
@@ -0,0 +1,104 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// ShortestPathDAG returns the directed acyclic graph of all shortest paths
+// from s in g. The returned graph holds the nodes of g that are reachable
+// from s and the edges u→v of g for which dist(s, v) == dist(s, u) + w(u, v),
+// that is, exactly the edges that lie on some shortest path from s. Edges of
+// an undirected g are oriented away from s.
+//
+// If g implements Weighted, distances are found using Dijkstra's algorithm
+// and the returned graph is a *simple.WeightedDirectedGraph holding the edge
+// weights of g. Otherwise distances are found by a breadth-first search and
+// the returned graph is a *simple.DirectedGraph. If s is not in g, the
+// returned graph is empty. Distances are compared exactly, so floating point
+// rounding may exclude edges from the DAG in graphs with non-integer weights.
+//
+// Zero weight edges between nodes at the same distance from s may form
+// cycles. To keep the result acyclic, such an edge u→v is only included if
+// u is reached before v by a breadth-first search from s over the edges that
+// lie on shortest paths, so every node of the returned graph remains
+// reachable from s.
+//
+// ShortestPathDAG will panic if g has an s-reachable negative edge weight.
+func ShortestPathDAG(s graph.Node, g graph.Graph) graph.Directed {
+	if wg, ok := g.(Weighted); ok {
+		dag := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		if g.Node(s.ID()) == nil {
+			return dag
+		}
+		paths := DijkstraFrom(s, g)
+		weight := func(uid, vid int64) float64 {
+			w, ok := wg.Weight(uid, vid)
+			if !ok {
+				panic("shortest path dag: unexpected invalid weight")
+			}
+			return w
+		}
+
+		// Number the nodes in the order they are reached by a
+		// breadth-first search over shortest path edges, and
+		// add the edges that respect that order for zero
+		// weight edges.
+		order := map[int64]int{s.ID(): 0}
+		queue := []graph.Node{s}
+		dag.AddNode(s)
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			uid := u.ID()
+			du := paths.WeightTo(uid)
+			for _, v := range graph.NodesOf(g.From(uid)) {
+				vid := v.ID()
+				if vid == uid {
+					continue
+				}
+				w := weight(uid, vid)
+				if paths.WeightTo(vid) != du+w {
+					continue
+				}
+				if _, seen := order[vid]; !seen {
+					order[vid] = len(order)
+					queue = append(queue, v)
+					dag.AddNode(v)
+				}
+				if w != 0 || order[uid] < order[vid] {
+					dag.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: w})
+				}
+			}
+		}
+		return dag
+	}
+
+	dag := simple.NewDirectedGraph()
+	if g.Node(s.ID()) == nil {
+		return dag
+	}
+	depth := make(map[int64]int)
+	var bf traverse.BreadthFirst
+	bf.Walk(g, s, func(n graph.Node, d int) bool {
+		depth[n.ID()] = d
+		dag.AddNode(n)
+		return false
+	})
+	for _, u := range graph.NodesOf(dag.Nodes()) {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			if d, ok := depth[v.ID()]; ok && d == depth[uid]+1 {
+				dag.SetEdge(simple.Edge{F: u, T: v})
+			}
+		}
+	}
+	return dag
+}
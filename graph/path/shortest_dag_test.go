@@ -0,0 +1,210 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+func TestShortestPathDAGGrid(t *testing.T) {
+	// On an r×c grid the number of shortest paths from one
+	// corner to the other is binomial(r+c-2, r-1).
+	const r, c = 4, 5
+	g := simple.NewUndirectedGraph()
+	id := func(i, j int) simple.Node { return simple.Node(i*c + j) }
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if i+1 < r {
+				g.SetEdge(simple.Edge{F: id(i, j), T: id(i+1, j)})
+			}
+			if j+1 < c {
+				g.SetEdge(simple.Edge{F: id(i, j), T: id(i, j+1)})
+			}
+		}
+	}
+
+	dag := ShortestPathDAG(id(0, 0), g)
+	if _, ok := dag.(*simple.DirectedGraph); !ok {
+		t.Fatalf("unexpected type for unweighted DAG: %T", dag)
+	}
+	if got, want := dag.Nodes().Len(), r*c; got != want {
+		t.Errorf("unexpected number of nodes: got:%d want:%d", got, want)
+	}
+	// Every grid edge lies on a shortest path from the corner.
+	if got, want := len(graph.EdgesOf(dag.(*simple.DirectedGraph).Edges())), r*(c-1)+c*(r-1); got != want {
+		t.Errorf("unexpected number of edges: got:%d want:%d", got, want)
+	}
+	if got, want := countPaths(dag, id(0, 0), id(r-1, c-1)), 35; got != want {
+		t.Errorf("unexpected number of shortest paths: got:%d want:%d", got, want)
+	}
+}
+
+func TestShortestPathDAGWeighted(t *testing.T) {
+	for seed := uint64(1); seed <= 10; seed++ {
+		for _, directed := range []bool{false, true} {
+			var g interface {
+				graph.Weighted
+				SetWeightedEdge(graph.WeightedEdge)
+			}
+			var topology interface {
+				graph.Graph
+				graph.Builder
+				Edges() graph.Edges
+			}
+			if directed {
+				g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+				topology = simple.NewDirectedGraph()
+			} else {
+				g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+				topology = simple.NewUndirectedGraph()
+			}
+			src := rand.NewSource(seed)
+			gen.Gnp(topology, 30, 0.15, src)
+			rnd := rand.New(src)
+			for _, e := range graph.EdgesOf(topology.Edges()) {
+				g.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: float64(rnd.Intn(3))})
+			}
+
+			s := simple.Node(0)
+			if g.Node(0) == nil {
+				continue
+			}
+			paths := DijkstraFrom(s, g)
+			dag := ShortestPathDAG(s, g).(*simple.WeightedDirectedGraph)
+
+			if _, err := topo.Sort(dag); err != nil {
+				t.Errorf("result is not a DAG for seed %d directed=%t", seed, directed)
+			}
+			for _, u := range graph.NodesOf(g.Nodes()) {
+				uid := u.ID()
+				du := paths.WeightTo(uid)
+				if (dag.Node(uid) != nil) == math.IsInf(du, 1) {
+					t.Errorf("unexpected node membership for %d for seed %d directed=%t", uid, seed, directed)
+				}
+				for _, v := range graph.NodesOf(g.From(uid)) {
+					vid := v.ID()
+					w, _ := g.Weight(uid, vid)
+					want := !math.IsInf(du, 1) && paths.WeightTo(vid) == du+w
+					got := dag.HasEdgeFromTo(uid, vid)
+					if want && w == 0 {
+						// Zero weight shortest path edges are oriented
+						// by the search order, so only one direction
+						// of an undirected edge may be included.
+						if got && dag.HasEdgeFromTo(vid, uid) {
+							t.Errorf("unexpected edges %d⇄%d for seed %d directed=%t", uid, vid, seed, directed)
+						}
+						want = got
+					}
+					if got != want {
+						t.Errorf("unexpected edge %d→%d membership for seed %d directed=%t: got:%t want:%t",
+							uid, vid, seed, directed, got, want)
+					}
+					if want {
+						if dw, _ := dag.Weight(uid, vid); dw != w {
+							t.Errorf("unexpected weight for edge %d→%d: got:%v want:%v", uid, vid, dw, w)
+						}
+					}
+				}
+			}
+
+			// Every node of the DAG is reachable from s.
+			var reached int
+			var bf traverse.BreadthFirst
+			bf.Walk(dag, s, func(graph.Node, int) bool {
+				reached++
+				return false
+			})
+			if reached != dag.Nodes().Len() {
+				t.Errorf("unexpected number of nodes reachable in DAG for seed %d directed=%t: got:%d want:%d",
+					seed, directed, reached, dag.Nodes().Len())
+			}
+
+			// Every path through the DAG from s is a shortest path.
+			for _, v := range graph.NodesOf(dag.Nodes()) {
+				for _, u := range graph.NodesOf(dag.To(v.ID())) {
+					if dw, _ := dag.Weight(u.ID(), v.ID()); paths.WeightTo(u.ID())+dw != paths.WeightTo(v.ID()) {
+						t.Errorf("DAG edge %d→%d not on a shortest path", u.ID(), v.ID())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestShortestPathDAGZeroWeight(t *testing.T) {
+	// Node 1 is only reachable through the zero weight edge
+	// 0-1, and nodes 2, 3 and 4 form a zero weight cycle.
+	for _, directed := range []bool{false, true} {
+		var g interface {
+			graph.Weighted
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if directed {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+		for _, e := range []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 0},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 0},
+			{F: simple.Node(3), T: simple.Node(4), W: 0},
+			{F: simple.Node(4), T: simple.Node(2), W: 0},
+		} {
+			g.SetWeightedEdge(e)
+		}
+
+		dag := ShortestPathDAG(simple.Node(0), g)
+		if _, err := topo.Sort(dag); err != nil {
+			t.Errorf("result is not a DAG for directed=%t", directed)
+		}
+		for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}} {
+			if !dag.HasEdgeFromTo(e[0], e[1]) {
+				t.Errorf("missing edge %d→%d for directed=%t", e[0], e[1], directed)
+			}
+		}
+		var reached int
+		var bf traverse.BreadthFirst
+		bf.Walk(dag, simple.Node(0), func(graph.Node, int) bool {
+			reached++
+			return false
+		})
+		if reached != 5 {
+			t.Errorf("unexpected number of reachable nodes for directed=%t: got:%d want:5", directed, reached)
+		}
+	}
+}
+
+func TestShortestPathDAGMissing(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	if n := ShortestPathDAG(simple.Node(2), g).Nodes().Len(); n != 0 {
+		t.Errorf("unexpected number of nodes for missing source: got:%d want:0", n)
+	}
+}
+
+// countPaths returns the number of paths from s to t in the DAG g.
+func countPaths(g graph.Directed, s, t graph.Node) int {
+	order, err := topo.Sort(g)
+	if err != nil {
+		panic(err)
+	}
+	count := map[int64]int{s.ID(): 1}
+	for _, u := range order {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			count[v.ID()] += count[u.ID()]
+		}
+	}
+	return count[t.ID()]
+}
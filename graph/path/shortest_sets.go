@@ -0,0 +1,81 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ShortestBetweenSets returns a minimum weight path in g from any node in
+// sources to any node in targets, and the weight of the path. The path starts
+// at a source and ends at a target. If no target is reachable from a source,
+// ok is returned false. Source and target nodes that are not in g are ignored.
+// If a node is both a source and a target, the path holds only that node and
+// has zero weight.
+//
+// The path is found with a multi-source Dijkstra search where all sources are
+// given a distance of zero and the search stops when the first target is
+// reached. ShortestBetweenSets will panic if g has a reachable negative edge
+// weight.
+//
+// The time complexity of ShortestBetweenSets is O(|E|.log|V|).
+func ShortestBetweenSets(g graph.Weighted, sources, targets []graph.Node) (path []graph.Node, weight float64, ok bool) {
+	isTarget := make(map[int64]bool, len(targets))
+	for _, t := range targets {
+		isTarget[t.ID()] = true
+	}
+
+	dist := make(map[int64]float64)
+	prev := make(map[int64]graph.Node)
+	var Q priorityQueue
+	for _, s := range sources {
+		if g.Node(s.ID()) == nil {
+			continue
+		}
+		if _, seen := dist[s.ID()]; seen {
+			continue
+		}
+		dist[s.ID()] = 0
+		Q = append(Q, distanceNode{node: s, dist: 0})
+	}
+	heap.Init(&Q)
+
+	for Q.Len() != 0 {
+		mid := heap.Pop(&Q).(distanceNode)
+		mnid := mid.node.ID()
+		if mid.dist > dist[mnid] {
+			continue
+		}
+		if isTarget[mnid] {
+			path = []graph.Node{mid.node}
+			for n, ok := prev[mnid]; ok; n, ok = prev[n.ID()] {
+				path = append(path, n)
+			}
+			ordered.Reverse(path)
+			return path, mid.dist, true
+		}
+		for _, v := range graph.NodesOf(g.From(mnid)) {
+			vid := v.ID()
+			w, ok := g.Weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			joint := mid.dist + w
+			if d, seen := dist[vid]; !seen || joint < d {
+				dist[vid] = joint
+				prev[vid] = mid.node
+				heap.Push(&Q, distanceNode{node: v, dist: joint})
+			}
+		}
+	}
+	return nil, math.Inf(1), false
+}
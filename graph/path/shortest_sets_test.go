@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestShortestBetweenSets(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 2 + rnd.Intn(12)
+		ids := make([]int64, n)
+		for i := range ids {
+			ids[i] = int64(i)
+		}
+		g := randomWeightedDirected(rnd, ids, 0.2)
+
+		var sources, targets []graph.Node
+		isSource := make(map[int64]bool)
+		isTarget := make(map[int64]bool)
+		for _, id := range ids {
+			switch rnd.Intn(4) {
+			case 0:
+				sources = append(sources, simple.Node(id))
+				isSource[id] = true
+			case 1:
+				targets = append(targets, simple.Node(id))
+				isTarget[id] = true
+			}
+		}
+
+		want := math.Inf(1)
+		for _, s := range sources {
+			pt := DijkstraFrom(s, g)
+			for _, d := range targets {
+				want = math.Min(want, pt.WeightTo(d.ID()))
+			}
+		}
+
+		path, weight, ok := ShortestBetweenSets(g, sources, targets)
+		if ok == math.IsInf(want, 1) {
+			t.Errorf("unexpected ok for test %d: got:%t want:%t", test, ok, !ok)
+		}
+		if weight != want {
+			t.Errorf("unexpected weight for test %d: got:%v want:%v", test, weight, want)
+		}
+		if !ok {
+			if path != nil {
+				t.Errorf("unexpected path for test %d: %v", test, path)
+			}
+			continue
+		}
+		if !isSource[path[0].ID()] || !isTarget[path[len(path)-1].ID()] {
+			t.Errorf("path does not join a source to a target for test %d: %v", test, pathIDs([][]graph.Node{path}))
+		}
+		var sum float64
+		for i := 1; i < len(path); i++ {
+			e := g.WeightedEdge(path[i-1].ID(), path[i].ID())
+			if e == nil {
+				t.Fatalf("path uses missing edge for test %d: %v", test, pathIDs([][]graph.Node{path}))
+			}
+			sum += e.Weight()
+		}
+		if sum != weight {
+			t.Errorf("unexpected path weight for test %d: got:%v want:%v", test, sum, weight)
+		}
+	}
+
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	path, weight, ok := ShortestBetweenSets(g, []graph.Node{simple.Node(1), simple.Node(0)}, []graph.Node{simple.Node(1)})
+	if !ok || weight != 0 || len(path) != 1 || path[0].ID() != 1 {
+		t.Errorf("unexpected result for node in both sets: path:%v weight:%v ok:%t", path, weight, ok)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAllBetweenFunc(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 3},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	pt, ok := FloydWarshall(g)
+	if !ok {
+		t.Fatal("unexpected negative cycle")
+	}
+
+	paths, weight := pt.AllBetweenFunc(0, 3, nil)
+	if weight != 2 {
+		t.Errorf("unexpected weight: got:%v want:2", weight)
+	}
+	got := fmt.Sprint(pathIDs(paths))
+	want := "[[0 1 3] [0 2 3] [0 4 3]]"
+	if got != want {
+		t.Errorf("unexpected default path order: got:%s want:%s", got, want)
+	}
+
+	// Order by descending ID of the middle node.
+	paths, _ = pt.AllBetweenFunc(0, 3, func(p, q []graph.Node) bool {
+		return p[1].ID() > q[1].ID()
+	})
+	got = fmt.Sprint(pathIDs(paths))
+	want = "[[0 4 3] [0 2 3] [0 1 3]]"
+	if got != want {
+		t.Errorf("unexpected custom path order: got:%s want:%s", got, want)
+	}
+
+	// Comparing paths as equal retains the order of AllBetween.
+	all, _ := pt.AllBetween(0, 3)
+	paths, _ = pt.AllBetweenFunc(0, 3, func(p, q []graph.Node) bool { return false })
+	if fmt.Sprint(pathIDs(paths)) != fmt.Sprint(pathIDs(all)) {
+		t.Errorf("sort not stable: got:%v want:%v", pathIDs(paths), pathIDs(all))
+	}
+
+	paths, weight = pt.AllBetweenFunc(3, 0, nil)
+	if paths != nil || !math.IsInf(weight, 1) {
+		t.Errorf("unexpected result for unreachable node: paths:%v weight:%v", paths, weight)
+	}
+}
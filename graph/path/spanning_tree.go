@@ -10,6 +10,7 @@ import (
 	"sort"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -32,14 +33,36 @@ type WeightedBuilder interface {
 //
 // If dst has nodes that exist in g, Prim will panic.
 func Prim(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
+	return prim(dst, g, false)
+}
+
+// PrimStable generates a minimum spanning tree of g as described for Prim,
+// ordering edges of equal weight by the lower and then the higher of their
+// end node IDs. Under this ordering the minimum spanning tree is unique, so
+// the tree constructed in dst is reproducible and is the same as the tree
+// constructed by KruskalStable. The weight of the tree is the same as the
+// weight returned by Prim.
+//
+// If dst has nodes that exist in g, PrimStable will panic.
+func PrimStable(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
+	return prim(dst, g, true)
+}
+
+// prim is the implementation of Prim and PrimStable, breaking ties between
+// equal weight edges by end node IDs if stable is true.
+func prim(dst WeightedBuilder, g graph.WeightedUndirected, stable bool) float64 {
 	nodes := graph.NodesOf(g.Nodes())
 	if len(nodes) == 0 {
 		return 0
 	}
+	if stable {
+		sort.Sort(ordered.ByID(nodes))
+	}
 
 	q := &primQueue{
 		indexOf: make(map[int64]int, len(nodes)-1),
 		nodes:   make([]simple.WeightedEdge, 0, len(nodes)-1),
+		stable:  stable,
 	}
 	dst.AddNode(nodes[0])
 	for _, u := range nodes[1:] {
@@ -73,7 +96,7 @@ func Prim(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
 				if !ok {
 					panic("prim: unexpected invalid weight")
 				}
-				if w < key {
+				if w < key || (stable && w == key && q.closer(n, u)) {
 					q.update(n, u, w)
 				}
 			}
@@ -86,13 +109,26 @@ func Prim(dst WeightedBuilder, g graph.WeightedUndirected) float64 {
 // queue of edge From nodes keyed on the minimum edge weight to
 // a node in the set of nodes already connected to the minimum
 // spanning forest.
+// If stable is true, queue elements with equal keys are
+// ordered by the IDs of the end nodes of their edges.
 type primQueue struct {
 	indexOf map[int64]int
 	nodes   []simple.WeightedEdge
+	stable  bool
 }
 
 func (q *primQueue) Less(i, j int) bool {
-	return q.nodes[i].Weight() < q.nodes[j].Weight()
+	a, b := q.nodes[i], q.nodes[j]
+	if !q.stable || a.W != b.W {
+		return a.W < b.W
+	}
+	switch {
+	case a.T == nil && b.T == nil:
+		return a.F.ID() < b.F.ID()
+	case a.T == nil || b.T == nil:
+		return b.T == nil
+	}
+	return edgeIDLess(a.F.ID(), a.T.ID(), b.F.ID(), b.T.ID())
 }
 
 func (q *primQueue) Swap(i, j int) {
@@ -129,6 +165,30 @@ func (q *primQueue) key(u graph.Node) (key float64, ok bool) {
 	return q.nodes[i].Weight(), ok
 }
 
+// closer returns whether the edge between u and the MST-connected
+// node v precedes u's current queue edge of equal weight in the
+// end node ID ordering.
+func (q *primQueue) closer(u, v graph.Node) bool {
+	e := q.nodes[q.indexOf[u.ID()]]
+	return e.T == nil || edgeIDLess(u.ID(), v.ID(), e.F.ID(), e.T.ID())
+}
+
+// edgeIDLess returns whether the undirected edge between the nodes with
+// IDs xid and yid is less than the edge between uid and vid when ordered
+// by the lower and then the higher of their end node IDs.
+func edgeIDLess(xid, yid, uid, vid int64) bool {
+	if xid > yid {
+		xid, yid = yid, xid
+	}
+	if uid > vid {
+		uid, vid = vid, uid
+	}
+	if xid != uid {
+		return xid < uid
+	}
+	return yid < vid
+}
+
 // update updates u's position in the queue with the new closest
 // MST-connected neighbour, v, and the key weight between u and v.
 func (q *primQueue) update(u, v graph.Node, key float64) {
@@ -164,7 +224,26 @@ type UndirectedWeightLister interface {
 func Kruskal(dst WeightedBuilder, g UndirectedWeightLister) float64 {
 	edges := graph.WeightedEdgesOf(g.WeightedEdges())
 	sort.Sort(byWeight(edges))
+	return kruskal(dst, g, edges)
+}
 
+// KruskalStable generates a minimum spanning tree of g as described for
+// Kruskal, ordering edges of equal weight by the lower and then the higher
+// of their end node IDs. Under this ordering the minimum spanning tree is
+// unique, so the tree constructed in dst is reproducible and is the same as
+// the tree constructed by PrimStable. The weight of the tree is the same as
+// the weight returned by Kruskal.
+//
+// If dst has nodes that exist in g, KruskalStable will panic.
+func KruskalStable(dst WeightedBuilder, g UndirectedWeightLister) float64 {
+	edges := graph.WeightedEdgesOf(g.WeightedEdges())
+	sort.Sort(byWeightStable(edges))
+	return kruskal(dst, g, edges)
+}
+
+// kruskal is the implementation of Kruskal and KruskalStable, adding
+// edges to the spanning tree in the order they are held in edges.
+func kruskal(dst WeightedBuilder, g UndirectedWeightLister, edges []graph.WeightedEdge) float64 {
 	ds := newDisjointSet()
 	for _, node := range graph.NodesOf(g.Nodes()) {
 		dst.AddNode(node)
@@ -187,3 +266,138 @@ type byWeight []graph.WeightedEdge
 func (e byWeight) Len() int           { return len(e) }
 func (e byWeight) Less(i, j int) bool { return e[i].Weight() < e[j].Weight() }
 func (e byWeight) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+
+// byWeightStable sorts edges by weight and then by the
+// lower and then higher of their end node IDs.
+type byWeightStable []graph.WeightedEdge
+
+func (e byWeightStable) Len() int { return len(e) }
+func (e byWeightStable) Less(i, j int) bool {
+	a, b := e[i], e[j]
+	if a.Weight() != b.Weight() {
+		return a.Weight() < b.Weight()
+	}
+	return edgeIDLess(a.From().ID(), a.To().ID(), b.From().ID(), b.To().ID())
+}
+func (e byWeightStable) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+
+// ClassifyMSTEdges classifies the edges of g by their membership of the
+// minimum spanning forests of g. An edge is in always if it is in every
+// minimum spanning forest, in sometimes if it is in at least one but not
+// all, and in never if it is in none. Self edges are never in a minimum
+// spanning forest. The maps are keyed by the edges returned by
+// g.WeightedEdge(uid, vid) with uid ≤ vid, so the edges of g must be
+// comparable.
+//
+// The edges are classified by considering classes of edges with equal
+// weight in increasing order of weight. An edge of a class joining nodes
+// already connected by lighter edges completes a cycle on which it is the
+// heaviest edge and is in no minimum spanning forest. The remaining edges
+// of the class are in some minimum spanning forest, and are in every one if
+// they are bridges of the graph formed by the class with the components
+// connected by lighter edges contracted to single nodes.
+func ClassifyMSTEdges(g graph.WeightedUndirected) (always, sometimes, never map[graph.Edge]bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	ds := newDisjointSet()
+	var edges []graph.WeightedEdge
+	for _, u := range nodes {
+		uid := u.ID()
+		ds.makeSet(uid)
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if uid <= v.ID() {
+				edges = append(edges, g.WeightedEdge(uid, v.ID()))
+			}
+		}
+	}
+	sort.Stable(byWeight(edges))
+
+	always = make(map[graph.Edge]bool)
+	sometimes = make(map[graph.Edge]bool)
+	never = make(map[graph.Edge]bool)
+	for i := 0; i < len(edges); {
+		j := i + 1
+		for j < len(edges) && edges[j].Weight() == edges[i].Weight() {
+			j++
+		}
+
+		// Build the contracted graph of the class.
+		var cand []graph.WeightedEdge
+		var ends [][2]int
+		index := make(map[*disjointSetNode]int)
+		for _, e := range edges[i:j] {
+			a, b := ds.find(e.From().ID()), ds.find(e.To().ID())
+			if a == b {
+				never[e] = true
+				continue
+			}
+			for _, c := range []*disjointSetNode{a, b} {
+				if _, ok := index[c]; !ok {
+					index[c] = len(index)
+				}
+			}
+			cand = append(cand, e)
+			ends = append(ends, [2]int{index[a], index[b]})
+		}
+		for k, isBridge := range bridges(len(index), ends) {
+			if isBridge {
+				always[cand[k]] = true
+			} else {
+				sometimes[cand[k]] = true
+			}
+		}
+		for _, e := range cand {
+			ds.union(ds.find(e.From().ID()), ds.find(e.To().ID()))
+		}
+		i = j
+	}
+	return always, sometimes, never
+}
+
+// bridges returns whether each of the edges of the undirected multigraph
+// with n nodes and the given edge end nodes is a bridge.
+func bridges(n int, ends [][2]int) []bool {
+	adj := make([][]int, n)
+	for k, e := range ends {
+		adj[e[0]] = append(adj[e[0]], k)
+		adj[e[1]] = append(adj[e[1]], k)
+	}
+	isBridge := make([]bool, len(ends))
+	index := make([]int, n)
+	low := make([]int, n)
+	var time int
+	var visit func(u, via int)
+	visit = func(u, via int) {
+		time++
+		index[u] = time
+		low[u] = time
+		for _, k := range adj[u] {
+			if k == via {
+				continue
+			}
+			v := ends[k][0]
+			if v == u {
+				v = ends[k][1]
+			}
+			if index[v] == 0 {
+				visit(v, k)
+				if low[v] < low[u] {
+					low[u] = low[v]
+				}
+				if low[v] > index[u] {
+					isBridge[k] = true
+				}
+			} else if index[v] < low[u] {
+				low[u] = index[v]
+			}
+		}
+	}
+	for u := range adj {
+		if index[u] == 0 {
+			visit(u, -1)
+		}
+	}
+	return isBridge
+}
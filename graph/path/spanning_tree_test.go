@@ -7,9 +7,13 @@ package path
 import (
 	"fmt"
 	"math"
+	"sort"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -292,3 +296,182 @@ func TestPrim(t *testing.T) {
 		return Prim(dst, g)
 	}, t)
 }
+
+func TestKruskalStable(t *testing.T) {
+	testMinumumSpanning(func(dst WeightedBuilder, g spanningGraph) float64 {
+		return KruskalStable(dst, g)
+	}, t)
+}
+
+func TestPrimStable(t *testing.T) {
+	testMinumumSpanning(func(dst WeightedBuilder, g spanningGraph) float64 {
+		return PrimStable(dst, g)
+	}, t)
+}
+
+func TestStableSpanningTies(t *testing.T) {
+	// All edges of a complete graph have equal weight, so the
+	// stable tree is the star on the node with the lowest ID.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	const n = 8
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 1})
+		}
+	}
+	var want string
+	for v := 1; v < n; v++ {
+		want += fmt.Sprintf("0-%d ", v)
+	}
+	for _, mst := range []struct {
+		name string
+		fn   func(WeightedBuilder, spanningGraph) float64
+	}{
+		{name: "PrimStable", fn: func(dst WeightedBuilder, g spanningGraph) float64 { return PrimStable(dst, g) }},
+		{name: "KruskalStable", fn: func(dst WeightedBuilder, g spanningGraph) float64 { return KruskalStable(dst, g) }},
+	} {
+		for i := 0; i < 10; i++ {
+			dst := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+			if w := mst.fn(dst, g); w != n-1 {
+				t.Errorf("unexpected weight for %s: got:%v want:%d", mst.name, w, n-1)
+			}
+			if got := treeEdges(dst); got != want {
+				t.Errorf("unexpected tree for %s: got:%s want:%s", mst.name, got, want)
+			}
+		}
+	}
+
+	// Prim and Kruskal agree on graphs with many ties.
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 50; test++ {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		n := 1 + rnd.Intn(12)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(3))})
+				}
+			}
+		}
+		p := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		k := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		pw := PrimStable(p, g)
+		kw := KruskalStable(k, g)
+		if pw != kw || treeEdges(p) != treeEdges(k) {
+			t.Errorf("stable trees differ for test %d:\nPrim:    %v %s\nKruskal: %v %s", test, pw, treeEdges(p), kw, treeEdges(k))
+		}
+	}
+}
+
+// treeEdges returns a description of the edges of g ordered by end node IDs.
+func treeEdges(g *simple.WeightedUndirectedGraph) string {
+	var s string
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		to := graph.NodesOf(g.From(u.ID()))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if u.ID() < v.ID() {
+				s += fmt.Sprintf("%d-%d ", u.ID(), v.ID())
+			}
+		}
+	}
+	return s
+}
+
+func TestClassifyMSTEdges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		n := 1 + rnd.Intn(7)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.5 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(3))})
+				}
+			}
+		}
+		edges := graph.WeightedEdgesOf(g.WeightedEdges())
+
+		// Enumerate all minimum spanning forests.
+		dst := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		min := Kruskal(dst, g)
+		size := dst.Edges().Len()
+		count := make(map[graph.Edge]int)
+		var forests int
+		for set := 0; set < 1<<uint(len(edges)); set++ {
+			var w float64
+			var chosen []graph.WeightedEdge
+			for k, e := range edges {
+				if set&(1<<uint(k)) != 0 {
+					chosen = append(chosen, e)
+					w += e.Weight()
+				}
+			}
+			if len(chosen) != size || w != min || !isForest(chosen) {
+				continue
+			}
+			forests++
+			for _, e := range chosen {
+				count[g.WeightedEdge(e.From().ID(), e.To().ID())]++
+			}
+		}
+
+		always, sometimes, never := ClassifyMSTEdges(g)
+		if len(always)+len(sometimes)+len(never) != len(edges) {
+			t.Errorf("unexpected number of classified edges for test %d: got:%d want:%d",
+				test, len(always)+len(sometimes)+len(never), len(edges))
+		}
+		for _, e := range edges {
+			uid, vid := e.From().ID(), e.To().ID()
+			if uid > vid {
+				uid, vid = vid, uid
+			}
+			key := g.WeightedEdge(uid, vid)
+			var want string
+			switch count[key] {
+			case forests:
+				want = "always"
+			case 0:
+				want = "never"
+			default:
+				want = "sometimes"
+			}
+			var got string
+			switch {
+			case always[key]:
+				got = "always"
+			case sometimes[key]:
+				got = "sometimes"
+			case never[key]:
+				got = "never"
+			}
+			if got != want {
+				t.Errorf("unexpected classification of edge %d-%d (w=%v) for test %d: got:%s want:%s",
+					uid, vid, e.Weight(), test, got, want)
+			}
+		}
+	}
+}
+
+// isForest returns whether the edges form a forest.
+func isForest(edges []graph.WeightedEdge) bool {
+	ds := newDisjointSet()
+	for _, e := range edges {
+		ds.makeSet(e.From().ID())
+		ds.makeSet(e.To().ID())
+		a, b := ds.find(e.From().ID()), ds.find(e.To().ID())
+		if a == b {
+			return false
+		}
+		ds.union(a, b)
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DijkstraWithTurnPenalties returns a shortest path from s to t in g and its
+// cost where, in addition to the edge weights, each turn from the edge prev→cur
+// onto the edge cur→next costs turnCost(prev, cur, next). A turn cost of +Inf
+// bans the turn. No turn cost is charged for leaving s at the start of the path.
+// If t is not reachable from s, DijkstraWithTurnPenalties returns a nil path and
+// +Inf.
+//
+// The search is Dijkstra's algorithm over states that are pairs of a node and
+// the node it was reached from, so a path may pass through a node more than
+// once when the turns make that cheaper. The state space is the set of edges of
+// g rather than its nodes, holding up to |E| states for a directed graph and
+// 2|E| for an undirected graph, and each state is expanded over the out-edges
+// of its node, giving a running time of O(Σ_v deg(v)^2 log|E|).
+//
+// DijkstraWithTurnPenalties will panic if it finds a negative edge weight or
+// a negative turn cost.
+func DijkstraWithTurnPenalties(g graph.Weighted, s, t graph.Node, turnCost func(prev, cur, next graph.Node) float64) ([]graph.Node, float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, math.Inf(1)
+	}
+	if s.ID() == t.ID() {
+		return []graph.Node{s}, 0
+	}
+
+	// State 0 is the start, and every other state is
+	// the head of an edge reached from its tail.
+	type state struct {
+		prev, cur graph.Node
+		parent    int
+		dist      float64
+		done      bool
+	}
+	states := []state{{cur: s, parent: -1}}
+	indexOf := make(map[[2]int64]int)
+
+	q := turnQueue{{state: 0}}
+	for q.Len() != 0 {
+		top := heap.Pop(&q).(turnItem)
+		st := &states[top.state]
+		if st.done {
+			continue
+		}
+		st.done = true
+		u := st.cur
+		if u.ID() == t.ID() {
+			var p []graph.Node
+			for i := top.state; i >= 0; i = states[i].parent {
+				p = append(p, states[i].cur)
+			}
+			for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+				p[i], p[j] = p[j], p[i]
+			}
+			return p, top.dist
+		}
+
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			w, ok := g.Weight(u.ID(), v.ID())
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			if st.prev != nil {
+				c := turnCost(st.prev, u, v)
+				if c < 0 {
+					panic("dijkstra: negative turn cost")
+				}
+				w += c
+			}
+			if math.IsInf(w, 1) {
+				continue
+			}
+			d := top.dist + w
+			key := [2]int64{u.ID(), v.ID()}
+			j, ok := indexOf[key]
+			if !ok {
+				j = len(states)
+				indexOf[key] = j
+				states = append(states, state{prev: u, cur: v, parent: -1, dist: math.Inf(1)})
+				// Re-establish st since states may have
+				// been reallocated by the append.
+				st = &states[top.state]
+			}
+			if d < states[j].dist {
+				states[j].dist = d
+				states[j].parent = top.state
+				heap.Push(&q, turnItem{state: j, dist: d})
+			}
+		}
+	}
+	return nil, math.Inf(1)
+}
+
+// turnItem is a search state with its distance from the start.
+type turnItem struct {
+	state int
+	dist  float64
+}
+
+// turnQueue implements a no-dec priority queue of search states.
+type turnQueue []turnItem
+
+func (q turnQueue) Len() int            { return len(q) }
+func (q turnQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q turnQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *turnQueue) Push(n interface{}) { *q = append(*q, n.(turnItem)) }
+func (q *turnQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
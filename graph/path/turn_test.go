@@ -0,0 +1,183 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDijkstraWithTurnPenalties(t *testing.T) {
+	// A junction at 1 where the turn 0→1→2 is banned,
+	// forcing the path around the block through 3 and 4.
+	//
+	//  0 - 1 - 2
+	//      |   |
+	//      3 - 4
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(4), T: simple.Node(2), W: 1},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	noUTurn := func(prev, cur, next graph.Node) float64 {
+		if prev.ID() == next.ID() {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	banned := func(prev, cur, next graph.Node) float64 {
+		if prev.ID() == 0 && cur.ID() == 1 && next.ID() == 2 {
+			return math.Inf(1)
+		}
+		return noUTurn(prev, cur, next)
+	}
+
+	for _, test := range []struct {
+		name string
+		turn func(prev, cur, next graph.Node) float64
+		want []int64
+		cost float64
+	}{
+		{name: "free", turn: noUTurn, want: []int64{0, 1, 2}, cost: 2},
+		{name: "banned", turn: banned, want: []int64{0, 1, 3, 4, 2}, cost: 4},
+	} {
+		p, cost := DijkstraWithTurnPenalties(g, simple.Node(0), simple.Node(2), test.turn)
+		if !equalIDs(nodeIDs(p), test.want) || cost != test.cost {
+			t.Errorf("unexpected result for %s: got:%v,%v want:%v,%v", test.name, nodeIDs(p), cost, test.want, test.cost)
+		}
+	}
+
+	// Banning the turns into 2 makes it unreachable from 0.
+	p, cost := DijkstraWithTurnPenalties(g, simple.Node(0), simple.Node(2), func(prev, cur, next graph.Node) float64 {
+		if next.ID() == 2 {
+			return math.Inf(1)
+		}
+		return 0
+	})
+	if p != nil || !math.IsInf(cost, 1) {
+		t.Errorf("unexpected result for unreachable target: got:%v,%v want:[],+Inf", nodeIDs(p), cost)
+	}
+
+	p, cost = DijkstraWithTurnPenalties(g, simple.Node(3), simple.Node(3), banned)
+	if !equalIDs(nodeIDs(p), []int64{3}) || cost != 0 {
+		t.Errorf("unexpected result for s = t: got:%v,%v want:[3],0", nodeIDs(p), cost)
+	}
+}
+
+func TestDijkstraWithTurnPenaltiesRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(4)
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.5 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(5))})
+				}
+			}
+		}
+		turns := make(map[[3]int64]float64)
+		turn := func(prev, cur, next graph.Node) float64 {
+			key := [3]int64{prev.ID(), cur.ID(), next.ID()}
+			c, ok := turns[key]
+			if !ok {
+				c = float64(rnd.Intn(4))
+				if rnd.Float64() < 0.3 {
+					c = math.Inf(1)
+				}
+				turns[key] = c
+			}
+			return c
+		}
+		// Fix all turn costs before searching.
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				for _, w := range graph.NodesOf(g.From(v.ID())) {
+					turn(u, v, w)
+				}
+			}
+		}
+
+		s, tn := simple.Node(0), simple.Node(n-1)
+		want := bruteForceTurns(g, s, tn, turn)
+		p, got := DijkstraWithTurnPenalties(g, s, tn, turn)
+		if got != want {
+			t.Errorf("unexpected cost for test %d: got:%v want:%v", i, got, want)
+			continue
+		}
+		if math.IsInf(got, 1) {
+			if p != nil {
+				t.Errorf("unexpected path for unreachable target in test %d: %v", i, nodeIDs(p))
+			}
+			continue
+		}
+		if p[0].ID() != s.ID() || p[len(p)-1].ID() != tn.ID() {
+			t.Errorf("path does not join s and t in test %d: %v", i, nodeIDs(p))
+			continue
+		}
+		var cost float64
+		for k := 1; k < len(p); k++ {
+			w, ok := g.Weight(p[k-1].ID(), p[k].ID())
+			if !ok {
+				t.Errorf("path uses missing edge in test %d: %v", i, nodeIDs(p))
+			}
+			cost += w
+			if k > 1 {
+				cost += turn(p[k-2], p[k-1], p[k])
+			}
+		}
+		if cost != got {
+			t.Errorf("cost of path does not match for test %d: got:%v want:%v", i, cost, got)
+		}
+	}
+}
+
+// bruteForceTurns returns the cost of the cheapest walk from s to t in g
+// that does not repeat an edge, including turn costs.
+func bruteForceTurns(g graph.WeightedDirected, s, t graph.Node, turn func(prev, cur, next graph.Node) float64) float64 {
+	if s.ID() == t.ID() {
+		return 0
+	}
+	best := math.Inf(1)
+	used := make(map[[2]int64]bool)
+	var walk func(prev, cur graph.Node, cost float64)
+	walk = func(prev, cur graph.Node, cost float64) {
+		for _, next := range graph.NodesOf(g.From(cur.ID())) {
+			e := [2]int64{cur.ID(), next.ID()}
+			if used[e] {
+				continue
+			}
+			w, _ := g.Weight(cur.ID(), next.ID())
+			c := cost + w
+			if prev != nil {
+				c += turn(prev, cur, next)
+			}
+			if math.IsInf(c, 1) {
+				continue
+			}
+			if next.ID() == t.ID() {
+				best = math.Min(best, c)
+			}
+			used[e] = true
+			walk(cur, next, c)
+			used[e] = false
+		}
+	}
+	walk(nil, s, 0)
+	return best
+}
@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package predict
+
+import (
+	"container/heap"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// TopKLinks returns the k highest scoring candidate links of the undirected
+// graph g, pairs of distinct nodes that are not joined by an edge, scored by
+// the provided scorer. Only pairs of nodes that share a common neighbour are
+// considered, so the cost of the search depends on the number of paths of
+// length two in g rather than the square of the number of nodes.
+//
+// The returned pairs are ordered by descending score, with ties broken by
+// ascending ID of the first and then the second node of each pair. The first
+// node of each pair has the lower ID. Fewer than k pairs are returned if g
+// has fewer than k candidate links.
+func TopKLinks(g graph.Undirected, scorer func(u, v graph.Node) float64, k int) [][2]graph.Node {
+	if k <= 0 {
+		return nil
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	var h linkHeap
+	for _, u := range nodes {
+		uid := u.ID()
+		seen := make(set.Int64s)
+		var candidates []graph.Node
+		for _, w := range graph.NodesOf(g.From(uid)) {
+			if w.ID() == uid {
+				continue
+			}
+			for _, v := range graph.NodesOf(g.From(w.ID())) {
+				vid := v.ID()
+				if vid <= uid || seen.Has(vid) || g.HasEdgeBetween(uid, vid) {
+					continue
+				}
+				seen.Add(vid)
+				candidates = append(candidates, v)
+			}
+		}
+		sort.Sort(ordered.ByID(candidates))
+		for _, v := range candidates {
+			l := link{u: u, v: v, score: scorer(u, v)}
+			if len(h) < k {
+				heap.Push(&h, l)
+			} else if h[0].worse(l) {
+				h[0] = l
+				heap.Fix(&h, 0)
+			}
+		}
+	}
+
+	links := make([][2]graph.Node, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		l := heap.Pop(&h).(link)
+		links[i] = [2]graph.Node{l.u, l.v}
+	}
+	return links
+}
+
+// link is a scored candidate link.
+type link struct {
+	u, v  graph.Node
+	score float64
+}
+
+// worse returns whether l ranks below m.
+func (l link) worse(m link) bool {
+	if l.score != m.score {
+		return l.score < m.score
+	}
+	if l.u.ID() != m.u.ID() {
+		return l.u.ID() > m.u.ID()
+	}
+	return l.v.ID() > m.v.ID()
+}
+
+// linkHeap is a min-heap of links with the worst ranked link at its root.
+type linkHeap []link
+
+func (h linkHeap) Len() int            { return len(h) }
+func (h linkHeap) Less(i, j int) bool  { return h[i].worse(h[j]) }
+func (h linkHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *linkHeap) Push(x interface{}) { *h = append(*h, x.(link)) }
+func (h *linkHeap) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	l := old[n]
+	*h = old[:n]
+	return l
+}
@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package predict
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTopKLinks(t *testing.T) {
+	// A square 0-1-2-3 with a diagonal 1-3 and a pendant 4 on 2.
+	//
+	//  0 - 1
+	//  |   | \
+	//  3 - 2 - 4
+	//
+	// with the edge 1-3.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {1, 3}, {2, 4}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	scorer := commonNeighbours(g)
+
+	got := linkIDs(TopKLinks(g, scorer, 2))
+	if want := "[[0 2] [1 4]]"; got != want {
+		t.Errorf("unexpected links: got:%s want:%s", got, want)
+	}
+	got = linkIDs(TopKLinks(g, scorer, 10))
+	if want := "[[0 2] [1 4] [3 4]]"; got != want {
+		t.Errorf("unexpected links: got:%s want:%s", got, want)
+	}
+	if links := TopKLinks(g, scorer, 0); links != nil {
+		t.Errorf("unexpected links for k=0: %s", linkIDs(links))
+	}
+}
+
+func TestTopKLinksRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 2 + rnd.Intn(20)
+		g := simple.NewUndirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.2 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+		scorer := commonNeighbours(g)
+
+		var all []scored
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if g.HasEdgeBetween(int64(u), int64(v)) {
+					continue
+				}
+				s := scorer(simple.Node(u), simple.Node(v))
+				if s == 0 {
+					continue
+				}
+				all = append(all, scored{u: int64(u), v: int64(v), score: s})
+			}
+		}
+		sort.Sort(byScore(all))
+
+		k := rnd.Intn(10)
+		if k < len(all) {
+			all = all[:k]
+		}
+		want := make([][2]int64, len(all))
+		for i, s := range all {
+			want[i] = [2]int64{s.u, s.v}
+		}
+		got := linkIDs(TopKLinks(g, scorer, k))
+		if k == 0 {
+			if got != "[]" {
+				t.Errorf("unexpected links for k=0 test %d: %s", test, got)
+			}
+			continue
+		}
+		if w := fmt.Sprint(want); got != w {
+			t.Errorf("unexpected links for test %d: got:%s want:%s", test, got, w)
+		}
+	}
+}
+
+// commonNeighbours returns a scorer counting the common
+// neighbours of two nodes in g.
+func commonNeighbours(g graph.Undirected) func(u, v graph.Node) float64 {
+	return func(u, v graph.Node) float64 {
+		var n float64
+		for _, w := range graph.NodesOf(g.From(u.ID())) {
+			if g.HasEdgeBetween(w.ID(), v.ID()) {
+				n++
+			}
+		}
+		return n
+	}
+}
+
+type scored struct {
+	u, v  int64
+	score float64
+}
+
+type byScore []scored
+
+func (s byScore) Len() int { return len(s) }
+func (s byScore) Less(i, j int) bool {
+	if s[i].score != s[j].score {
+		return s[i].score > s[j].score
+	}
+	if s[i].u != s[j].u {
+		return s[i].u < s[j].u
+	}
+	return s[i].v < s[j].v
+}
+func (s byScore) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func linkIDs(links [][2]graph.Node) string {
+	ids := make([][2]int64, len(links))
+	for i, l := range links {
+		ids[i] = [2]int64{l[0].ID(), l[1].ID()}
+	}
+	return fmt.Sprint(ids)
+}
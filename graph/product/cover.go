@@ -0,0 +1,54 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// BipartiteDoubleCover returns the bipartite double cover of g, the tensor
+// product of g with the complete graph K2. Each node u of g has two copies in
+// the cover, a left copy and a right copy, and each edge {u, v} of g is
+// represented by the edges {u_left, v_right} and {v_left, u_right}. A self
+// loop on u in g is represented by the edge {u_left, u_right}.
+//
+// The leftOf and rightOf maps hold the IDs of the left and right copies of
+// each node of g, keyed by the ID of the node. If the nodes of g ordered by
+// ascending ID are u_0, ..., u_{n-1}, the left copy of u_i has ID i and the
+// right copy has ID n+i.
+//
+// The double cover is connected if and only if g is connected and not
+// bipartite, and is two disjoint copies of g if g is bipartite.
+func BipartiteDoubleCover(g graph.Undirected) (cover graph.Undirected, leftOf, rightOf map[int64]int64) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := int64(len(nodes))
+
+	c := simple.NewUndirectedGraph()
+	leftOf = make(map[int64]int64, len(nodes))
+	rightOf = make(map[int64]int64, len(nodes))
+	for i, u := range nodes {
+		l, r := int64(i), n+int64(i)
+		leftOf[u.ID()] = l
+		rightOf[u.ID()] = r
+		c.AddNode(simple.Node(l))
+	}
+	for _, u := range nodes {
+		c.AddNode(simple.Node(rightOf[u.ID()]))
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			c.SetEdge(simple.Edge{F: c.Node(leftOf[uid]), T: c.Node(rightOf[vid])})
+		}
+	}
+	return c, leftOf, rightOf
+}
@@ -0,0 +1,113 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestBipartiteDoubleCover(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := 1 + rnd.Intn(10)
+		g := simple.NewUndirectedGraph()
+		for j := 0; j < n; j++ {
+			// Use sparse IDs to check the mapping.
+			g.AddNode(simple.Node(3 * j))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					g.SetEdge(simple.Edge{F: simple.Node(3 * u), T: simple.Node(3 * v)})
+				}
+			}
+		}
+
+		cover, leftOf, rightOf := BipartiteDoubleCover(g)
+		if got := cover.Nodes().Len(); got != 2*n {
+			t.Errorf("unexpected number of nodes in cover: got:%d want:%d", got, 2*n)
+		}
+		left := make(map[int64]int64)
+		right := make(map[int64]int64)
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			l, r := leftOf[u.ID()], rightOf[u.ID()]
+			if cover.Node(l) == nil || cover.Node(r) == nil || l == r {
+				t.Fatalf("invalid copies of node %d: left:%d right:%d", u.ID(), l, r)
+			}
+			left[l] = u.ID()
+			right[r] = u.ID()
+		}
+		if len(left) != n || len(right) != n {
+			t.Fatalf("copies are not distinct")
+		}
+
+		// Every edge of the cover joins a left copy to a right
+		// copy of nodes that are adjacent in g, and the cover
+		// has twice as many edges as g.
+		var edges int
+		for _, u := range graph.NodesOf(cover.Nodes()) {
+			for _, v := range graph.NodesOf(cover.From(u.ID())) {
+				edges++
+				lu, uLeft := left[u.ID()]
+				rv, vRight := right[v.ID()]
+				if !uLeft {
+					lu, uLeft = left[v.ID()]
+					rv, vRight = right[u.ID()]
+				}
+				if !uLeft || !vRight {
+					t.Errorf("cover edge %d-%d does not join left and right copies", u.ID(), v.ID())
+					continue
+				}
+				if !g.HasEdgeBetween(lu, rv) {
+					t.Errorf("cover edge %d-%d has no corresponding edge in g", u.ID(), v.ID())
+				}
+			}
+		}
+		if want := 4 * len(graph.EdgesOf(g.Edges())); edges != want {
+			t.Errorf("unexpected number of edge ends in cover: got:%d want:%d", edges, want)
+		}
+
+		connected := len(topo.ConnectedComponents(g)) == 1
+		coverConnected := len(topo.ConnectedComponents(cover)) == 1
+		if want := connected && !isBipartite(g); coverConnected != want {
+			t.Errorf("unexpected connectivity of cover: got:%t want:%t", coverConnected, want)
+		}
+		if got, want := len(topo.ConnectedComponents(cover)), 2*len(topo.ConnectedComponents(g)); isBipartite(g) && got != want {
+			t.Errorf("unexpected number of components in cover of bipartite graph: got:%d want:%d", got, want)
+		}
+	}
+}
+
+// isBipartite returns whether g can be two-colored.
+func isBipartite(g graph.Undirected) bool {
+	color := make(map[int64]int)
+	for _, s := range graph.NodesOf(g.Nodes()) {
+		if _, ok := color[s.ID()]; ok {
+			continue
+		}
+		color[s.ID()] = 0
+		queue := []int64{s.ID()}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range graph.NodesOf(g.From(u)) {
+				c, ok := color[v.ID()]
+				if !ok {
+					color[v.ID()] = 1 - color[u]
+					queue = append(queue, v.ID())
+				} else if c == color[u] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Node is a node of a graph product. It holds the nodes of the
+// two factor graphs that it is constructed from.
+type Node struct {
+	UID int64
+
+	// G and H are the nodes of the
+	// first and second factor graphs.
+	G, H graph.Node
+}
+
+// ID returns the ID of the node.
+func (n Node) ID() int64 { return n.UID }
+
+// Factors returns the nodes of the factor graphs that the product graph
+// node n was constructed from. Factors will panic if n is not a Node.
+func Factors(n graph.Node) (g, h graph.Node) {
+	p := n.(Node)
+	return p.G, p.H
+}
+
+// Cartesian returns the Cartesian product of g and h. Nodes (u, x) and
+// (v, y) are adjacent in the product if u and v are the same node and x
+// and y are adjacent in h, or if x and y are the same node and u and v are
+// adjacent in g. The Cartesian product of two paths is a grid and of two
+// cycles is a torus.
+//
+// The nodes of the product are of type Node. If g and h have m and n nodes
+// and the nodes of each ordered by ascending ID are u_0, ..., u_{m-1} and
+// x_0, ..., x_{n-1}, the product node of u_i and x_j has ID i*n+j.
+func Cartesian(g, h graph.Undirected) graph.Undirected {
+	return newProduct(g, h, true, false)
+}
+
+// Tensor returns the tensor product of g and h, also known as the direct
+// or Kronecker product. Nodes (u, x) and (v, y) are adjacent in the product
+// if u and v are adjacent in g and x and y are adjacent in h.
+//
+// The nodes of the product are numbered as described for Cartesian.
+func Tensor(g, h graph.Undirected) graph.Undirected {
+	return newProduct(g, h, false, true)
+}
+
+// Strong returns the strong product of g and h, the union of the Cartesian
+// and tensor products of g and h. The strong product of two complete graphs
+// is a complete graph.
+//
+// The nodes of the product are numbered as described for Cartesian.
+func Strong(g, h graph.Undirected) graph.Undirected {
+	return newProduct(g, h, true, true)
+}
+
+// newProduct returns the product of g and h with the edges of the
+// Cartesian product and the edges of the tensor product as requested.
+func newProduct(g, h graph.Undirected, cartesian, tensor bool) graph.Undirected {
+	gNodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(gNodes))
+	hNodes := graph.NodesOf(h.Nodes())
+	sort.Sort(ordered.ByID(hNodes))
+	gIndex := indexOf(gNodes)
+	hIndex := indexOf(hNodes)
+	n := int64(len(hNodes))
+
+	p := simple.NewUndirectedGraph()
+	nodes := make([]graph.Node, len(gNodes)*len(hNodes))
+	for i, u := range gNodes {
+		for j, x := range hNodes {
+			id := int64(i)*n + int64(j)
+			nodes[id] = Node{UID: id, G: u, H: x}
+			p.AddNode(nodes[id])
+		}
+	}
+	for i, u := range gNodes {
+		gAdj := neighbors(g, u, gIndex)
+		for j, x := range hNodes {
+			hAdj := neighbors(h, x, hIndex)
+			uid := int64(i)*n + int64(j)
+			if cartesian {
+				for _, l := range hAdj {
+					if vid := int64(i)*n + l; vid > uid {
+						p.SetEdge(simple.Edge{F: nodes[uid], T: nodes[vid]})
+					}
+				}
+				for _, k := range gAdj {
+					if vid := k*n + int64(j); vid > uid {
+						p.SetEdge(simple.Edge{F: nodes[uid], T: nodes[vid]})
+					}
+				}
+			}
+			if tensor {
+				for _, k := range gAdj {
+					for _, l := range hAdj {
+						if vid := k*n + l; vid > uid {
+							p.SetEdge(simple.Edge{F: nodes[uid], T: nodes[vid]})
+						}
+					}
+				}
+			}
+		}
+	}
+	return p
+}
+
+// indexOf returns a map from node ID to position in nodes.
+func indexOf(nodes []graph.Node) map[int64]int64 {
+	idx := make(map[int64]int64, len(nodes))
+	for i, n := range nodes {
+		idx[n.ID()] = int64(i)
+	}
+	return idx
+}
+
+// neighbors returns the indexes of the nodes adjacent to u in g,
+// excluding u itself.
+func neighbors(g graph.Undirected, u graph.Node, index map[int64]int64) []int64 {
+	var adj []int64
+	to := g.From(u.ID())
+	for to.Next() {
+		v := to.Node()
+		if v.ID() != u.ID() {
+			adj = append(adj, index[v.ID()])
+		}
+	}
+	return adj
+}
@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package product
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var productTests = []struct {
+	name     string
+	fn       func(g, h graph.Undirected) graph.Undirected
+	adjacent func(gEq, gAdj, hEq, hAdj bool) bool
+}{
+	{
+		name: "Cartesian",
+		fn:   Cartesian,
+		adjacent: func(gEq, gAdj, hEq, hAdj bool) bool {
+			return (gEq && hAdj) || (hEq && gAdj)
+		},
+	},
+	{
+		name: "Tensor",
+		fn:   Tensor,
+		adjacent: func(gEq, gAdj, hEq, hAdj bool) bool {
+			return gAdj && hAdj
+		},
+	},
+	{
+		name: "Strong",
+		fn:   Strong,
+		adjacent: func(gEq, gAdj, hEq, hAdj bool) bool {
+			return (gEq && hAdj) || (hEq && gAdj) || (gAdj && hAdj)
+		},
+	},
+}
+
+func TestProduct(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		g := randomGraph(rnd, 1+rnd.Intn(6), 2)
+		h := randomGraph(rnd, 1+rnd.Intn(6), 5)
+		for _, test := range productTests {
+			p := test.fn(g, h)
+			gNodes := graph.NodesOf(g.Nodes())
+			hNodes := graph.NodesOf(h.Nodes())
+			if got, want := p.Nodes().Len(), len(gNodes)*len(hNodes); got != want {
+				t.Errorf("unexpected number of %s product nodes: got:%d want:%d", test.name, got, want)
+			}
+			pNodes := graph.NodesOf(p.Nodes())
+			seen := make(map[[2]int64]bool)
+			for _, a := range pNodes {
+				u, x := Factors(a)
+				if seen[[2]int64{u.ID(), x.ID()}] {
+					t.Errorf("duplicate %s product node for (%d, %d)", test.name, u.ID(), x.ID())
+				}
+				seen[[2]int64{u.ID(), x.ID()}] = true
+				if g.Node(u.ID()) == nil || h.Node(x.ID()) == nil {
+					t.Errorf("%s product node %d has factors not in g and h", test.name, a.ID())
+				}
+				for _, b := range pNodes {
+					if a.ID() == b.ID() {
+						continue
+					}
+					v, y := Factors(b)
+					want := test.adjacent(
+						u.ID() == v.ID(), g.HasEdgeBetween(u.ID(), v.ID()),
+						x.ID() == y.ID(), h.HasEdgeBetween(x.ID(), y.ID()),
+					)
+					if got := p.HasEdgeBetween(a.ID(), b.ID()); got != want {
+						t.Errorf("unexpected %s product adjacency of (%d, %d) and (%d, %d): got:%t want:%t",
+							test.name, u.ID(), x.ID(), v.ID(), y.ID(), got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestProductIDs(t *testing.T) {
+	g := path(3)
+	h := path(4)
+	p := Cartesian(g, h)
+	for i := int64(0); i < 3; i++ {
+		for j := int64(0); j < 4; j++ {
+			u, x := Factors(p.Node(i*4 + j))
+			if u.ID() != 2*i || x.ID() != 2*j {
+				t.Errorf("unexpected factors of %d: got:(%d, %d) want:(%d, %d)", i*4+j, u.ID(), x.ID(), 2*i, 2*j)
+			}
+		}
+	}
+
+	// The Cartesian product of two paths is a grid.
+	var corners, sides, inner int
+	for _, n := range graph.NodesOf(p.Nodes()) {
+		switch p.From(n.ID()).Len() {
+		case 2:
+			corners++
+		case 3:
+			sides++
+		case 4:
+			inner++
+		}
+	}
+	if corners != 4 || sides != 6 || inner != 2 {
+		t.Errorf("unexpected grid degrees: got corners:%d sides:%d inner:%d want corners:4 sides:6 inner:2", corners, sides, inner)
+	}
+}
+
+// path returns a path graph with n nodes with IDs 0, 2, ..., 2n-2.
+func path(n int) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	for i := 1; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(2 * (i - 1)), T: simple.Node(2 * i)})
+	}
+	return g
+}
+
+// randomGraph returns a random graph with n nodes with IDs that
+// are multiples of stride.
+func randomGraph(rnd *rand.Rand, n, stride int) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(stride * i))
+	}
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			if rnd.Float64() < 0.4 {
+				g.SetEdge(simple.Edge{F: simple.Node(stride * u), T: simple.Node(stride * v)})
+			}
+		}
+	}
+	return g
+}
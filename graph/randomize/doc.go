@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package randomize provides randomizations of graphs for constructing
+// null models.
+package randomize // import "gonum.org/v1/gonum/graph/randomize"
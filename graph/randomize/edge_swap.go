@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package randomize
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// EdgeSwap returns a randomized copy of g with the same nodes and the same
+// degree sequence, obtained by attempting numSwaps double edge swaps. Each
+// attempt picks two distinct edges a-b and c-d uniformly at random and
+// rewires them to a-c and b-d. An attempt that would create a self loop or
+// a parallel edge is rejected and leaves the graph unchanged, so the number
+// of swaps performed may be less than numSwaps.
+//
+// The returned graph is a *simple.UndirectedGraph holding the nodes of g.
+// If src is nil, rand.Intn is used as the random number generator,
+// otherwise the result is deterministic for a given src. EdgeSwap will
+// panic if g has a self loop or numSwaps is negative.
+func EdgeSwap(g graph.Undirected, numSwaps int, src rand.Source) graph.Undirected {
+	if numSwaps < 0 {
+		panic("randomize: negative number of swaps")
+	}
+
+	var rnd func(int) int
+	if src == nil {
+		rnd = rand.Intn
+	} else {
+		rnd = rand.New(src).Intn
+	}
+
+	dst := simple.NewUndirectedGraph()
+	nodes := graph.NodesOf(g.Nodes())
+	for _, n := range nodes {
+		dst.AddNode(n)
+	}
+	var edges []edge
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid == uid {
+				panic("randomize: graph has self loop")
+			}
+			if vid < uid {
+				continue
+			}
+			dst.SetEdge(simple.Edge{F: u, T: v})
+			edges = append(edges, edge{u: u, v: v})
+		}
+	}
+	if len(edges) < 2 {
+		return dst
+	}
+	// Order the edges so that the result for a given
+	// src does not depend on the iteration order of g.
+	sort.Sort(byIDs(edges))
+
+	for k := 0; k < numSwaps; k++ {
+		i := rnd(len(edges))
+		j := rnd(len(edges) - 1)
+		if j >= i {
+			j++
+		}
+		a, b := edges[i].u, edges[i].v
+		c, d := edges[j].u, edges[j].v
+		if rnd(2) == 0 {
+			c, d = d, c
+		}
+		aid, bid, cid, did := a.ID(), b.ID(), c.ID(), d.ID()
+		if aid == cid || bid == did {
+			continue
+		}
+		if dst.HasEdgeBetween(aid, cid) || dst.HasEdgeBetween(bid, did) {
+			continue
+		}
+		dst.RemoveEdge(aid, bid)
+		dst.RemoveEdge(cid, did)
+		dst.SetEdge(simple.Edge{F: a, T: c})
+		dst.SetEdge(simple.Edge{F: b, T: d})
+		edges[i] = edge{u: a, v: c}
+		edges[j] = edge{u: b, v: d}
+	}
+	return dst
+}
+
+// edge is an undirected edge between u and v.
+type edge struct {
+	u, v graph.Node
+}
+
+// byIDs sorts edges by the IDs of their lower and then higher end nodes.
+type byIDs []edge
+
+func (e byIDs) Len() int { return len(e) }
+func (e byIDs) Less(i, j int) bool {
+	if e[i].u.ID() != e[j].u.ID() {
+		return e[i].u.ID() < e[j].u.ID()
+	}
+	return e[i].v.ID() < e[j].v.ID()
+}
+func (e byIDs) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
@@ -0,0 +1,86 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package randomize
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestEdgeSwap(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 12; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	// A ring with chords, with a range of node degrees.
+	for i := 0; i < 12; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % 12)})
+	}
+	for _, e := range [][2]int64{{0, 4}, {0, 6}, {0, 8}, {2, 7}, {3, 9}, {5, 10}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(20))
+
+	for seed := uint64(1); seed <= 5; seed++ {
+		got := EdgeSwap(g, 100, rand.NewSource(seed))
+
+		if n := got.Nodes().Len(); n != g.Nodes().Len() {
+			t.Errorf("unexpected number of nodes for seed %d: got:%d want:%d", seed, n, g.Nodes().Len())
+		}
+		for _, n := range graph.NodesOf(g.Nodes()) {
+			id := n.ID()
+			if got.Node(id) != n {
+				t.Errorf("node identity not preserved for seed %d: node %d", seed, id)
+			}
+			if d, want := got.From(id).Len(), g.From(id).Len(); d != want {
+				t.Errorf("unexpected degree of node %d for seed %d: got:%d want:%d", id, seed, d, want)
+			}
+			if got.HasEdgeBetween(id, id) {
+				t.Errorf("unexpected self loop at node %d for seed %d", id, seed)
+			}
+		}
+
+		again := EdgeSwap(g, 100, rand.NewSource(seed))
+		if edges(got) != edges(again) {
+			t.Errorf("result not deterministic for seed %d:\ngot: %s\nwant:%s", seed, edges(again), edges(got))
+		}
+		if edges(got) == edges(g) {
+			t.Errorf("graph unchanged for seed %d", seed)
+		}
+	}
+
+	if got := EdgeSwap(g, 0, rand.NewSource(1)); edges(got) != edges(g) {
+		t.Errorf("unexpected change with no swaps: got:%s want:%s", edges(got), edges(g))
+	}
+
+	// A star has no valid swaps.
+	star := simple.NewUndirectedGraph()
+	for i := 1; i < 5; i++ {
+		star.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(i)})
+	}
+	if got := EdgeSwap(star, 10, rand.NewSource(1)); edges(got) != edges(star) {
+		t.Errorf("unexpected change of star: got:%s want:%s", edges(got), edges(star))
+	}
+}
+
+// edges returns a description of the edges of g.
+func edges(g graph.Undirected) string {
+	var e []string
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if u.ID() < v.ID() {
+				e = append(e, fmt.Sprintf("%d-%d", u.ID(), v.ID()))
+			}
+		}
+	}
+	sort.Strings(e)
+	return fmt.Sprint(e)
+}
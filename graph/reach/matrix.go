@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reach
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// BitMatrix is a square matrix of bits packed into rows of 64-bit words.
+type BitMatrix struct {
+	n      int
+	stride int
+	bits   []uint64
+}
+
+// newBitMatrix returns an n×n BitMatrix with all bits clear.
+func newBitMatrix(n int) *BitMatrix {
+	stride := (n + 63) / 64
+	return &BitMatrix{n: n, stride: stride, bits: make([]uint64, n*stride)}
+}
+
+// Len returns the number of rows and columns of m.
+func (m *BitMatrix) Len() int { return m.n }
+
+// Reaches returns whether the bit at row i and column j of m is set. For a
+// BitMatrix returned by Matrix, this is whether there is a path from the
+// node with index i to the node with index j. Reaches will panic if i or j
+// is out of range.
+func (m *BitMatrix) Reaches(i, j int) bool {
+	if uint(i) >= uint(m.n) || uint(j) >= uint(m.n) {
+		panic("reach: index out of range")
+	}
+	return m.bits[i*m.stride+j/64]&(1<<uint(j%64)) != 0
+}
+
+// row returns the words of row i of m.
+func (m *BitMatrix) row(i int) []uint64 {
+	return m.bits[i*m.stride : (i+1)*m.stride]
+}
+
+// set sets the bit at row i and column j of m.
+func (m *BitMatrix) set(i, j int) {
+	m.bits[i*m.stride+j/64] |= 1 << uint(j%64)
+}
+
+// Matrix returns the reachability matrix of the directed graph g, the
+// adjacency matrix of its transitive closure, and the nodes of g in the
+// order of the rows and columns of the matrix, which is ordered by node ID.
+// The bit at (i, j) is set when there is a path of at least one edge from
+// nodes[i] to nodes[j], so a node reaches itself only if it is on a cycle.
+//
+// The matrix is built by condensing the strongly connected components of g
+// and propagating the rows of the components in reverse topological order,
+// forming the row of each component as the union of the rows of the
+// components it has edges to, so each row union operates a word at a time.
+func Matrix(g graph.Directed) (*BitMatrix, []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// The components are returned by TarjanSCC in reverse
+	// topological order, so every component reachable from
+	// a component is completed before it.
+	sccs := topo.TarjanSCC(g)
+	sccOf := make([]int, n)
+	for c, scc := range sccs {
+		for _, u := range scc {
+			sccOf[indexOf[u.ID()]] = c
+		}
+	}
+
+	m := newBitMatrix(n)
+	for c, scc := range sccs {
+		// Collect the row of the component into the
+		// row of its first member.
+		first := indexOf[scc[0].ID()]
+		row := m.row(first)
+		cyclic := len(scc) > 1
+		for _, u := range scc {
+			uid := u.ID()
+			to := g.From(uid)
+			for to.Next() {
+				vid := to.Node().ID()
+				j := indexOf[vid]
+				d := sccOf[j]
+				if d == c {
+					cyclic = true
+					continue
+				}
+				m.set(first, j)
+				for k, w := range m.row(indexOf[sccs[d][0].ID()]) {
+					row[k] |= w
+				}
+			}
+		}
+		if cyclic {
+			for _, u := range scc {
+				m.set(first, indexOf[u.ID()])
+			}
+		}
+		for _, u := range scc[1:] {
+			copy(m.row(indexOf[u.ID()]), row)
+		}
+	}
+	return m, nodes
+}
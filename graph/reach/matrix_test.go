@@ -0,0 +1,113 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package reach
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMatrix(t *testing.T) {
+	// A cycle 0→1→2→0 leading to a chain 2→3→4, with a self
+	// edge on 4 and an isolated node 5.
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(5))
+	// Self edges are not allowed by simple.DirectedGraph,
+	// so use a graph that allows them.
+	sg := selfLoopGraph{DirectedGraph: g, loops: map[int64]bool{4: true}}
+
+	m, nodes := Matrix(sg)
+	if m.Len() != 6 || len(nodes) != 6 {
+		t.Fatalf("unexpected size: got:%d nodes:%d want:6", m.Len(), len(nodes))
+	}
+	want := [6][6]bool{
+		{true, true, true, true, true, false},
+		{true, true, true, true, true, false},
+		{true, true, true, true, true, false},
+		{false, false, false, false, true, false},
+		{false, false, false, false, true, false},
+		{false, false, false, false, false, false},
+	}
+	for i, row := range want {
+		for j, w := range row {
+			if got := m.Reaches(i, j); got != w {
+				t.Errorf("unexpected reachability from %d to %d: got:%t want:%t", i, j, got, w)
+			}
+		}
+	}
+}
+
+func TestMatrixRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 1 + rnd.Intn(150)
+		p := 2 / float64(n)
+		g := simple.NewDirectedGraph()
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < p {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		m, nodes := Matrix(g)
+		for i, u := range nodes {
+			want := reachableFrom(g, u)
+			for j, v := range nodes {
+				if got := m.Reaches(i, j); got != want[v.ID()] {
+					t.Errorf("unexpected reachability from %d to %d for test %d: got:%t want:%t", u.ID(), v.ID(), test, got, want[v.ID()])
+				}
+			}
+		}
+	}
+}
+
+// reachableFrom returns the nodes reachable from u by a path of at
+// least one edge.
+func reachableFrom(g graph.Directed, u graph.Node) map[int64]bool {
+	seen := make(map[int64]bool)
+	stack := []graph.Node{u}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range graph.NodesOf(g.From(v.ID())) {
+			if !seen[w.ID()] {
+				seen[w.ID()] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+	return seen
+}
+
+// selfLoopGraph is a directed graph with additional self edges.
+type selfLoopGraph struct {
+	*simple.DirectedGraph
+	loops map[int64]bool
+}
+
+func (g selfLoopGraph) From(id int64) graph.Nodes {
+	nodes := graph.NodesOf(g.DirectedGraph.From(id))
+	if g.loops[id] {
+		nodes = append(nodes, g.Node(id))
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+func (g selfLoopGraph) HasEdgeFromTo(uid, vid int64) bool {
+	return (uid == vid && g.loops[uid]) || g.DirectedGraph.HasEdgeFromTo(uid, vid)
+}
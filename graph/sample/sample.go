@@ -0,0 +1,247 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sample
+
+import (
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/internal/weights"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// RandomNodes returns the subgraph of g induced by k nodes of g chosen
+// uniformly at random without replacement. If k is greater than the
+// number of nodes in g, all nodes are chosen.
+//
+// If src is not nil it is used as the random source, otherwise rand.Intn
+// is used. For a given src and g the returned sample is deterministic.
+// See Induce for a description of the returned graph.
+func RandomNodes(g graph.Graph, k int, src rand.Source) graph.Graph {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	return Induce(g, choose(nodes, k, src))
+}
+
+// RandomEdges returns the subgraph of g induced by the end points of k
+// edges of g chosen uniformly at random without replacement. If k is
+// greater than the number of edges in g, all edges are chosen. Self edges
+// are not considered for sampling.
+//
+// Since the returned graph is induced, it may hold edges of g between the
+// chosen end points in addition to the chosen edges.
+//
+// If src is not nil it is used as the random source, otherwise rand.Intn
+// is used. For a given src and g the returned sample is deterministic.
+// See Induce for a description of the returned graph.
+func RandomEdges(g graph.Graph, k int, src rand.Source) graph.Graph {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	_, isDirected := g.(graph.Directed)
+	var edges [][2]graph.Node
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if vid == uid || (!isDirected && vid < uid) {
+				continue
+			}
+			edges = append(edges, [2]graph.Node{u, v})
+		}
+	}
+
+	if k > len(edges) {
+		k = len(edges)
+	}
+	if k < 0 {
+		k = 0
+	}
+	rnd := intn(src)
+	for i := 0; i < k; i++ {
+		j := i + rnd(len(edges)-i)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	seen := make(set.Int64s)
+	var chosen []graph.Node
+	for _, e := range edges[:k] {
+		for _, n := range e {
+			if !seen.Has(n.ID()) {
+				seen.Add(n.ID())
+				chosen = append(chosen, n)
+			}
+		}
+	}
+	return Induce(g, chosen)
+}
+
+// Snowball returns the subgraph of g induced by snowball sampling from the
+// given seed nodes. Starting from the seeds, each wave of the sample adds
+// up to k neighbors chosen uniformly at random from the nodes reachable
+// directly from each node added in the previous wave that are not already
+// in the sample. Sampling stops after depth waves, so every node in the
+// sample is at most depth hops from a seed. If k is not positive, all
+// neighbors are added in each wave. Seeds that are not in g are ignored.
+// The k parameter is needed for the sample to depend on src; when all
+// neighbors are added, the sample is the depth-bounded neighborhood of the
+// seeds and src is not used.
+//
+// If src is not nil it is used as the random source, otherwise rand.Intn
+// is used. For a given src, g and seeds the returned sample is deterministic.
+// See Induce for a description of the returned graph.
+func Snowball(g graph.Graph, seeds []graph.Node, depth, k int, src rand.Source) graph.Graph {
+	rnd := intn(src)
+	seen := make(set.Int64s)
+	var (
+		chosen []graph.Node
+		wave   []graph.Node
+	)
+	for _, s := range seeds {
+		n := g.Node(s.ID())
+		if n == nil || seen.Has(n.ID()) {
+			continue
+		}
+		seen.Add(n.ID())
+		chosen = append(chosen, n)
+		wave = append(wave, n)
+	}
+
+	var next, candidates []graph.Node
+	for d := 0; d < depth && len(wave) != 0; d++ {
+		next = next[:0]
+		for _, u := range wave {
+			candidates = candidates[:0]
+			to := g.From(u.ID())
+			for to.Next() {
+				v := to.Node()
+				if !seen.Has(v.ID()) {
+					candidates = append(candidates, v)
+				}
+			}
+			sort.Sort(ordered.ByID(candidates))
+			if k > 0 && k < len(candidates) {
+				for i := 0; i < k; i++ {
+					j := i + rnd(len(candidates)-i)
+					candidates[i], candidates[j] = candidates[j], candidates[i]
+				}
+				candidates = candidates[:k]
+			}
+			for _, v := range candidates {
+				seen.Add(v.ID())
+				chosen = append(chosen, v)
+				next = append(next, v)
+			}
+		}
+		wave, next = next, wave
+	}
+	return Induce(g, chosen)
+}
+
+// Induce returns the subgraph of g induced by the given nodes. The returned
+// graph is a *simple.DirectedGraph, *simple.UndirectedGraph,
+// *simple.WeightedDirectedGraph or *simple.WeightedUndirectedGraph according
+// to whether g is directed and whether g is weighted. The nodes and edges
+// of the returned graph are the values held by g, so node identities are
+// preserved. Self edges and parallel edges in g are not represented in the
+// returned graph. For weighted graphs, the self and absent weights of the
+// returned graph are obtained by querying g.
+//
+// Nodes that are not in g are ignored.
+func Induce(g graph.Graph, nodes []graph.Node) graph.Graph {
+	var in []graph.Node
+	for _, n := range nodes {
+		if gn := g.Node(n.ID()); gn != nil {
+			in = append(in, gn)
+		}
+	}
+
+	_, isDirected := g.(graph.Directed)
+	wg, isWeighted := g.(graph.Weighted)
+	var (
+		dst interface {
+			graph.Graph
+			graph.NodeAdder
+		}
+		setEdge func(uid, vid int64)
+	)
+	switch {
+	case isWeighted:
+		var wdst interface {
+			graph.Graph
+			graph.NodeAdder
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		self, absent := weights.Params(wg, in)
+		if isDirected {
+			wdst = simple.NewWeightedDirectedGraph(self, absent)
+		} else {
+			wdst = simple.NewWeightedUndirectedGraph(self, absent)
+		}
+		dst = wdst
+		setEdge = func(uid, vid int64) { wdst.SetWeightedEdge(wg.WeightedEdge(uid, vid)) }
+	default:
+		var udst interface {
+			graph.Graph
+			graph.NodeAdder
+			SetEdge(graph.Edge)
+		}
+		if isDirected {
+			udst = simple.NewDirectedGraph()
+		} else {
+			udst = simple.NewUndirectedGraph()
+		}
+		dst = udst
+		setEdge = func(uid, vid int64) { udst.SetEdge(g.Edge(uid, vid)) }
+	}
+
+	for _, n := range in {
+		if dst.Node(n.ID()) == nil {
+			dst.AddNode(n)
+		}
+	}
+	for _, u := range in {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid || dst.Node(vid) == nil {
+				continue
+			}
+			setEdge(uid, vid)
+		}
+	}
+	return dst
+}
+
+// choose returns k elements of nodes chosen uniformly at random without
+// replacement. The order of nodes is altered.
+func choose(nodes []graph.Node, k int, src rand.Source) []graph.Node {
+	if k > len(nodes) {
+		k = len(nodes)
+	}
+	if k < 0 {
+		k = 0
+	}
+	rnd := intn(src)
+	for i := 0; i < k; i++ {
+		j := i + rnd(len(nodes)-i)
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return nodes[:k]
+}
+
+// intn returns a function returning random integers in [0,n)
+// using src if it is not nil.
+func intn(src rand.Source) func(int) int {
+	if src == nil {
+		return rand.Intn
+	}
+	return rand.New(src).Intn
+}
@@ -0,0 +1,218 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sample
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// namedNode is a node type used to check that node
+// identities are preserved in samples.
+type namedNode struct {
+	id   int64
+	name string
+}
+
+func (n namedNode) ID() int64 { return n.id }
+
+func namedGraph(directed bool, n int, p float64, seed uint64) graph.Graph {
+	var topology, g interface {
+		graph.Builder
+		graph.Graph
+		Edges() graph.Edges
+	}
+	if directed {
+		topology = simple.NewDirectedGraph()
+		g = simple.NewDirectedGraph()
+	} else {
+		topology = simple.NewUndirectedGraph()
+		g = simple.NewUndirectedGraph()
+	}
+	err := gen.Gnp(topology, n, p, rand.NewSource(seed))
+	if err != nil {
+		panic(err)
+	}
+	nodes := topology.Nodes()
+	for nodes.Next() {
+		id := nodes.Node().ID()
+		g.AddNode(namedNode{id: id, name: fmt.Sprint("n", id)})
+	}
+	for _, e := range graph.EdgesOf(topology.Edges()) {
+		g.SetEdge(simple.Edge{F: g.Node(e.From().ID()), T: g.Node(e.To().ID())})
+	}
+	return g
+}
+
+// checkInduced checks that s is the subgraph of g induced by the
+// nodes of s and that the node values of s are those of g.
+func checkInduced(t *testing.T, name string, g, s graph.Graph) {
+	t.Helper()
+	nodes := graph.NodesOf(s.Nodes())
+	for _, u := range nodes {
+		if gn := g.Node(u.ID()); gn == nil || !reflect.DeepEqual(gn, u) {
+			t.Errorf("node %d not preserved in %s sample: got:%#v want:%#v", u.ID(), name, u, gn)
+		}
+		for _, v := range nodes {
+			if (g.Edge(u.ID(), v.ID()) != nil) != (s.Edge(u.ID(), v.ID()) != nil) {
+				t.Errorf("edge %d->%d not induced in %s sample", u.ID(), v.ID(), name)
+			}
+		}
+	}
+}
+
+func ids(g graph.Graph) []int64 {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
+
+func TestRandomNodes(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := namedGraph(directed, 50, 0.1, 1)
+		for _, k := range []int{0, 1, 10, 50, 100} {
+			s := RandomNodes(g, k, rand.NewSource(2))
+			want := k
+			if want > 50 {
+				want = 50
+			}
+			if got := s.Nodes().Len(); got != want {
+				t.Errorf("unexpected number of nodes for k=%d: got:%d want:%d", k, got, want)
+			}
+			_, isDirected := s.(graph.Directed)
+			if isDirected != directed {
+				t.Errorf("unexpected directedness for k=%d: got:%t want:%t", k, isDirected, directed)
+			}
+			checkInduced(t, "RandomNodes", g, s)
+
+			again := RandomNodes(g, k, rand.NewSource(2))
+			if !reflect.DeepEqual(ids(s), ids(again)) {
+				t.Errorf("sample not deterministic for k=%d", k)
+			}
+		}
+	}
+}
+
+func TestRandomEdges(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := namedGraph(directed, 50, 0.05, 1)
+		for _, k := range []int{-1, 0, 1, 10, 1000} {
+			s := RandomEdges(g, k, rand.NewSource(2))
+			n := s.Nodes().Len()
+			if k <= 0 && n != 0 {
+				t.Errorf("unexpected nodes for k=%d: got:%d", k, n)
+			}
+			if k > 0 && n > 2*k {
+				t.Errorf("too many nodes for k=%d: got:%d", k, n)
+			}
+			for _, u := range graph.NodesOf(s.Nodes()) {
+				if s.From(u.ID()).Len() == 0 && (!directed || s.(graph.Directed).To(u.ID()).Len() == 0) {
+					t.Errorf("isolated node %d in sample for k=%d", u.ID(), k)
+				}
+			}
+			checkInduced(t, "RandomEdges", g, s)
+
+			again := RandomEdges(g, k, rand.NewSource(2))
+			if !reflect.DeepEqual(ids(s), ids(again)) {
+				t.Errorf("sample not deterministic for k=%d", k)
+			}
+		}
+	}
+}
+
+func TestSnowball(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := namedGraph(directed, 100, 0.03, 1)
+		seeds := []graph.Node{simple.Node(0), simple.Node(50), simple.Node(1000)}
+		for _, depth := range []int{0, 1, 2, 3} {
+			for _, k := range []int{0, 1, 2} {
+				s := Snowball(g, seeds, depth, k, rand.NewSource(2))
+				checkInduced(t, "Snowball", g, s)
+
+				ball := within(g, seeds, depth)
+				for _, id := range ids(s) {
+					if _, ok := ball[id]; !ok {
+						t.Errorf("node %d beyond depth %d of seeds with k=%d", id, depth, k)
+					}
+				}
+				if k == 0 && len(ids(s)) != len(ball) {
+					t.Errorf("unexpected number of nodes for depth %d with all neighbors: got:%d want:%d",
+						depth, len(ids(s)), len(ball))
+				}
+
+				again := Snowball(g, seeds, depth, k, rand.NewSource(2))
+				if !reflect.DeepEqual(ids(s), ids(again)) {
+					t.Errorf("sample not deterministic for depth=%d k=%d", depth, k)
+				}
+			}
+		}
+	}
+}
+
+// within returns the IDs of the nodes of g within depth hops of the seeds.
+func within(g graph.Graph, seeds []graph.Node, depth int) map[int64]int {
+	dist := make(map[int64]int)
+	var queue []graph.Node
+	for _, s := range seeds {
+		if g.Node(s.ID()) != nil {
+			dist[s.ID()] = 0
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if dist[u.ID()] == depth {
+			continue
+		}
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			if _, ok := dist[v.ID()]; !ok {
+				dist[v.ID()] = dist[u.ID()] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return dist
+}
+
+func TestInduceWeighted(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(0), W: 4})
+
+	s, ok := Induce(g, []graph.Node{simple.Node(0), simple.Node(1), simple.Node(5)}).(graph.WeightedDirected)
+	if !ok {
+		t.Fatal("induced subgraph is not weighted directed")
+	}
+	if got := ids(s); !reflect.DeepEqual(got, []int64{0, 1}) {
+		t.Errorf("unexpected nodes: got:%v want:[0 1]", got)
+	}
+	if w, ok := s.Weight(0, 1); w != 2 || !ok {
+		t.Errorf("unexpected weight: got:%v,%t want:2,true", w, ok)
+	}
+	if w, ok := s.Weight(1, 0); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected weight for absent edge: got:%v,%t want:+Inf,false", w, ok)
+	}
+	if w, ok := s.Weight(0, 0); w != 0 || !ok {
+		t.Errorf("unexpected self weight: got:%v,%t want:0,true", w, ok)
+	}
+}
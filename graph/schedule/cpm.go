@@ -0,0 +1,132 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schedule
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// CPM performs the critical path method on the activity-on-node network g,
+// where each node is an activity taking the time given by duration and an
+// edge u→v requires that activity v does not start until activity u has
+// finished and the time given by the weight of the edge, a lag, has elapsed.
+// The project starts at time zero and finishes when its last activity
+// finishes.
+//
+// CPM returns the earliest and latest start times of each activity that allow
+// the project to finish as early as possible, keyed by node ID, and the total
+// slack of each activity, the difference between its latest and earliest
+// start times. Activities with zero slack are critical. Slack within a
+// relative tolerance of 1e-12 of the project duration is treated as zero to
+// allow for floating point rounding.
+//
+// The returned critical path is a chain of critical activities starting at
+// time zero and finishing when the project finishes, where each activity
+// starts as soon as its predecessor in the chain and the lag between them
+// allow. When there is more than one such chain, the path starting with the
+// activity with the lowest ID and then continuing with the successor with
+// the lowest ID at each step is returned.
+//
+// CPM will panic if g contains a cycle or a duration is negative.
+func CPM(g graph.WeightedDirected, duration func(graph.Node) float64) (earliest, latest, slack map[int64]float64, critical []graph.Node) {
+	order, err := topo.Sort(g)
+	if err != nil {
+		panic("schedule: activity network has a cycle")
+	}
+
+	lag := func(uid, vid int64) float64 {
+		w, ok := g.Weight(uid, vid)
+		if !ok {
+			panic("schedule: unexpected invalid weight")
+		}
+		return w
+	}
+
+	dur := make(map[int64]float64, len(order))
+	earliest = make(map[int64]float64, len(order))
+	var finish float64
+	for _, u := range order {
+		uid := u.ID()
+		d := duration(u)
+		if d < 0 {
+			panic("schedule: negative duration")
+		}
+		dur[uid] = d
+		es := earliest[uid]
+		to := g.To(uid)
+		for to.Next() {
+			pid := to.Node().ID()
+			if t := earliest[pid] + dur[pid] + lag(pid, uid); t > es {
+				es = t
+			}
+		}
+		earliest[uid] = es
+		finish = math.Max(finish, es+d)
+	}
+
+	latest = make(map[int64]float64, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		uid := u.ID()
+		lf := finish
+		from := g.From(uid)
+		for from.Next() {
+			sid := from.Node().ID()
+			if t := latest[sid] - lag(uid, sid); t < lf {
+				lf = t
+			}
+		}
+		latest[uid] = lf - dur[uid]
+	}
+
+	tol := 1e-12 * math.Max(1, finish)
+	isCritical := make(map[int64]bool)
+	slack = make(map[int64]float64, len(order))
+	for _, u := range order {
+		uid := u.ID()
+		s := latest[uid] - earliest[uid]
+		if math.Abs(s) <= tol {
+			s = 0
+			isCritical[uid] = true
+		}
+		slack[uid] = s
+	}
+
+	// Every critical activity that does not finish with the project
+	// has a critical successor that starts as soon as it can, so the
+	// path can be followed from any critical activity starting at zero.
+	var u graph.Node
+	for _, n := range order {
+		if isCritical[n.ID()] && earliest[n.ID()] == 0 && (u == nil || n.ID() < u.ID()) {
+			u = n
+		}
+	}
+	for u != nil {
+		critical = append(critical, u)
+		uid := u.ID()
+		ef := earliest[uid] + dur[uid]
+		if math.Abs(finish-ef) <= tol {
+			break
+		}
+		var next graph.Node
+		from := g.From(uid)
+		for from.Next() {
+			v := from.Node()
+			vid := v.ID()
+			if !isCritical[vid] || math.Abs(earliest[vid]-(ef+lag(uid, vid))) > tol {
+				continue
+			}
+			if next == nil || vid < next.ID() {
+				next = v
+			}
+		}
+		u = next
+	}
+
+	return earliest, latest, slack, critical
+}
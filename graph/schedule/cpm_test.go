@@ -0,0 +1,186 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schedule
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestCPM(t *testing.T) {
+	// Activities A to F with durations, where B and C follow A,
+	// D follows B, E follows C after a lag of 1 and F follows
+	// D and E.
+	const (
+		A = iota
+		B
+		C
+		D
+		E
+		F
+	)
+	durations := map[int64]float64{A: 3, B: 2, C: 4, D: 4, E: 1, F: 2}
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(A), T: simple.Node(B)},
+		{F: simple.Node(A), T: simple.Node(C)},
+		{F: simple.Node(B), T: simple.Node(D)},
+		{F: simple.Node(C), T: simple.Node(E), W: 1},
+		{F: simple.Node(D), T: simple.Node(F)},
+		{F: simple.Node(E), T: simple.Node(F)},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	earliest, latest, slack, critical := CPM(g, func(n graph.Node) float64 { return durations[n.ID()] })
+	wantEarliest := map[int64]float64{A: 0, B: 3, C: 3, D: 5, E: 8, F: 9}
+	wantLatest := map[int64]float64{A: 0, B: 3, C: 3, D: 5, E: 8, F: 9}
+	wantSlack := map[int64]float64{A: 0, B: 0, C: 0, D: 0, E: 0, F: 0}
+	if !reflect.DeepEqual(earliest, wantEarliest) {
+		t.Errorf("unexpected earliest starts: got:%v want:%v", earliest, wantEarliest)
+	}
+	if !reflect.DeepEqual(latest, wantLatest) {
+		t.Errorf("unexpected latest starts: got:%v want:%v", latest, wantLatest)
+	}
+	if !reflect.DeepEqual(slack, wantSlack) {
+		t.Errorf("unexpected slack: got:%v want:%v", slack, wantSlack)
+	}
+	// Both A-B-D-F and A-C-E-F are critical, and
+	// the path through the lower IDs is returned.
+	if got, want := ids(critical), []int64{A, B, D, F}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected critical path: got:%v want:%v", got, want)
+	}
+
+	// Shortening E leaves the C-E branch with slack.
+	durations[E] = 0.5
+	earliest, latest, slack, critical = CPM(g, func(n graph.Node) float64 { return durations[n.ID()] })
+	if got := slack[C]; got != 0.5 {
+		t.Errorf("unexpected slack for C: got:%v want:0.5", got)
+	}
+	if got := latest[E] - earliest[E]; got != 0.5 {
+		t.Errorf("unexpected slack for E: got:%v want:0.5", got)
+	}
+	if got, want := ids(critical), []int64{A, B, D, F}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected critical path: got:%v want:%v", got, want)
+	}
+
+	// Lengthening E makes A-C-E-F the only critical path.
+	durations[E] = 2
+	_, _, _, critical = CPM(g, func(n graph.Node) float64 { return durations[n.ID()] })
+	if got, want := ids(critical), []int64{A, C, E, F}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected critical path: got:%v want:%v", got, want)
+	}
+}
+
+func TestCPMRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 1 + rnd.Intn(20)
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		durations := make(map[int64]float64)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+			durations[int64(u)] = float64(rnd.Intn(10))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.2 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(3))})
+				}
+			}
+		}
+		duration := func(n graph.Node) float64 { return durations[n.ID()] }
+
+		earliest, latest, slack, critical := CPM(g, duration)
+
+		// Every precedence constraint is met by both
+		// schedules and no activity finishes late.
+		var finish float64
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			finish = math.Max(finish, earliest[u.ID()]+duration(u))
+		}
+		for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+			uid, vid := e.From().ID(), e.To().ID()
+			if earliest[vid] < earliest[uid]+durations[uid]+e.Weight() {
+				t.Errorf("earliest schedule violates %d→%d for test %d", uid, vid, test)
+			}
+			if latest[vid] < latest[uid]+durations[uid]+e.Weight() {
+				t.Errorf("latest schedule violates %d→%d for test %d", uid, vid, test)
+			}
+		}
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			uid := u.ID()
+			if latest[uid]+durations[uid] > finish {
+				t.Errorf("activity %d finishes late for test %d", uid, test)
+			}
+			if slack[uid] != latest[uid]-earliest[uid] || slack[uid] < 0 {
+				t.Errorf("unexpected slack for %d for test %d: got:%v", uid, test, slack[uid])
+			}
+		}
+
+		// Each earliest start is the length of the longest
+		// chain of predecessors.
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			if got, want := earliest[u.ID()], longestTo(g, u.ID(), durations); got != want {
+				t.Errorf("unexpected earliest start for %d for test %d: got:%v want:%v", u.ID(), test, got, want)
+			}
+		}
+
+		// The critical path is a chain of zero slack activities
+		// from the project start to the project finish.
+		if !isCriticalPath(g, critical, slack, earliest, durations, finish) {
+			t.Errorf("invalid critical path for test %d: %v", test, ids(critical))
+		}
+	}
+}
+
+// longestTo returns the earliest start of the activity with ID vid.
+func longestTo(g graph.WeightedDirected, vid int64, durations map[int64]float64) float64 {
+	var best float64
+	for _, u := range graph.NodesOf(g.To(vid)) {
+		w, _ := g.Weight(u.ID(), vid)
+		best = math.Max(best, longestTo(g, u.ID(), durations)+durations[u.ID()]+w)
+	}
+	return best
+}
+
+// isCriticalPath returns whether path is a chain of zero slack activities
+// starting at time zero and finishing at time finish, with each activity
+// starting as soon as its predecessor in the chain allows.
+func isCriticalPath(g graph.WeightedDirected, path []graph.Node, slack, earliest, durations map[int64]float64, finish float64) bool {
+	if len(path) == 0 || earliest[path[0].ID()] != 0 {
+		return false
+	}
+	for i, u := range path {
+		uid := u.ID()
+		if slack[uid] != 0 {
+			return false
+		}
+		if i == 0 {
+			continue
+		}
+		pid := path[i-1].ID()
+		w, ok := g.Weight(pid, uid)
+		if !g.HasEdgeFromTo(pid, uid) || !ok || earliest[uid] != earliest[pid]+durations[pid]+w {
+			return false
+		}
+	}
+	last := path[len(path)-1].ID()
+	return earliest[last]+durations[last] == finish
+}
+
+func ids(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
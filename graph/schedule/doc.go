@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package schedule provides project scheduling routines for activity
+// networks.
+package schedule // import "gonum.org/v1/gonum/graph/schedule"
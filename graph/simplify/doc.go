@@ -0,0 +1,14 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package simplify provides functions for reducing graphs to simple graphs.
+//
+// Some functions in the graph packages require graphs without self loops,
+// such as network.NewLaplacian, network.NewSymNormLaplacian,
+// network.NewRandomWalkLaplacian and spectral.NormalizedCut, which panic when
+// given a self loop, and functions that consider each pair of nodes once,
+// such as those of the spectral and flow packages, read a single weight for
+// the pair. Graphs built from data can be normalized for these functions with
+// RemoveSelfLoops, ToSimple and CollapseParallelEdges.
+package simplify // import "gonum.org/v1/gonum/graph/simplify"
@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplify
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// CollapseParallelEdges returns a simple graph with the nodes of g and a
+// single edge between each pair of distinct nodes that are joined in g. The
+// weight of each edge is combine(weights) where weights holds the weights of
+// the lines joining the pair in g, ordered by line ID, if g is a
+// graph.WeightedMultigraph, or the weight of the single edge joining the
+// pair otherwise. For example, passing floats.Sum as combine gives each edge
+// the total weight of the lines it replaces.
+//
+// The returned graph has a self weight of zero and an absent weight of +Inf.
+// Self loops in g are not represented in the returned graph. The combine
+// function must not retain the weights slice.
+func CollapseParallelEdges(g graph.WeightedUndirected, combine func(weights []float64) float64) graph.WeightedUndirected {
+	dst := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		dst.AddNode(u)
+	}
+	var weights []float64
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid <= uid {
+				continue
+			}
+			weights = weightsOf(weights[:0], g, uid, vid)
+			dst.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: combine(weights)})
+		}
+	}
+	return dst
+}
+
+// CollapseParallelEdgesDirected returns a simple directed graph with the
+// nodes of g and a single edge from u to v for each pair of distinct nodes
+// where g has an edge from u to v. The weights of the edges are combined as
+// described for CollapseParallelEdges, and the returned graph has the same
+// self and absent weights.
+func CollapseParallelEdgesDirected(g graph.WeightedDirected, combine func(weights []float64) float64) graph.WeightedDirected {
+	dst := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		dst.AddNode(u)
+	}
+	var weights []float64
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid == uid {
+				continue
+			}
+			weights = weightsOf(weights[:0], g, uid, vid)
+			dst.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: combine(weights)})
+		}
+	}
+	return dst
+}
+
+// weightsOf appends the weights of the lines from uid to vid in g to dst,
+// ordered by line ID, or the weight of the edge if g is not a multigraph.
+func weightsOf(dst []float64, g graph.Weighted, uid, vid int64) []float64 {
+	m, ok := g.(graph.WeightedMultigraph)
+	if !ok {
+		return append(dst, g.WeightedEdge(uid, vid).Weight())
+	}
+	lines := graph.LinesOf(m.Lines(uid, vid))
+	sort.Sort(byLineID(lines))
+	for _, l := range lines {
+		dst = append(dst, l.(graph.WeightedLine).Weight())
+	}
+	return dst
+}
+
+// byLineID sorts lines by ID.
+type byLineID []graph.Line
+
+func (l byLineID) Len() int           { return len(l) }
+func (l byLineID) Less(i, j int) bool { return l[i].ID() < l[j].ID() }
+func (l byLineID) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
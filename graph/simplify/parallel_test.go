@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplify
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var parallelLines = []struct{ u, v int64 }{
+	{0, 1}, {1, 0}, {0, 1}, {1, 2}, {2, 2}, {2, 3}, {3, 2},
+}
+
+func TestCollapseParallelEdges(t *testing.T) {
+	g := multi.NewWeightedUndirectedGraph()
+	for i, l := range parallelLines {
+		g.SetWeightedLine(g.NewWeightedLine(multi.Node(l.u), multi.Node(l.v), float64(i+1)))
+	}
+	g.AddNode(multi.Node(4))
+
+	for _, test := range []struct {
+		name    string
+		combine func([]float64) float64
+		want    string
+	}{
+		{name: "sum", combine: floats.Sum, want: "[0-1:6 1-2:4 2-3:13]"},
+		{name: "min", combine: floats.Min, want: "[0-1:1 1-2:4 2-3:6]"},
+		{name: "first", combine: func(w []float64) float64 { return w[0] }, want: "[0-1:1 1-2:4 2-3:6]"},
+	} {
+		got := CollapseParallelEdges(g, test.combine)
+		if n := got.Nodes().Len(); n != 5 {
+			t.Errorf("unexpected number of nodes for %s: got:%d want:5", test.name, n)
+		}
+		if s := describe(got); s != test.want {
+			t.Errorf("unexpected edges for %s: got:%s want:%s", test.name, s, test.want)
+		}
+	}
+
+	// A simple graph is copied with combine applied to each weight.
+	s := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	s.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	got := CollapseParallelEdges(s, func(w []float64) float64 { return 10 * w[0] })
+	if d := describe(got); d != "[0-1:20]" {
+		t.Errorf("unexpected edges for simple graph: got:%s want:[0-1:20]", d)
+	}
+}
+
+func TestCollapseParallelEdgesDirected(t *testing.T) {
+	g := multi.NewWeightedDirectedGraph()
+	for i, l := range parallelLines {
+		g.SetWeightedLine(g.NewWeightedLine(multi.Node(l.u), multi.Node(l.v), float64(i+1)))
+	}
+	got := CollapseParallelEdgesDirected(g, floats.Sum)
+	want := "[0→1:4 1→0:2 1→2:4 2→3:6 3→2:7]"
+	if d := describe(got); d != want {
+		t.Errorf("unexpected edges: got:%s want:%s", d, want)
+	}
+}
+
+// describe returns a description of the edges of g ordered by their end
+// node IDs.
+func describe(g graph.Weighted) string {
+	_, directed := g.(graph.Directed)
+	var edges []string
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		u := n.ID()
+		for _, m := range graph.NodesOf(g.From(u)) {
+			v := m.ID()
+			w := g.WeightedEdge(u, v).Weight()
+			switch {
+			case directed:
+				edges = append(edges, fmt.Sprintf("%d→%d:%v", u, v, w))
+			case u < v:
+				edges = append(edges, fmt.Sprintf("%d-%d:%v", u, v, w))
+			}
+		}
+	}
+	sort.Strings(edges)
+	return fmt.Sprint(edges)
+}
@@ -0,0 +1,160 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplify
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// RemoveSelfLoops returns a copy of g without its self loops. The nodes,
+// edges and lines of g are retained by the returned graph, so node identities
+// are preserved.
+//
+// If g is a graph.Multigraph the returned graph is a multigraph from the multi
+// package holding all lines of g between distinct nodes, otherwise it is a
+// graph from the simple package holding all edges of g between distinct nodes.
+// The returned graph is directed if g is a graph.Directed and weighted if g is
+// a graph.Weighted, with the multigraph's default weight function or with a
+// self weight of zero and an absent weight of +Inf respectively.
+func RemoveSelfLoops(g graph.Graph) graph.Graph {
+	_, directed := g.(graph.Directed)
+	_, weighted := g.(graph.Weighted)
+	if m, ok := g.(graph.Multigraph); ok {
+		var dst graph.Graph
+		switch {
+		case directed && weighted:
+			dst = multi.NewWeightedDirectedGraph()
+		case directed:
+			dst = multi.NewDirectedGraph()
+		case weighted:
+			dst = multi.NewWeightedUndirectedGraph()
+		default:
+			dst = multi.NewUndirectedGraph()
+		}
+		copyNodes(dst.(graph.NodeAdder), g)
+		eachPair(g, directed, func(u, v graph.Node) {
+			lines := m.Lines(u.ID(), v.ID())
+			for lines.Next() {
+				l := lines.Line()
+				if weighted {
+					dst.(graph.WeightedLineAdder).SetWeightedLine(l.(graph.WeightedLine))
+				} else {
+					dst.(graph.LineAdder).SetLine(l)
+				}
+			}
+		})
+		return dst
+	}
+
+	dst := newSimple(directed, weighted)
+	copyNodes(dst.(graph.NodeAdder), g)
+	eachPair(g, directed, func(u, v graph.Node) {
+		if weighted {
+			dst.(graph.WeightedEdgeAdder).SetWeightedEdge(g.(graph.Weighted).WeightedEdge(u.ID(), v.ID()))
+		} else {
+			dst.(graph.EdgeAdder).SetEdge(g.Edge(u.ID(), v.ID()))
+		}
+	})
+	return dst
+}
+
+// ToSimple returns a simple graph from the simple package with the nodes of g
+// and, for each pair of distinct nodes joined by lines in g, a single edge
+// returned by combine when called with those lines ordered by line ID. If
+// combine is nil, the line with the lowest ID is used. Self loops in g are
+// not represented in the returned graph.
+//
+// The returned graph is directed if g is a graph.Directed and weighted if g
+// is a graph.Weighted, with a self weight of zero and an absent weight of
+// +Inf. When g is weighted the edges returned by combine must be
+// graph.WeightedEdge values. ToSimple will panic if combine returns an edge
+// that does not join the nodes of the lines it was called with.
+func ToSimple(g graph.Multigraph, combine func([]graph.Edge) graph.Edge) graph.Graph {
+	_, directed := g.(graph.Directed)
+	_, weighted := g.(graph.Weighted)
+	dst := newSimple(directed, weighted)
+	copyNodes(dst.(graph.NodeAdder), g)
+	var edges []graph.Edge
+	eachPair(g, directed, func(u, v graph.Node) {
+		lines := graph.LinesOf(g.Lines(u.ID(), v.ID()))
+		sort.Sort(byLineID(lines))
+		edges = edges[:0]
+		for _, l := range lines {
+			edges = append(edges, l)
+		}
+		e := edges[0]
+		if combine != nil {
+			e = combine(edges)
+		}
+
+		f, t := e.From().ID(), e.To().ID()
+		if !(f == u.ID() && t == v.ID()) && (directed || !(f == v.ID() && t == u.ID())) {
+			panic("simplify: combined edge does not join the nodes of its lines")
+		}
+		if weighted {
+			we, ok := e.(graph.WeightedEdge)
+			if !ok {
+				panic("simplify: combined edge is not weighted")
+			}
+			dst.(graph.WeightedEdgeAdder).SetWeightedEdge(we)
+		} else {
+			dst.(graph.EdgeAdder).SetEdge(e)
+		}
+	})
+	return dst
+}
+
+// newSimple returns an empty graph from the simple package.
+func newSimple(directed, weighted bool) graph.Graph {
+	switch {
+	case directed && weighted:
+		return simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	case directed:
+		return simple.NewDirectedGraph()
+	case weighted:
+		return simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	default:
+		return simple.NewUndirectedGraph()
+	}
+}
+
+// adjacency is a graph or multigraph.
+type adjacency interface {
+	Nodes() graph.Nodes
+	From(id int64) graph.Nodes
+}
+
+// copyNodes adds the nodes of g to dst.
+func copyNodes(dst graph.NodeAdder, g adjacency) {
+	nodes := g.Nodes()
+	for nodes.Next() {
+		dst.AddNode(nodes.Node())
+	}
+}
+
+// eachPair calls fn for each pair of distinct adjacent nodes of g, once
+// for each ordered pair if directed is true and once for each unordered
+// pair otherwise.
+func eachPair(g adjacency, directed bool, fn func(u, v graph.Node)) {
+	nodes := g.Nodes()
+	for nodes.Next() {
+		u := nodes.Node()
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if vid == uid || (!directed && vid < uid) {
+				continue
+			}
+			fn(u, v)
+		}
+	}
+}
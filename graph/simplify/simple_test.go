@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplify
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestRemoveSelfLoops(t *testing.T) {
+	g := multi.NewDirectedGraph()
+	for _, l := range parallelLines {
+		g.SetLine(g.NewLine(multi.Node(l.u), multi.Node(l.v)))
+	}
+	g.AddNode(multi.Node(4))
+
+	got := RemoveSelfLoops(g)
+	m, ok := got.(*multi.DirectedGraph)
+	if !ok {
+		t.Fatalf("unexpected graph type: %T", got)
+	}
+	if n := m.Nodes().Len(); n != 5 {
+		t.Errorf("unexpected number of nodes: got:%d want:5", n)
+	}
+	if m.HasEdgeFromTo(2, 2) {
+		t.Error("self loop not removed")
+	}
+	want := "[0→1 0→1 1→0 1→2 2→3 3→2]"
+	if s := describeLines(m); s != want {
+		t.Errorf("unexpected lines: got:%s want:%s", s, want)
+	}
+	if m.Node(0) != g.Node(0) {
+		t.Error("node identity not preserved")
+	}
+
+	w := multi.NewWeightedUndirectedGraph()
+	for i, l := range parallelLines {
+		w.SetWeightedLine(w.NewWeightedLine(multi.Node(l.u), multi.Node(l.v), float64(i)))
+	}
+	gotW := RemoveSelfLoops(w)
+	if _, ok := gotW.(*multi.WeightedUndirectedGraph); !ok {
+		t.Fatalf("unexpected graph type: %T", gotW)
+	}
+	if gotW.HasEdgeBetween(2, 2) {
+		t.Error("self loop not removed from weighted graph")
+	}
+	if n := gotW.(graph.WeightedMultigraph).WeightedLines(0, 1).Len(); n != 3 {
+		t.Errorf("unexpected number of lines between 0 and 1: got:%d want:3", n)
+	}
+
+	s := simple.NewUndirectedGraph()
+	s.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	gotS := RemoveSelfLoops(s)
+	if _, ok := gotS.(*simple.UndirectedGraph); !ok {
+		t.Fatalf("unexpected graph type: %T", gotS)
+	}
+	if !gotS.HasEdgeBetween(0, 1) {
+		t.Error("edge not copied from simple graph")
+	}
+}
+
+func TestToSimple(t *testing.T) {
+	g := multi.NewWeightedUndirectedGraph()
+	for i, l := range parallelLines {
+		g.SetWeightedLine(g.NewWeightedLine(multi.Node(l.u), multi.Node(l.v), float64(i+1)))
+	}
+
+	// By default the line with the lowest ID is kept.
+	got := ToSimple(g, nil)
+	if _, ok := got.(*simple.WeightedUndirectedGraph); !ok {
+		t.Fatalf("unexpected graph type: %T", got)
+	}
+	want := "[0-1:1 1-2:4 2-3:6]"
+	if s := describe(got.(graph.Weighted)); s != want {
+		t.Errorf("unexpected edges: got:%s want:%s", s, want)
+	}
+
+	// Keep the heaviest line.
+	got = ToSimple(g, func(edges []graph.Edge) graph.Edge {
+		best := edges[0].(graph.WeightedEdge)
+		for _, e := range edges[1:] {
+			if e := e.(graph.WeightedEdge); e.Weight() > best.Weight() {
+				best = e
+			}
+		}
+		return best
+	})
+	want = "[0-1:3 1-2:4 2-3:7]"
+	if s := describe(got.(graph.Weighted)); s != want {
+		t.Errorf("unexpected edges: got:%s want:%s", s, want)
+	}
+
+	d := multi.NewDirectedGraph()
+	for _, l := range parallelLines {
+		d.SetLine(d.NewLine(multi.Node(l.u), multi.Node(l.v)))
+	}
+	gotD, ok := ToSimple(d, nil).(*simple.DirectedGraph)
+	if !ok {
+		t.Fatalf("unexpected graph type: %T", gotD)
+	}
+	if gotD.HasEdgeBetween(2, 2) || gotD.Edges().Len() != 5 {
+		t.Errorf("unexpected edges: got %d edges, self loop %t", gotD.Edges().Len(), gotD.HasEdgeBetween(2, 2))
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		ToSimple(d, func(edges []graph.Edge) graph.Edge {
+			return simple.Edge{F: simple.Node(0), T: simple.Node(3)}
+		})
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for edge not joining its lines")
+	}
+}
+
+// describeLines returns a description of the lines of g.
+func describeLines(g graph.Multigraph) string {
+	var lines []string
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			for _, l := range graph.LinesOf(g.Lines(u.ID(), v.ID())) {
+				lines = append(lines, fmt.Sprintf("%d→%d", l.From().ID(), l.To().ID()))
+			}
+		}
+	}
+	sort.Strings(lines)
+	return fmt.Sprint(lines)
+}
@@ -0,0 +1,7 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spectral provides graph partitioning and analysis functions
+// based on the spectra of matrices associated with graphs.
+package spectral // import "gonum.org/v1/gonum/graph/spectral"
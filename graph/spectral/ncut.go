@@ -0,0 +1,172 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// NormalizedCut returns a bipartition of the nodes of g found by the
+// normalized cut method of Shi and Malik doi:10.1109/34.868688, and the
+// normalized cut value of the bipartition
+//
+//	Ncut(A, B) = cut(A, B)/assoc(A, V) + cut(A, B)/assoc(B, V)
+//
+// where cut(A, B) is the total weight of edges between A and B and
+// assoc(A, V) is the total weight of edges incident to nodes in A.
+//
+// The relaxed problem is solved by the eigenvector x of the generalized
+// eigenproblem (D-W)x = λDx with the second smallest eigenvalue, where W is
+// the weighted adjacency matrix of g and D is the diagonal matrix of
+// weighted degrees. The nodes are ordered by their value in x, with ties
+// broken by ID, and the split of the ordering into a prefix and suffix with
+// the minimum normalized cut is returned. Nodes with zero weighted degree
+// have no contribution to either association and are placed by their value
+// in x, which is zero.
+//
+// If fewer than two nodes of g have non-zero weighted degree, all the nodes
+// are returned in parts[0] and ncut is NaN. NormalizedCut will panic if g
+// has a self edge or a negative edge weight.
+func NormalizedCut(g graph.WeightedUndirected) (parts [2][]graph.Node, ncut float64) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// adj[i] holds the weighted neighbors of node i.
+	type neighbor struct {
+		j int
+		w float64
+	}
+	adj := make([][]neighbor, n)
+	deg := make([]float64, n)
+	var connected int
+	for i, u := range nodes {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			if uid == vid {
+				panic("spectral: self edge in graph")
+			}
+			w, ok := g.Weight(uid, vid)
+			if !ok {
+				panic("spectral: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("spectral: negative edge weight")
+			}
+			adj[i] = append(adj[i], neighbor{j: indexOf[vid], w: w})
+			deg[i] += w
+		}
+		if deg[i] > 0 {
+			connected++
+		}
+	}
+	if connected < 2 {
+		return [2][]graph.Node{nodes}, math.NaN()
+	}
+
+	// Solve the generalized eigenproblem through the symmetric
+	// normalized Laplacian D^-1/2 (D-W) D^-1/2 of the nodes with
+	// non-zero degree, whose eigenvectors z give the generalized
+	// eigenvectors x = D^-1/2 z.
+	active := make([]int, 0, connected)
+	activeIndex := make([]int, n)
+	for i, d := range deg {
+		activeIndex[i] = -1
+		if d > 0 {
+			activeIndex[i] = len(active)
+			active = append(active, i)
+		}
+	}
+	l := mat.NewSymDense(len(active), nil)
+	for a, i := range active {
+		l.SetSym(a, a, 1)
+		for _, e := range adj[i] {
+			if b := activeIndex[e.j]; a < b {
+				l.SetSym(a, b, -e.w/math.Sqrt(deg[i]*deg[e.j]))
+			}
+		}
+	}
+	var es mat.EigenSym
+	if !es.Factorize(l, true) {
+		panic("spectral: eigendecomposition failed")
+	}
+	var vecs mat.Dense
+	vecs.EigenvectorsSym(&es)
+	x := make([]float64, n)
+	for a, i := range active {
+		x[i] = vecs.At(a, 1) / math.Sqrt(deg[i])
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Stable(byValue{order: order, x: x})
+
+	// Sweep the ordering, maintaining the cut and association
+	// of the prefix.
+	var total float64
+	for _, d := range deg {
+		total += d
+	}
+	in := make([]bool, n)
+	var (
+		cut, assoc float64
+		count      int
+	)
+	best := math.Inf(1)
+	split := 0
+	for k, i := range order[:n-1] {
+		var toPrefix float64
+		for _, e := range adj[i] {
+			if in[e.j] {
+				toPrefix += e.w
+			}
+		}
+		in[i] = true
+		cut += deg[i] - 2*toPrefix
+		assoc += deg[i]
+		if deg[i] > 0 {
+			count++
+		}
+		if count == 0 || count == connected {
+			continue
+		}
+		c := cut/assoc + cut/(total-assoc)
+		if c < best {
+			best = c
+			split = k + 1
+		}
+	}
+
+	for k, i := range order {
+		if k < split {
+			parts[0] = append(parts[0], nodes[i])
+		} else {
+			parts[1] = append(parts[1], nodes[i])
+		}
+	}
+	return parts, best
+}
+
+// byValue sorts node indices by ascending value.
+type byValue struct {
+	order []int
+	x     []float64
+}
+
+func (s byValue) Len() int           { return len(s.order) }
+func (s byValue) Less(i, j int) bool { return s.x[s.order[i]] < s.x[s.order[j]] }
+func (s byValue) Swap(i, j int)      { s.order[i], s.order[j] = s.order[j], s.order[i] }
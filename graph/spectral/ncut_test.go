@@ -0,0 +1,184 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNormalizedCutClusters(t *testing.T) {
+	// Two dense clusters joined by weak edges are separated.
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 10; trial++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		const size = 6
+		for c := 0; c < 2; c++ {
+			for i := 0; i < size; i++ {
+				for j := i + 1; j < size; j++ {
+					if rnd.Float64() < 0.8 {
+						g.SetWeightedEdge(simple.WeightedEdge{
+							F: simple.Node(c*size + i), T: simple.Node(c*size + j), W: 1 + rnd.Float64(),
+						})
+					}
+				}
+			}
+		}
+		for k := 0; k < 2; k++ {
+			g.SetWeightedEdge(simple.WeightedEdge{
+				F: simple.Node(rnd.Intn(size)), T: simple.Node(size + rnd.Intn(size)), W: 0.05,
+			})
+		}
+
+		parts, ncut := NormalizedCut(g)
+		want, wantCut := bruteForceNcut(g)
+		if !floats.EqualWithinAbsOrRel(ncut, wantCut, 1e-10, 1e-10) {
+			t.Errorf("unexpected ncut for trial %d: got:%v want:%v", trial, ncut, wantCut)
+		}
+		if got := ncutOf(g, parts[0]); !floats.EqualWithinAbsOrRel(got, ncut, 1e-10, 1e-10) {
+			t.Errorf("returned ncut does not match parts for trial %d: got:%v parts:%v", trial, ncut, got)
+		}
+		got := ids(parts[0])
+		if !equalIDs(got, want) && !equalIDs(ids(parts[1]), want) {
+			t.Errorf("unexpected parts for trial %d: got:%v want:%v", trial, parts, want)
+		}
+	}
+}
+
+func TestNormalizedCutRandom(t *testing.T) {
+	// The returned value is the normalized cut of the returned
+	// bipartition and is no better than the optimum.
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		const n = 9
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+			for j := 0; j < i; j++ {
+				if rnd.Float64() < 0.4 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(j), W: rnd.Float64()})
+				}
+			}
+		}
+		parts, ncut := NormalizedCut(g)
+		if len(parts[0])+len(parts[1]) != n || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			t.Errorf("invalid bipartition for trial %d: %v", trial, parts)
+			continue
+		}
+		if got := ncutOf(g, parts[0]); !floats.EqualWithinAbsOrRel(got, ncut, 1e-10, 1e-10) {
+			t.Errorf("returned ncut does not match parts for trial %d: got:%v parts:%v", trial, ncut, got)
+		}
+		if _, opt := bruteForceNcut(g); ncut < opt-1e-10 {
+			t.Errorf("ncut below optimum for trial %d: got:%v optimum:%v", trial, ncut, opt)
+		}
+	}
+}
+
+func TestNormalizedCutDisconnected(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(5))
+	parts, ncut := NormalizedCut(g)
+	if ncut != 0 {
+		t.Errorf("unexpected ncut for disconnected graph: got:%v want:0", ncut)
+	}
+	if got := ncutOf(g, parts[0]); got != 0 {
+		t.Errorf("parts of disconnected graph are connected: %v", parts)
+	}
+
+	g = simple.NewWeightedUndirectedGraph(0, 0)
+	g.AddNode(simple.Node(0))
+	g.AddNode(simple.Node(1))
+	parts, ncut = NormalizedCut(g)
+	if !math.IsNaN(ncut) || len(parts[0]) != 2 || len(parts[1]) != 0 {
+		t.Errorf("unexpected result for edgeless graph: parts:%v ncut:%v", parts, ncut)
+	}
+}
+
+// ncutOf returns the normalized cut between a and the remaining nodes of g.
+func ncutOf(g graph.WeightedUndirected, a []graph.Node) float64 {
+	in := make(map[int64]bool)
+	for _, n := range a {
+		in[n.ID()] = true
+	}
+	var cut, assocA, assocB float64
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			w, _ := g.Weight(u.ID(), v.ID())
+			if in[u.ID()] {
+				assocA += w
+			} else {
+				assocB += w
+			}
+			if in[u.ID()] && !in[v.ID()] {
+				cut += w
+			}
+		}
+	}
+	if cut == 0 {
+		return 0
+	}
+	return cut/assocA + cut/assocB
+}
+
+// bruteForceNcut returns the IDs of the part holding the lowest ID node
+// of the optimal bipartition of g, and its normalized cut.
+func bruteForceNcut(g graph.WeightedUndirected) ([]int64, float64) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	best := math.Inf(1)
+	var bestPart []int64
+	for mask := 1; mask < 1<<uint(len(nodes)); mask += 2 {
+		if mask == 1<<uint(len(nodes))-1 {
+			continue
+		}
+		var a []graph.Node
+		for i, n := range nodes {
+			if mask&(1<<uint(i)) != 0 {
+				a = append(a, n)
+			}
+		}
+		if c := ncutOf(g, a); c < best {
+			best = c
+			bestPart = ids(a)
+		}
+	}
+	return bestPart, best
+}
+
+func ids(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ResistanceMatrix returns the effective resistance between each pair of
+// nodes of g, treating each edge as a resistor with a conductance equal to
+// its weight, and the nodes of g in the order of the rows and columns of the
+// returned matrix, which is ordered by node ID.
+//
+// The effective resistance between nodes i and j is
+//
+//	R(i, j) = L⁺(i, i) + L⁺(j, j) - 2 L⁺(i, j)
+//
+// where L⁺ is the Moore-Penrose pseudoinverse of the weighted Laplacian of g.
+// The pseudoinverse of the Laplacian of each connected component of g with k
+// nodes is found with a single Cholesky factorization as
+//
+//	L⁺ = (L + J/k)⁻¹ - J/k
+//
+// where J is the k×k matrix of ones. Nodes in different connected components
+// of g, where only edges with positive weight connect nodes, have an infinite
+// effective resistance. Self edges are ignored.
+//
+// ResistanceMatrix will panic if g has a negative edge weight.
+func ResistanceMatrix(g graph.WeightedUndirected) (mat.Symmetric, []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	n := len(nodes)
+	if n == 0 {
+		return &mat.SymDense{}, nil
+	}
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// adj[i] holds the positively weighted neighbors of node i.
+	type neighbor struct {
+		j int
+		w float64
+	}
+	adj := make([][]neighbor, n)
+	for i, u := range nodes {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			if uid == vid {
+				continue
+			}
+			w, ok := g.Weight(uid, vid)
+			if !ok {
+				panic("spectral: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("spectral: negative edge weight")
+			}
+			if w > 0 {
+				adj[i] = append(adj[i], neighbor{j: indexOf[vid], w: w})
+			}
+		}
+	}
+
+	r := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			r.SetSym(i, j, math.Inf(1))
+		}
+	}
+
+	seen := make([]bool, n)
+	for i := range nodes {
+		if seen[i] {
+			continue
+		}
+		// Collect the connected component holding i.
+		comp := []int{i}
+		seen[i] = true
+		for k := 0; k < len(comp); k++ {
+			for _, e := range adj[comp[k]] {
+				if !seen[e.j] {
+					seen[e.j] = true
+					comp = append(comp, e.j)
+				}
+			}
+		}
+		if len(comp) == 1 {
+			continue
+		}
+		sort.Ints(comp)
+		local := make(map[int]int, len(comp))
+		for a, j := range comp {
+			local[j] = a
+		}
+
+		k := len(comp)
+		shift := 1 / float64(k)
+		l := mat.NewSymDense(k, nil)
+		for a := 0; a < k; a++ {
+			for b := a; b < k; b++ {
+				l.SetSym(a, b, shift)
+			}
+		}
+		for a, j := range comp {
+			for _, e := range adj[j] {
+				l.SetSym(a, a, l.At(a, a)+e.w)
+				if b := local[e.j]; a < b {
+					l.SetSym(a, b, l.At(a, b)-e.w)
+				}
+			}
+		}
+		var chol mat.Cholesky
+		if !chol.Factorize(l) {
+			panic("spectral: Laplacian factorization failed")
+		}
+		var inv mat.SymDense
+		err := chol.InverseTo(&inv)
+		if c, ok := err.(mat.Condition); ok && math.IsInf(float64(c), 1) {
+			panic("spectral: Laplacian inversion failed")
+		}
+		// The J/k term of the pseudoinverse cancels
+		// in the resistance and is not subtracted.
+		for a, i := range comp {
+			for b := a + 1; b < k; b++ {
+				r.SetSym(i, comp[b], inv.At(a, a)+inv.At(b, b)-2*inv.At(a, b))
+			}
+		}
+	}
+	return r, nodes
+}
@@ -0,0 +1,131 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestResistanceMatrix(t *testing.T) {
+	// A unit triangle {0,1,2}, a pendant edge of weight 2 from
+	// 2 to 3, and an isolated edge 4-5 of weight 0.5.
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(0), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+		{F: simple.Node(4), T: simple.Node(5), W: 0.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	r, nodes := ResistanceMatrix(g)
+	if got, want := ids(nodes), []int64{0, 1, 2, 3, 4, 5}; !equalIDs(got, want) {
+		t.Fatalf("unexpected nodes: got:%v want:%v", got, want)
+	}
+	inf := math.Inf(1)
+	want := [][]float64{
+		{0, 2. / 3, 2. / 3, 2./3 + 0.5, inf, inf},
+		{2. / 3, 0, 2. / 3, 2./3 + 0.5, inf, inf},
+		{2. / 3, 2. / 3, 0, 0.5, inf, inf},
+		{2./3 + 0.5, 2./3 + 0.5, 0.5, 0, inf, inf},
+		{inf, inf, inf, inf, 0, 2},
+		{inf, inf, inf, inf, 2, 0},
+	}
+	for i, row := range want {
+		for j, w := range row {
+			if got := r.At(i, j); !floats.EqualWithinAbsOrRel(got, w, 1e-12, 1e-12) && got != w {
+				t.Errorf("unexpected resistance between %d and %d: got:%v want:%v", i, j, got, w)
+			}
+		}
+	}
+}
+
+func TestResistanceMatrixRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 50; test++ {
+		n := 1 + rnd.Intn(12)
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.3 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 0.1 + rnd.Float64()})
+				}
+			}
+		}
+
+		r, nodes := ResistanceMatrix(g)
+		for i := range nodes {
+			for j := range nodes {
+				want := groundedResistance(g, nodes, i, j)
+				if got := r.At(i, j); !floats.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) && got != want {
+					t.Errorf("unexpected resistance between %d and %d for test %d: got:%v want:%v", i, j, test, got, want)
+				}
+			}
+		}
+	}
+}
+
+// groundedResistance returns the effective resistance between nodes[i]
+// and nodes[j] found by grounding nodes[j] and injecting a unit current
+// at nodes[i] in their connected component, or +Inf if they are not
+// connected.
+func groundedResistance(g graph.WeightedUndirected, nodes []graph.Node, i, j int) float64 {
+	if i == j {
+		return 0
+	}
+	if !topo.PathExistsIn(g, nodes[i], nodes[j]) {
+		return math.Inf(1)
+	}
+	var comp []graph.Node
+	for _, v := range nodes {
+		if topo.PathExistsIn(g, nodes[i], v) {
+			comp = append(comp, v)
+		}
+	}
+	n := len(comp)
+	var src, dst int
+	l := mat.NewDense(n, n, nil)
+	for a, u := range comp {
+		switch u.ID() {
+		case nodes[i].ID():
+			src = a
+		case nodes[j].ID():
+			dst = a
+		}
+		for b, v := range comp {
+			if w, ok := g.Weight(u.ID(), v.ID()); ok && a != b {
+				l.Set(a, b, l.At(a, b)-w)
+				l.Set(a, a, l.At(a, a)+w)
+			}
+		}
+	}
+	// Replace the row of the ground node with the
+	// constraint that its potential is zero.
+	for b := 0; b < n; b++ {
+		l.Set(dst, b, 0)
+	}
+	l.Set(dst, dst, 1)
+	b := mat.NewVecDense(n, nil)
+	b.SetVec(src, 1)
+	var x mat.VecDense
+	err := x.SolveVec(l, b)
+	if err != nil {
+		panic(err)
+	}
+	return x.AtVec(src)
+}
@@ -0,0 +1,61 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+// RenameWalk performs a depth-first walk of the dominator tree dt from its
+// root in the order required for SSA variable renaming. The pre function is
+// called for a node before any of the nodes it immediately dominates are
+// walked and the post function is called for the node after all of them
+// have been walked. Nodes immediately dominated by the same node are walked
+// in order of ascending ID.
+//
+// The pre function will typically push new variable versions defined in
+// the node onto their version stacks and rename uses, and the post function
+// pops the versions pushed by the corresponding pre call. Either of pre and
+// post may be nil.
+func RenameWalk(dt path.DominatorTree, pre, post func(n graph.Node)) {
+	root := dt.Root()
+	if root == nil {
+		return
+	}
+
+	// The walk is iterative so that deep dominator
+	// trees do not exhaust the goroutine stack.
+	type frame struct {
+		node     graph.Node
+		children []graph.Node
+	}
+	var stack []frame
+	enter := func(n graph.Node) {
+		if pre != nil {
+			pre(n)
+		}
+		children := append([]graph.Node(nil), dt.DominatedBy(n.ID())...)
+		sort.Sort(ordered.ByID(children))
+		stack = append(stack, frame{node: n, children: children})
+	}
+	enter(root)
+	for len(stack) != 0 {
+		top := &stack[len(stack)-1]
+		if len(top.children) == 0 {
+			if post != nil {
+				post(top.node)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := top.children[0]
+		top.children = top.children[1:]
+		enter(next)
+	}
+}
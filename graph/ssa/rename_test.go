@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// cfg is the control flow graph from figure 5 of Cytron et al. "Efficiently
+// computing static single assignment form and the control dependence graph"
+// doi:10.1145/115372.115320 with the entry node 0 and exit node 13.
+var cfg = []simple.Edge{
+	{F: simple.Node(0), T: simple.Node(1)},
+	{F: simple.Node(0), T: simple.Node(13)},
+	{F: simple.Node(1), T: simple.Node(2)},
+	{F: simple.Node(2), T: simple.Node(3)},
+	{F: simple.Node(2), T: simple.Node(7)},
+	{F: simple.Node(3), T: simple.Node(4)},
+	{F: simple.Node(3), T: simple.Node(5)},
+	{F: simple.Node(4), T: simple.Node(6)},
+	{F: simple.Node(5), T: simple.Node(6)},
+	{F: simple.Node(6), T: simple.Node(8)},
+	{F: simple.Node(7), T: simple.Node(8)},
+	{F: simple.Node(8), T: simple.Node(9)},
+	{F: simple.Node(9), T: simple.Node(10)},
+	{F: simple.Node(9), T: simple.Node(11)},
+	{F: simple.Node(10), T: simple.Node(11)},
+	{F: simple.Node(11), T: simple.Node(9)},
+	{F: simple.Node(11), T: simple.Node(12)},
+	{F: simple.Node(12), T: simple.Node(2)},
+	{F: simple.Node(12), T: simple.Node(13)},
+}
+
+func TestRenameWalk(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range cfg {
+		g.SetEdge(e)
+	}
+	dt := path.Dominators(simple.Node(0), g)
+
+	// Variables defined in each block.
+	defs := map[int64][]string{
+		0:  {"i", "j", "k"},
+		3:  {"j"},
+		4:  {"k"},
+		5:  {"k"},
+		7:  {"j", "k"},
+		9:  {"i"},
+		10: {"k"},
+		11: {"j"},
+	}
+
+	// reaching returns the block holding the definition of v
+	// that reaches the start of block id along the dominator tree.
+	reaching := func(id int64, v string) int64 {
+		for n := dt.DominatorOf(id); n != nil; n = dt.DominatorOf(n.ID()) {
+			for _, d := range defs[n.ID()] {
+				if d == v {
+					return n.ID()
+				}
+			}
+		}
+		return -1
+	}
+
+	stacks := make(map[string][]int64)
+	var open []int64
+	seen := make(map[int64]bool)
+	RenameWalk(dt,
+		func(n graph.Node) {
+			id := n.ID()
+			if seen[id] {
+				t.Errorf("node %d visited more than once", id)
+			}
+			seen[id] = true
+			if len(open) != 0 {
+				if parent := open[len(open)-1]; dt.DominatorOf(id).ID() != parent {
+					t.Errorf("node %d entered below %d, not its immediate dominator", id, parent)
+				}
+			}
+			open = append(open, id)
+
+			for _, v := range []string{"i", "j", "k"} {
+				got := int64(-1)
+				if s := stacks[v]; len(s) != 0 {
+					got = s[len(s)-1]
+				}
+				if want := reaching(id, v); got != want {
+					t.Errorf("unexpected version of %s at entry to %d: got:%d want:%d", v, id, got, want)
+				}
+			}
+			for _, v := range defs[id] {
+				stacks[v] = append(stacks[v], id)
+			}
+		},
+		func(n graph.Node) {
+			id := n.ID()
+			if top := open[len(open)-1]; top != id {
+				t.Errorf("unexpected exit from %d: open node is %d", id, top)
+			}
+			open = open[:len(open)-1]
+			for _, v := range defs[id] {
+				stacks[v] = stacks[v][:len(stacks[v])-1]
+			}
+		},
+	)
+
+	if len(open) != 0 {
+		t.Errorf("walk ended with open nodes: %v", open)
+	}
+	if len(seen) != g.Nodes().Len() {
+		t.Errorf("unexpected number of nodes visited: got:%d want:%d", len(seen), g.Nodes().Len())
+	}
+	for v, s := range stacks {
+		if len(s) != 0 {
+			t.Errorf("version stack for %s not empty: %v", v, s)
+		}
+	}
+}
+
+func TestRenameWalkOrder(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(3)},
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(0), T: simple.Node(2)},
+		{F: simple.Node(1), T: simple.Node(5)},
+		{F: simple.Node(1), T: simple.Node(4)},
+	} {
+		g.SetEdge(e)
+	}
+	var got []int64
+	RenameWalk(path.Dominators(simple.Node(0), g),
+		func(n graph.Node) { got = append(got, n.ID()) },
+		func(n graph.Node) { got = append(got, -n.ID()-1) },
+	)
+	want := []int64{0, 1, 4, -5, 5, -6, -2, 2, -3, 3, -4, -1}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected walk: got:%v want:%v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected walk: got:%v want:%v", got, want)
+		}
+	}
+
+	// Nil hooks are permitted.
+	RenameWalk(path.Dominators(simple.Node(0), g), nil, nil)
+}
@@ -0,0 +1,367 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package strings
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+var (
+	_ graph.Directed   = (*DAWG)(nil)
+	_ graph.Multigraph = (*DAWG)(nil)
+)
+
+// DAWG is a directed acyclic word graph, the minimal deterministic finite
+// automaton accepting a finite set of strings. The states of the automaton
+// are the nodes of the graph and each transition is a Transition line
+// labeled with the rune it consumes.
+//
+// A DAWG is a read-only graph.Directed and graph.Multigraph, so it may be
+// used with the graph analysis routines. For example, since the graph is
+// acyclic, topo.Sort returns an order of its states.
+type DAWG struct {
+	g     *multi.DirectedGraph
+	root  graph.Node
+	final set.Int64s
+
+	// next holds the transitions from each state
+	// and labels holds the labels of the transitions
+	// from each state in ascending order.
+	next   map[int64]map[rune]int64
+	labels map[int64][]rune
+}
+
+// Transition is a labeled transition of a DAWG.
+type Transition struct {
+	F, T  graph.Node
+	UID   int64
+	Label rune
+}
+
+// From returns the from-node of the transition.
+func (t Transition) From() graph.Node { return t.F }
+
+// To returns the to-node of the transition.
+func (t Transition) To() graph.Node { return t.T }
+
+// ID returns the ID of the transition.
+func (t Transition) ID() int64 { return t.UID }
+
+// Attributes returns the label of the transition as an encoding attribute
+// with the key "label".
+func (t Transition) Attributes() []encoding.Attribute {
+	return []encoding.Attribute{{Key: "label", Value: string(t.Label)}}
+}
+
+// state is a state of the automaton during construction.
+type state struct {
+	final bool
+	next  map[rune]*state
+	// id is the ID of the state once it has been
+	// registered, and -1 otherwise.
+	id int64
+}
+
+func newState() *state { return &state{next: make(map[rune]*state), id: -1} }
+
+// signature returns a key that is equal for registered states
+// with the same finality and the same transitions.
+func (s *state) signature() string {
+	labels := make([]rune, 0, len(s.next))
+	for r := range s.next {
+		labels = append(labels, r)
+	}
+	sort.Sort(runes(labels))
+	b := []byte{'0'}
+	if s.final {
+		b[0] = '1'
+	}
+	for _, r := range labels {
+		b = append(b, fmt.Sprintf(" %d:%d", r, s.next[r].id)...)
+	}
+	return string(b)
+}
+
+// NewDAWG returns the minimal DAWG accepting the given words. Duplicate
+// words are accepted once. The root of the DAWG has ID 0 and the remaining
+// states are numbered in breadth-first order from the root, following
+// transitions in ascending label order.
+//
+// The DAWG is constructed using the incremental algorithm for sorted input
+// described in Daciuk et al. "Incremental construction of minimal acyclic
+// finite-state automata" doi:10.1162/089120100561601.
+func NewDAWG(words []string) *DAWG {
+	sorted := make([][]rune, len(words))
+	for i, w := range words {
+		sorted[i] = []rune(w)
+	}
+	sort.Sort(byRunes(sorted))
+
+	root := newState()
+	register := make(map[string]*state)
+
+	// replaceOrRegister minimizes the most recently added
+	// suffix of transitions reachable from s, replacing each
+	// state with an equivalent registered state if one exists.
+	var replaceOrRegister func(s *state)
+	replaceOrRegister = func(s *state) {
+		labels := make([]rune, 0, len(s.next))
+		for r := range s.next {
+			labels = append(labels, r)
+		}
+		if len(labels) == 0 {
+			return
+		}
+		sort.Sort(runes(labels))
+		last := labels[len(labels)-1]
+		child := s.next[last]
+		if child.id >= 0 {
+			return
+		}
+		replaceOrRegister(child)
+		sig := child.signature()
+		if r, ok := register[sig]; ok {
+			s.next[last] = r
+			return
+		}
+		child.id = int64(len(register))
+		register[sig] = child
+	}
+
+	var prev []rune
+	for i, w := range sorted {
+		if i != 0 && equalRunes(w, prev) {
+			continue
+		}
+		// Follow the common prefix of w and the previous word.
+		s := root
+		n := 0
+		for n < len(w) {
+			next, ok := s.next[w[n]]
+			if !ok || next.id >= 0 {
+				break
+			}
+			s = next
+			n++
+		}
+		replaceOrRegister(s)
+		for _, r := range w[n:] {
+			next := newState()
+			s.next[r] = next
+			s = next
+		}
+		s.final = true
+		prev = w
+	}
+	replaceOrRegister(root)
+
+	// Renumber the states breadth-first from the
+	// root and construct the graph representation.
+	d := &DAWG{
+		g:      multi.NewDirectedGraph(),
+		root:   multi.Node(0),
+		final:  make(set.Int64s),
+		next:   make(map[int64]map[rune]int64),
+		labels: make(map[int64][]rune),
+	}
+	idOf := map[*state]int64{root: 0}
+	queue := []*state{root}
+	d.g.AddNode(d.root)
+	var uid int64
+	for len(queue) != 0 {
+		s := queue[0]
+		queue = queue[1:]
+		id := idOf[s]
+		if s.final {
+			d.final.Add(id)
+		}
+		labels := make([]rune, 0, len(s.next))
+		for r := range s.next {
+			labels = append(labels, r)
+		}
+		sort.Sort(runes(labels))
+		d.labels[id] = labels
+		d.next[id] = make(map[rune]int64, len(labels))
+		for _, r := range labels {
+			t := s.next[r]
+			tid, ok := idOf[t]
+			if !ok {
+				tid = int64(len(idOf))
+				idOf[t] = tid
+				queue = append(queue, t)
+			}
+			d.next[id][r] = tid
+			d.g.SetLine(Transition{F: multi.Node(id), T: multi.Node(tid), UID: uid, Label: r})
+			uid++
+		}
+	}
+	return d
+}
+
+// Root returns the initial state of the DAWG.
+func (d *DAWG) Root() graph.Node { return d.root }
+
+// IsFinal returns whether the state with the given ID is accepting.
+func (d *DAWG) IsFinal(id int64) bool { return d.final.Has(id) }
+
+// Next returns the state reached from the state with the given ID by the
+// transition labeled r, and whether such a transition exists.
+func (d *DAWG) Next(id int64, r rune) (graph.Node, bool) {
+	tid, ok := d.next[id][r]
+	if !ok {
+		return nil, false
+	}
+	return d.g.Node(tid), true
+}
+
+// Contains returns whether word is accepted by the DAWG.
+func (d *DAWG) Contains(word string) bool {
+	id := d.root.ID()
+	for _, r := range word {
+		next, ok := d.next[id][r]
+		if !ok {
+			return false
+		}
+		id = next
+	}
+	return d.final.Has(id)
+}
+
+// Words returns an iterator over the words accepted by the DAWG in
+// ascending lexical order of their runes.
+func (d *DAWG) Words() *Words {
+	w := &Words{d: d}
+	w.Reset()
+	return w
+}
+
+// Node returns the state with the given ID if it exists in the DAWG,
+// and nil otherwise.
+func (d *DAWG) Node(id int64) graph.Node { return d.g.Node(id) }
+
+// Nodes returns all the states in the DAWG.
+func (d *DAWG) Nodes() graph.Nodes { return d.g.Nodes() }
+
+// From returns all states that can be reached directly from the state
+// with the given ID.
+func (d *DAWG) From(id int64) graph.Nodes { return d.g.From(id) }
+
+// To returns all states that can reach directly to the state with the
+// given ID.
+func (d *DAWG) To(id int64) graph.Nodes { return d.g.To(id) }
+
+// HasEdgeBetween returns whether a transition exists between the states
+// with IDs xid and yid without considering direction.
+func (d *DAWG) HasEdgeBetween(xid, yid int64) bool { return d.g.HasEdgeBetween(xid, yid) }
+
+// HasEdgeFromTo returns whether a transition exists from the state with
+// ID uid to the state with ID vid.
+func (d *DAWG) HasEdgeFromTo(uid, vid int64) bool { return d.g.HasEdgeFromTo(uid, vid) }
+
+// Edge returns the collection of transitions from the state with ID uid
+// to the state with ID vid if any exist and nil otherwise.
+func (d *DAWG) Edge(uid, vid int64) graph.Edge { return d.g.Edge(uid, vid) }
+
+// Lines returns the transitions from the state with ID uid to the state
+// with ID vid.
+func (d *DAWG) Lines(uid, vid int64) graph.Lines { return d.g.Lines(uid, vid) }
+
+// Words is an iterator over the words accepted by a DAWG.
+type Words struct {
+	d *DAWG
+
+	// stack holds the states on the path from the
+	// root to the current state along with the index
+	// of the next transition to follow from each.
+	stack  []wordFrame
+	prefix []rune
+	word   string
+}
+
+type wordFrame struct {
+	id   int64
+	next int
+}
+
+// Next advances the iterator to the next word and returns whether the
+// iterator holds a word.
+func (w *Words) Next() bool {
+	for len(w.stack) != 0 {
+		top := &w.stack[len(w.stack)-1]
+		if top.next < 0 {
+			// The state is being visited for the first time.
+			top.next = 0
+			if w.d.final.Has(top.id) {
+				w.word = string(w.prefix)
+				return true
+			}
+		}
+		labels := w.d.labels[top.id]
+		if top.next == len(labels) {
+			w.stack = w.stack[:len(w.stack)-1]
+			if len(w.prefix) != 0 {
+				w.prefix = w.prefix[:len(w.prefix)-1]
+			}
+			continue
+		}
+		r := labels[top.next]
+		top.next++
+		w.prefix = append(w.prefix, r)
+		w.stack = append(w.stack, wordFrame{id: w.d.next[top.id][r], next: -1})
+	}
+	w.word = ""
+	return false
+}
+
+// Word returns the current word of the iterator.
+func (w *Words) Word() string { return w.word }
+
+// Reset returns the iterator to its start position.
+func (w *Words) Reset() {
+	w.stack = append(w.stack[:0], wordFrame{id: w.d.root.ID(), next: -1})
+	w.prefix = w.prefix[:0]
+	w.word = ""
+}
+
+// runes sorts a slice of runes in ascending order.
+type runes []rune
+
+func (r runes) Len() int           { return len(r) }
+func (r runes) Less(i, j int) bool { return r[i] < r[j] }
+func (r runes) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+// byRunes sorts rune slices in ascending lexical order.
+type byRunes [][]rune
+
+func (w byRunes) Len() int           { return len(w) }
+func (w byRunes) Less(i, j int) bool { return lessRunes(w[i], w[j]) }
+func (w byRunes) Swap(i, j int)      { w[i], w[j] = w[j], w[i] }
+
+func lessRunes(a, b []rune) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
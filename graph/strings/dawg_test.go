@@ -0,0 +1,175 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package strings
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var dawgTests = []struct {
+	name  string
+	words []string
+	// states is the number of states in
+	// the minimal automaton.
+	states int
+}{
+	{name: "empty", words: nil, states: 1},
+	{name: "empty word", words: []string{""}, states: 1},
+	{name: "single", words: []string{"abc"}, states: 4},
+	{name: "shared suffix", words: []string{"tap", "taps", "top", "tops"}, states: 5},
+	{name: "parallel", words: []string{"a", "b", "c"}, states: 2},
+	{name: "duplicates", words: []string{"ab", "ab", "b", "ab"}, states: 3},
+	{name: "prefixes", words: []string{"", "a", "ab", "abc"}, states: 4},
+	{name: "unicode", words: []string{"héllo", "hallo", "hullo"}, states: 6},
+}
+
+func TestDAWG(t *testing.T) {
+	for _, test := range dawgTests {
+		d := NewDAWG(test.words)
+		checkDAWG(t, test.name, d, test.words)
+		if got := d.Nodes().Len(); got != test.states {
+			t.Errorf("unexpected number of states for %s: got:%d want:%d", test.name, got, test.states)
+		}
+	}
+}
+
+func TestDAWGRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		words := make([]string, rnd.Intn(30))
+		for j := range words {
+			b := make([]byte, rnd.Intn(6))
+			for k := range b {
+				b[k] = 'a' + byte(rnd.Intn(3))
+			}
+			words[j] = string(b)
+		}
+		d := NewDAWG(words)
+		checkDAWG(t, "random", d, words)
+		if got, want := d.Nodes().Len(), minimalStates(words); got != want {
+			t.Errorf("unexpected number of states for %q: got:%d want:%d", words, got, want)
+		}
+
+		// Words not in the set must be rejected.
+		in := make(map[string]bool)
+		for _, w := range words {
+			in[w] = true
+		}
+		for j := 0; j < 20; j++ {
+			b := make([]byte, rnd.Intn(7))
+			for k := range b {
+				b[k] = 'a' + byte(rnd.Intn(4))
+			}
+			if d.Contains(string(b)) != in[string(b)] {
+				t.Errorf("unexpected acceptance of %q for %q: got:%t", b, words, !in[string(b)])
+			}
+		}
+	}
+}
+
+// checkDAWG checks that d accepts exactly words, that its states form
+// an acyclic graph reachable from the root and that the transitions
+// are consistent with the graph.
+func checkDAWG(t *testing.T, name string, d *DAWG, words []string) {
+	t.Helper()
+
+	want := make([]string, 0, len(words))
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			want = append(want, w)
+		}
+		if !d.Contains(w) {
+			t.Errorf("%s: expected %q to be accepted", name, w)
+		}
+	}
+	sort.Strings(want)
+	var got []string
+	it := d.Words()
+	for it.Next() {
+		got = append(got, it.Word())
+	}
+	if len(got) == 0 {
+		got = []string{}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: unexpected words: got:%q want:%q", name, got, want)
+	}
+	it.Reset()
+	if len(want) != 0 && (!it.Next() || it.Word() != want[0]) {
+		t.Errorf("%s: unexpected first word after reset: got:%q want:%q", name, it.Word(), want[0])
+	}
+
+	if _, err := topo.Sort(d); err != nil {
+		t.Errorf("%s: DAWG is not acyclic: %v", name, err)
+	}
+	if d.Root().ID() != 0 {
+		t.Errorf("%s: unexpected root ID: got:%d want:0", name, d.Root().ID())
+	}
+
+	nodes := graph.NodesOf(d.Nodes())
+	for _, u := range nodes {
+		if u.ID() != d.Root().ID() && d.To(u.ID()).Len() == 0 {
+			t.Errorf("%s: state %d is not reachable", name, u.ID())
+		}
+		if u.ID() != d.Root().ID() && d.From(u.ID()).Len() == 0 && !d.IsFinal(u.ID()) {
+			t.Errorf("%s: state %d is a dead state", name, u.ID())
+		}
+		for _, v := range graph.NodesOf(d.From(u.ID())) {
+			lines := d.Lines(u.ID(), v.ID())
+			for lines.Next() {
+				l := lines.Line().(Transition)
+				next, ok := d.Next(u.ID(), l.Label)
+				if !ok || next.ID() != v.ID() {
+					t.Errorf("%s: transition %d-%q->%d not returned by Next", name, u.ID(), l.Label, v.ID())
+				}
+				attrs := l.Attributes()
+				if len(attrs) != 1 || attrs[0] != (encoding.Attribute{Key: "label", Value: string(l.Label)}) {
+					t.Errorf("%s: unexpected transition attributes: %v", name, attrs)
+				}
+			}
+		}
+	}
+}
+
+// minimalStates returns the number of states of the minimal automaton
+// accepting words, the number of distinct non-empty sets of suffixes
+// that complete a prefix of a word to a word.
+func minimalStates(words []string) int {
+	residuals := make(map[string]bool)
+	for _, w := range words {
+		for i := 0; i <= len(w); i++ {
+			var suffixes []string
+			for _, v := range words {
+				if len(v) >= i && v[:i] == w[:i] {
+					suffixes = append(suffixes, v[i:])
+				}
+			}
+			sort.Strings(suffixes)
+			var key string
+			for j, s := range suffixes {
+				if j == 0 || s != suffixes[j-1] {
+					key += s + "|"
+				}
+			}
+			residuals[key] = true
+		}
+	}
+	if len(residuals) == 0 {
+		// The automaton for the empty language
+		// has only its root.
+		return 1
+	}
+	return len(residuals)
+}
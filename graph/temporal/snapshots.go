@@ -0,0 +1,251 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Delta is the change to a graph between two consecutive time steps. When a
+// Delta is applied to a graph, the edges in RemoveEdges are removed first,
+// then the nodes in RemoveNodes are removed along with their edges, then the
+// nodes in AddNodes are added and finally the edges in AddEdges are set.
+// Nodes and edges are matched by ID. Setting an edge that is already in the
+// graph replaces it, so a change of edge weight is an element of AddEdges.
+type Delta struct {
+	RemoveEdges []graph.Edge
+	RemoveNodes []graph.Node
+	AddNodes    []graph.Node
+	AddEdges    []graph.Edge
+}
+
+// Diff returns the Delta that transforms the graph from into the graph to.
+// Both graphs must be directed or both undirected, and if they are weighted
+// an edge held by both with different weights is included in AddEdges.
+// The nodes and edges of the Delta are ordered by ID.
+func Diff(from, to graph.Graph) Delta {
+	var d Delta
+	fromNodes := sortedNodes(from)
+	toNodes := sortedNodes(to)
+	for _, n := range fromNodes {
+		if to.Node(n.ID()) == nil {
+			d.RemoveNodes = append(d.RemoveNodes, n)
+		}
+	}
+	for _, n := range toNodes {
+		if from.Node(n.ID()) == nil {
+			d.AddNodes = append(d.AddNodes, n)
+		}
+	}
+
+	_, directed := from.(graph.Directed)
+	fw, weighted := from.(graph.Weighted)
+	tw, _ := to.(graph.Weighted)
+	eachEdge(from, fromNodes, directed, func(e graph.Edge) {
+		uid, vid := e.From().ID(), e.To().ID()
+		if to.Node(uid) == nil || to.Node(vid) == nil {
+			// The edge is removed with its end points.
+			return
+		}
+		if to.Edge(uid, vid) == nil {
+			d.RemoveEdges = append(d.RemoveEdges, e)
+		}
+	})
+	eachEdge(to, toNodes, directed, func(e graph.Edge) {
+		uid, vid := e.From().ID(), e.To().ID()
+		switch {
+		case from.Edge(uid, vid) == nil:
+			d.AddEdges = append(d.AddEdges, e)
+		case weighted:
+			a, _ := fw.Weight(uid, vid)
+			b, _ := tw.Weight(uid, vid)
+			if a != b {
+				d.AddEdges = append(d.AddEdges, e)
+			}
+		}
+	})
+	return d
+}
+
+// Snapshots is a sequence of graphs over time steps 0, 1, ..., Len()-1,
+// held as the graph at time 0 followed by a Delta for each later step.
+//
+// Storing a full copy of the graph for every time step needs memory in
+// O(T(|V|+|E|)) for T steps but gives constant time access to each graph,
+// while storing only the deltas needs memory in O(|V|+|E|+D), where D is
+// the total size of the deltas, but reconstructing the graph at time t takes
+// time proportional to the size of the deltas up to t. Snapshots balances
+// these by keeping a full copy of the graph, a checkpoint, every interval
+// time steps, so that At replays at most interval-1 deltas.
+type Snapshots struct {
+	directed bool
+	weighted bool
+
+	interval    int
+	checkpoints []mutable
+	deltas      []Delta
+}
+
+// NewSnapshots returns a new Snapshots with a copy of base as the graph at
+// time 0. The graphs of the sequence are directed if base is a graph.Directed
+// and weighted if base is a graph.Weighted, with self weight zero and absent
+// weight +Inf.
+//
+// If interval is positive, a checkpoint copy of the graph is kept for every
+// time step that is a multiple of interval. An interval of one keeps a full
+// copy of every graph. If interval is not positive, only the graph at time 0
+// and the deltas are kept.
+func NewSnapshots(base graph.Graph, interval int) *Snapshots {
+	_, directed := base.(graph.Directed)
+	_, weighted := base.(graph.Weighted)
+	s := &Snapshots{directed: directed, weighted: weighted, interval: interval}
+	s.checkpoints = []mutable{s.copyOf(base)}
+	return s
+}
+
+// Len returns the number of time steps in the sequence.
+func (s *Snapshots) Len() int { return len(s.deltas) + 1 }
+
+// Append adds a time step to the end of the sequence with the graph obtained
+// by applying d to the last graph of the sequence. If s is weighted, the edges
+// in d.AddEdges must be graph.WeightedEdge values. The Delta is retained by s
+// and must not be modified after the call.
+func (s *Snapshots) Append(d Delta) {
+	s.deltas = append(s.deltas, d)
+	t := len(s.deltas)
+	if s.interval > 0 && t%s.interval == 0 {
+		s.checkpoints = append(s.checkpoints, s.at(t))
+	}
+}
+
+// At returns the graph at time t. The returned graph is a new copy that may
+// be modified without affecting s. At will panic if t is not in [0, Len()).
+func (s *Snapshots) At(t int) graph.Graph {
+	if t < 0 || t >= s.Len() {
+		panic(fmt.Sprintf("temporal: time step %d out of range", t))
+	}
+	return s.at(t)
+}
+
+// Each calls fn with the graph at each time step in order. The graphs are
+// obtained by applying each delta in turn to a single working copy, so the
+// complete sequence is visited in time proportional to the size of the graph
+// at time 0 and of the deltas. The graph passed to fn is modified after fn
+// returns, so fn must not modify or retain it; use At for a persistent copy.
+func (s *Snapshots) Each(fn func(t int, g graph.Graph)) {
+	g := s.copyOf(s.checkpoints[0])
+	fn(0, g)
+	for i, d := range s.deltas {
+		s.apply(g, d)
+		fn(i+1, g)
+	}
+}
+
+// at returns a new copy of the graph at time t.
+func (s *Snapshots) at(t int) mutable {
+	c := 0
+	if s.interval > 0 {
+		c = t / s.interval
+		if c >= len(s.checkpoints) {
+			c = len(s.checkpoints) - 1
+		}
+	}
+	g := s.copyOf(s.checkpoints[c])
+	start := c
+	if s.interval > 0 {
+		start = c * s.interval
+	}
+	for _, d := range s.deltas[start:t] {
+		s.apply(g, d)
+	}
+	return g
+}
+
+// mutable is a graph that can have nodes and edges added and removed.
+type mutable interface {
+	graph.Graph
+	graph.NodeAdder
+	graph.NodeRemover
+	graph.EdgeRemover
+}
+
+// newGraph returns an empty graph of the kind held by s.
+func (s *Snapshots) newGraph() mutable {
+	switch {
+	case s.directed && s.weighted:
+		return simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	case s.directed:
+		return simple.NewDirectedGraph()
+	case s.weighted:
+		return simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	default:
+		return simple.NewUndirectedGraph()
+	}
+}
+
+// copyOf returns a copy of g of the kind held by s.
+func (s *Snapshots) copyOf(g graph.Graph) mutable {
+	dst := s.newGraph()
+	if s.weighted {
+		graph.CopyWeighted(dst.(graph.WeightedBuilder), g.(graph.Weighted))
+	} else {
+		graph.Copy(dst.(graph.Builder), g)
+	}
+	return dst
+}
+
+// apply applies the delta d to g.
+func (s *Snapshots) apply(g mutable, d Delta) {
+	for _, e := range d.RemoveEdges {
+		g.RemoveEdge(e.From().ID(), e.To().ID())
+	}
+	for _, n := range d.RemoveNodes {
+		g.RemoveNode(n.ID())
+	}
+	for _, n := range d.AddNodes {
+		if g.Node(n.ID()) == nil {
+			g.AddNode(n)
+		}
+	}
+	for _, e := range d.AddEdges {
+		if s.weighted {
+			we, ok := e.(graph.WeightedEdge)
+			if !ok {
+				panic("temporal: unweighted edge added to weighted graph")
+			}
+			g.(graph.WeightedEdgeAdder).SetWeightedEdge(we)
+		} else {
+			g.(graph.EdgeAdder).SetEdge(e)
+		}
+	}
+}
+
+// sortedNodes returns the nodes of g ordered by ID.
+func sortedNodes(g graph.Graph) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// eachEdge calls fn for each edge of g in order of the IDs of its end
+// points. If g is undirected, each edge is visited once.
+func eachEdge(g graph.Graph, nodes []graph.Node, directed bool, fn func(graph.Edge)) {
+	for _, u := range nodes {
+		to := graph.NodesOf(g.From(u.ID()))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			if !directed && v.ID() < u.ID() {
+				continue
+			}
+			fn(g.Edge(u.ID(), v.ID()))
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// evolve returns a sequence of steps graphs where each graph is a random
+// modification of the previous one.
+func evolve(rnd *rand.Rand, directed, weighted bool, steps int) []graph.Graph {
+	type mut interface {
+		mutable
+		Edges() graph.Edges
+	}
+	newGraph := func() mut {
+		switch {
+		case directed && weighted:
+			return simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		case directed:
+			return simple.NewDirectedGraph()
+		case weighted:
+			return simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		default:
+			return simple.NewUndirectedGraph()
+		}
+	}
+	setEdge := func(g mut, u, v int64, w float64) {
+		if weighted {
+			g.(graph.WeightedEdgeAdder).SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		} else {
+			g.(graph.EdgeAdder).SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	const n = 8
+	var seq []graph.Graph
+	g := newGraph()
+	for i := 0; i < steps; i++ {
+		next := newGraph()
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			next.AddNode(u)
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			w := 1.0
+			if weighted {
+				w = e.(graph.WeightedEdge).Weight()
+			}
+			setEdge(next, e.From().ID(), e.To().ID(), w)
+		}
+		for j := 0; j < 4; j++ {
+			u, v := int64(rnd.Intn(n)), int64(rnd.Intn(n))
+			switch rnd.Intn(4) {
+			case 0:
+				if next.Node(u) == nil {
+					next.AddNode(simple.Node(u))
+				}
+			case 1:
+				next.RemoveNode(u)
+			case 2:
+				if u != v {
+					setEdge(next, u, v, float64(rnd.Intn(3)))
+				}
+			case 3:
+				next.RemoveEdge(u, v)
+			}
+		}
+		seq = append(seq, next)
+		g = next
+	}
+	return seq
+}
+
+// describe returns a canonical description of the nodes, edges
+// and weights of g.
+func describe(g graph.Graph) string {
+	nodes := sortedNodes(g)
+	_, directed := g.(graph.Directed)
+	wg, weighted := g.(graph.Weighted)
+	var ids []int64
+	for _, n := range nodes {
+		ids = append(ids, n.ID())
+	}
+	s := fmt.Sprint(ids)
+	eachEdge(g, nodes, directed, func(e graph.Edge) {
+		uid, vid := e.From().ID(), e.To().ID()
+		if !directed && vid < uid {
+			uid, vid = vid, uid
+		}
+		s += fmt.Sprintf(" %d-%d", uid, vid)
+		if weighted {
+			w, _ := wg.Weight(uid, vid)
+			s += fmt.Sprintf(":%v", w)
+		}
+	})
+	return s
+}
+
+func TestSnapshots(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		for _, weighted := range []bool{false, true} {
+			seq := evolve(rnd, directed, weighted, 20)
+			for _, interval := range []int{0, 1, 3} {
+				s := NewSnapshots(seq[0], interval)
+				for t := 1; t < len(seq); t++ {
+					s.Append(Diff(seq[t-1], seq[t]))
+				}
+				if s.Len() != len(seq) {
+					t.Errorf("unexpected length: got:%d want:%d", s.Len(), len(seq))
+				}
+				for i, want := range seq {
+					if got := describe(s.At(i)); got != describe(want) {
+						t.Errorf("unexpected graph at %d for directed=%t weighted=%t interval=%d:\ngot: %s\nwant:%s",
+							i, directed, weighted, interval, got, describe(want))
+					}
+				}
+				var visited int
+				s.Each(func(i int, g graph.Graph) {
+					if i != visited {
+						t.Errorf("unexpected time step: got:%d want:%d", i, visited)
+					}
+					visited++
+					if got := describe(g); got != describe(seq[i]) {
+						t.Errorf("unexpected graph in Each at %d for directed=%t weighted=%t interval=%d", i, directed, weighted, interval)
+					}
+				})
+				if visited != len(seq) {
+					t.Errorf("unexpected number of steps visited: got:%d want:%d", visited, len(seq))
+				}
+			}
+		}
+	}
+}
+
+func TestSnapshotsAtIsCopy(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	s := NewSnapshots(g, 1)
+	s.Append(Delta{AddEdges: []graph.Edge{simple.Edge{F: simple.Node(1), T: simple.Node(2)}}})
+
+	a := s.At(1).(*simple.UndirectedGraph)
+	a.RemoveNode(1)
+	if got := s.At(1).Nodes().Len(); got != 3 {
+		t.Errorf("modifying returned graph changed snapshot: got %d nodes want 3", got)
+	}
+	g.RemoveNode(0)
+	if got := s.At(0).Nodes().Len(); got != 2 {
+		t.Errorf("modifying base graph changed snapshot: got %d nodes want 2", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for out of range time step")
+		}
+	}()
+	s.At(2)
+}
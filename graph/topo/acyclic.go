@@ -0,0 +1,145 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+// AcyclicView returns a read-only view of g with a feedback arc set removed,
+// so that the view is acyclic, and the edges of g that are not in the view.
+// Changes to g are reflected by the view and may introduce cycles.
+//
+// The feedback arc set is found using the greedy heuristic of Eades, Lin
+// and Smyth "A fast and effective heuristic for the feedback arc set problem"
+// doi:10.1016/0020-0190(93)90079-O, which orders the nodes of g and removes
+// the edges that point backwards in the order. Only edges that lie within a
+// strongly connected component of g are candidates for removal and self
+// edges are always removed. The returned set is not guaranteed to be minimum.
+// The removed edges are ordered by the IDs of their from and then to nodes.
+func AcyclicView(g graph.Directed) (acyclic graph.Directed, removed []graph.Edge) {
+	component := make(map[int64]int)
+	for i, c := range TarjanSCC(g) {
+		for _, n := range c {
+			component[n.ID()] = i
+		}
+	}
+
+	position := make(map[int64]int)
+	for i, n := range greedyArcOrder(g) {
+		position[n.ID()] = i
+	}
+
+	type arc struct{ from, to int64 }
+	drop := make(map[arc]bool)
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if component[uid] != component[vid] || position[uid] < position[vid] {
+				continue
+			}
+			drop[arc{from: uid, to: vid}] = true
+			removed = append(removed, g.Edge(uid, vid))
+		}
+	}
+
+	acyclic = view.NewFilteredDirected(g, nil, func(e graph.Edge) bool {
+		return !drop[arc{from: e.From().ID(), to: e.To().ID()}]
+	})
+	return acyclic, removed
+}
+
+// greedyArcOrder returns an ordering of the nodes of g constructed by the
+// Eades, Lin and Smyth heuristic. Sinks are repeatedly placed at the end of
+// the order and sources at the start; when neither remain, the node with the
+// greatest difference between out-degree and in-degree is placed at the start.
+// Self edges are ignored and ties are broken by lowest node ID.
+func greedyArcOrder(g graph.Directed) []graph.Node {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	in := make(map[int64]int, len(nodes))
+	out := make(map[int64]int, len(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			if v.ID() == uid {
+				continue
+			}
+			out[uid]++
+			in[v.ID()]++
+		}
+	}
+
+	remaining := make(map[int64]bool, len(nodes))
+	for _, n := range nodes {
+		remaining[n.ID()] = true
+	}
+	remove := func(n graph.Node) {
+		id := n.ID()
+		delete(remaining, id)
+		for _, v := range graph.NodesOf(g.From(id)) {
+			if remaining[v.ID()] {
+				in[v.ID()]--
+			}
+		}
+		for _, u := range graph.NodesOf(g.To(id)) {
+			if remaining[u.ID()] {
+				out[u.ID()]--
+			}
+		}
+	}
+
+	var head, tail []graph.Node
+	for len(remaining) != 0 {
+		progress := true
+		for progress {
+			progress = false
+			for _, n := range nodes {
+				if remaining[n.ID()] && out[n.ID()] == 0 {
+					tail = append(tail, n)
+					remove(n)
+					progress = true
+				}
+			}
+			for _, n := range nodes {
+				if remaining[n.ID()] && in[n.ID()] == 0 {
+					head = append(head, n)
+					remove(n)
+					progress = true
+				}
+			}
+		}
+		if len(remaining) == 0 {
+			break
+		}
+		var best graph.Node
+		delta := 0
+		for _, n := range nodes {
+			if !remaining[n.ID()] {
+				continue
+			}
+			if d := out[n.ID()] - in[n.ID()]; best == nil || d > delta {
+				best, delta = n, d
+			}
+		}
+		head = append(head, best)
+		remove(best)
+	}
+
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	return append(head, tail...)
+}
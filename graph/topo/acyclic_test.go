@@ -0,0 +1,123 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAcyclicView(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		g := simple.NewDirectedGraph()
+		gen.Gnp(g, 40, 0.08, rand.NewSource(seed))
+		nEdges := len(graph.EdgesOf(g.Edges()))
+
+		acyclic, removed := AcyclicView(g)
+		if _, err := Sort(acyclic); err != nil {
+			t.Errorf("view is not acyclic for seed %d: %v", seed, err)
+		}
+		if got := len(graph.EdgesOf(g.Edges())); got != nEdges {
+			t.Errorf("input graph mutated for seed %d", seed)
+		}
+
+		component := make(map[int64]int)
+		for i, c := range TarjanSCC(g) {
+			for _, n := range c {
+				component[n.ID()] = i
+			}
+		}
+		for _, e := range removed {
+			uid, vid := e.From().ID(), e.To().ID()
+			if component[uid] != component[vid] {
+				t.Errorf("removed edge %d→%d between components for seed %d", uid, vid, seed)
+			}
+			if acyclic.Edge(uid, vid) != nil {
+				t.Errorf("removed edge %d→%d present in view for seed %d", uid, vid, seed)
+			}
+		}
+
+		var kept int
+		for _, u := range graph.NodesOf(acyclic.Nodes()) {
+			for _, v := range graph.NodesOf(acyclic.From(u.ID())) {
+				if g.Edge(u.ID(), v.ID()) == nil {
+					t.Errorf("view edge %d→%d not in input for seed %d", u.ID(), v.ID(), seed)
+				}
+				kept++
+			}
+		}
+		if kept+len(removed) != nEdges {
+			t.Errorf("edges lost for seed %d: kept=%d removed=%d total=%d", seed, kept, len(removed), nEdges)
+		}
+		if acyclic.Nodes().Len() != g.Nodes().Len() {
+			t.Errorf("nodes lost for seed %d", seed)
+		}
+	}
+}
+
+func TestAcyclicViewSmall(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		edges []simple.Edge
+		want  int
+	}{
+		{
+			name:  "dag",
+			edges: []simple.Edge{{F: simple.Node(0), T: simple.Node(1)}, {F: simple.Node(1), T: simple.Node(2)}, {F: simple.Node(0), T: simple.Node(2)}},
+			want:  0,
+		},
+		{
+			name:  "cycle",
+			edges: []simple.Edge{{F: simple.Node(0), T: simple.Node(1)}, {F: simple.Node(1), T: simple.Node(2)}, {F: simple.Node(2), T: simple.Node(0)}},
+			want:  1,
+		},
+		{
+			// Two cycles sharing the edge 0→1.
+			name: "shared",
+			edges: []simple.Edge{
+				{F: simple.Node(0), T: simple.Node(1)},
+				{F: simple.Node(1), T: simple.Node(2)}, {F: simple.Node(2), T: simple.Node(0)},
+				{F: simple.Node(1), T: simple.Node(3)}, {F: simple.Node(3), T: simple.Node(0)},
+			},
+			want: 1,
+		},
+		{
+			name:  "two cycles",
+			edges: []simple.Edge{{F: simple.Node(0), T: simple.Node(1)}, {F: simple.Node(1), T: simple.Node(0)}, {F: simple.Node(1), T: simple.Node(2)}, {F: simple.Node(2), T: simple.Node(3)}, {F: simple.Node(3), T: simple.Node(2)}},
+			want:  2,
+		},
+	} {
+		g := simple.NewDirectedGraph()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		acyclic, removed := AcyclicView(g)
+		if _, err := Sort(acyclic); err != nil {
+			t.Errorf("view is not acyclic for %s: %v", test.name, err)
+		}
+		if len(removed) != test.want {
+			t.Errorf("unexpected number of removed edges for %s: got:%d want:%d", test.name, len(removed), test.want)
+		}
+	}
+}
+
+func TestAcyclicViewSelf(t *testing.T) {
+	g := multi.NewDirectedGraph()
+	g.SetLine(g.NewLine(multi.Node(0), multi.Node(0)))
+	g.SetLine(g.NewLine(multi.Node(0), multi.Node(1)))
+	acyclic, removed := AcyclicView(g)
+	if _, err := Sort(acyclic); err != nil {
+		t.Errorf("view is not acyclic: %v", err)
+	}
+	if len(removed) != 1 || removed[0].From().ID() != 0 || removed[0].To().ID() != 0 {
+		t.Errorf("unexpected removed edges: got:%v want self edge of 0", removed)
+	}
+}
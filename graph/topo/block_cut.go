@@ -0,0 +1,145 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// BlockCutTree returns the block-cut tree of the undirected graph g along
+// with the blocks and cut vertices of g. A block is a maximal biconnected
+// subgraph of g, or a bridge, or an isolated node, and a cut vertex is a node
+// whose removal increases the number of connected components of g. Self
+// edges are ignored.
+//
+// The nodes of the returned tree represent the blocks and cut vertices of g:
+// the node with ID i for i < len(blocks) represents blocks[i] and the node
+// with ID len(blocks)+j represents cutVertices[j]. A block node is joined to
+// a cut vertex node when the cut vertex is a member of the block. The tree is
+// a forest with one tree for each connected component of g.
+//
+// The nodes in each block are ordered by ID and the blocks are ordered
+// lexically by the IDs of their nodes. The cut vertices are ordered by ID.
+//
+// The blocks are found using the algorithm of Hopcroft and Tarjan
+// doi:10.1145/362248.362272.
+func BlockCutTree(g graph.Undirected) (tree graph.Undirected, blocks [][]graph.Node, cutVertices []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	b := biconnected{
+		g:     g,
+		index: make(map[int64]int, len(nodes)),
+		low:   make(map[int64]int, len(nodes)),
+		cut:   make(map[int64]bool),
+	}
+	for _, u := range nodes {
+		if b.index[u.ID()] != 0 {
+			continue
+		}
+		if b.visit(u, nil) == 0 {
+			b.blocks = append(b.blocks, []graph.Node{u})
+		}
+		b.stack = b.stack[:0]
+	}
+
+	blocks = b.blocks
+	for _, blk := range blocks {
+		sort.Sort(ordered.ByID(blk))
+	}
+	sort.Sort(ordered.BySliceIDs(blocks))
+	for _, u := range nodes {
+		if b.cut[u.ID()] {
+			cutVertices = append(cutVertices, u)
+		}
+	}
+
+	t := simple.NewUndirectedGraph()
+	cutID := make(map[int64]int64, len(cutVertices))
+	for i := range blocks {
+		t.AddNode(simple.Node(i))
+	}
+	for j, c := range cutVertices {
+		id := int64(len(blocks) + j)
+		cutID[c.ID()] = id
+		t.AddNode(simple.Node(id))
+	}
+	for i, blk := range blocks {
+		for _, u := range blk {
+			if id, ok := cutID[u.ID()]; ok {
+				t.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(id)})
+			}
+		}
+	}
+	return t, blocks, cutVertices
+}
+
+// biconnected implements the Hopcroft and Tarjan biconnected
+// component finding algorithm.
+type biconnected struct {
+	g graph.Undirected
+
+	time  int
+	index map[int64]int
+	low   map[int64]int
+	stack []graph.Node
+
+	blocks [][]graph.Node
+	cut    map[int64]bool
+}
+
+// visit performs a depth first search from u, which was reached from
+// parent, adding the blocks completed during the search and marking
+// cut vertices. It returns the number of DFS children of u.
+func (b *biconnected) visit(u, parent graph.Node) (children int) {
+	uid := u.ID()
+	b.time++
+	b.index[uid] = b.time
+	b.low[uid] = b.time
+	b.stack = append(b.stack, u)
+
+	to := graph.NodesOf(b.g.From(uid))
+	sort.Sort(ordered.ByID(to))
+	for _, v := range to {
+		vid := v.ID()
+		switch {
+		case vid == uid:
+			continue
+		case b.index[vid] == 0:
+			children++
+			b.visit(v, u)
+			if b.low[vid] < b.low[uid] {
+				b.low[uid] = b.low[vid]
+			}
+			if b.low[vid] >= b.index[uid] {
+				// u separates the subtree rooted at v, so
+				// the nodes above v on the stack and u form
+				// a block.
+				if parent != nil || children > 1 {
+					b.cut[uid] = true
+				}
+				var blk []graph.Node
+				for {
+					n := b.stack[len(b.stack)-1]
+					b.stack = b.stack[:len(b.stack)-1]
+					blk = append(blk, n)
+					if n.ID() == vid {
+						break
+					}
+				}
+				b.blocks = append(b.blocks, append(blk, u))
+			}
+		case parent == nil || vid != parent.ID():
+			if b.index[vid] < b.low[uid] {
+				b.low[uid] = b.index[vid]
+			}
+		}
+	}
+	return children
+}
@@ -0,0 +1,169 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+func TestBlockCutTree(t *testing.T) {
+	// Two triangles sharing node 2, a bridge from 4 to 5,
+	// a square 5-6-7-8 and an isolated node 9:
+	//
+	//  0       3
+	//  | \   / |
+	//  |  2    |
+	//  | /   \ |
+	//  1       4 - 5 - 6
+	//              |   |
+	//              8 - 7
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {1, 2}, {2, 0},
+		{2, 3}, {3, 4}, {4, 2},
+		{4, 5},
+		{5, 6}, {6, 7}, {7, 8}, {8, 5},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(9))
+
+	tree, blocks, cuts := BlockCutTree(g)
+	wantBlocks := "[[0 1 2] [2 3 4] [4 5] [5 6 7 8] [9]]"
+	if got := fmt.Sprint(blockIDs(blocks)); got != wantBlocks {
+		t.Errorf("unexpected blocks: got:%s want:%s", got, wantBlocks)
+	}
+	if got, want := nodeIDs(cuts), []int64{2, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected cut vertices: got:%v want:%v", got, want)
+	}
+
+	// Blocks are nodes 0-4 and cut vertices 2, 4 and 5 are nodes 5-7.
+	wantEdges := map[[2]int64]bool{
+		{0, 5}: true, {1, 5}: true,
+		{1, 6}: true, {2, 6}: true,
+		{2, 7}: true, {3, 7}: true,
+	}
+	if n := tree.Nodes().Len(); n != 8 {
+		t.Errorf("unexpected number of tree nodes: got:%d want:8", n)
+	}
+	for _, u := range graph.NodesOf(tree.Nodes()) {
+		for _, v := range graph.NodesOf(tree.From(u.ID())) {
+			e := [2]int64{u.ID(), v.ID()}
+			if e[0] > e[1] {
+				e[0], e[1] = e[1], e[0]
+			}
+			if !wantEdges[e] {
+				t.Errorf("unexpected tree edge %d-%d", e[0], e[1])
+			}
+		}
+	}
+	for e := range wantEdges {
+		if !tree.HasEdgeBetween(e[0], e[1]) {
+			t.Errorf("missing tree edge %d-%d", e[0], e[1])
+		}
+	}
+}
+
+func TestBlockCutTreeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 1 + rnd.Intn(12)
+		g := simple.NewUndirectedGraph()
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.25 {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		tree, blocks, cuts := BlockCutTree(g)
+
+		// Cut vertices are the nodes whose removal
+		// disconnects their component.
+		components := len(ConnectedComponents(g))
+		isCut := make(map[int64]bool)
+		for _, c := range cuts {
+			isCut[c.ID()] = true
+		}
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			id := u.ID()
+			removed := view.NewFilteredUndirected(g, func(n graph.Node) bool { return n.ID() != id }, nil)
+			want := len(ConnectedComponents(removed)) > components
+			if isCut[id] != want {
+				t.Errorf("unexpected cut vertex status of %d for test %d: got:%t want:%t", id, test, isCut[id], want)
+			}
+		}
+
+		// Each edge is in exactly one block, and each block
+		// with more than two nodes is biconnected.
+		edgeBlocks := make(map[[2]int64]int)
+		inBlock := make(map[int64]bool)
+		for _, blk := range blocks {
+			member := make(map[int64]bool)
+			for _, u := range blk {
+				member[u.ID()] = true
+				inBlock[u.ID()] = true
+			}
+			for _, u := range blk {
+				for _, v := range graph.NodesOf(g.From(u.ID())) {
+					if member[v.ID()] && u.ID() < v.ID() {
+						edgeBlocks[[2]int64{u.ID(), v.ID()}]++
+					}
+				}
+			}
+			if len(blk) > 2 {
+				sub := view.NewFilteredUndirected(g, func(n graph.Node) bool { return member[n.ID()] }, nil)
+				for _, u := range blk {
+					id := u.ID()
+					rest := view.NewFilteredUndirected(sub, func(n graph.Node) bool { return n.ID() != id }, nil)
+					if len(ConnectedComponents(rest)) != 1 {
+						t.Errorf("block %v not biconnected for test %d", blockIDs([][]graph.Node{blk}), test)
+					}
+				}
+			}
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			u, v := e.From().ID(), e.To().ID()
+			if u > v {
+				u, v = v, u
+			}
+			if c := edgeBlocks[[2]int64{u, v}]; c != 1 {
+				t.Errorf("edge %d-%d in %d blocks for test %d", u, v, c, test)
+			}
+		}
+		if len(inBlock) != n {
+			t.Errorf("not all nodes in blocks for test %d: got:%d want:%d", test, len(inBlock), n)
+		}
+
+		// The block-cut tree is a forest with one tree
+		// per component of g.
+		if got, want := tree.(*simple.UndirectedGraph).Edges().Len(), tree.Nodes().Len()-components; got != want {
+			t.Errorf("unexpected number of tree edges for test %d: got:%d want:%d", test, got, want)
+		}
+		if got := len(ConnectedComponents(tree)); got != components {
+			t.Errorf("unexpected number of trees for test %d: got:%d want:%d", test, got, components)
+		}
+	}
+}
+
+func blockIDs(blocks [][]graph.Node) [][]int64 {
+	ids := make([][]int64, len(blocks))
+	for i, b := range blocks {
+		ids[i] = nodeIDs(b)
+	}
+	return ids
+}
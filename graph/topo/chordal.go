@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/ordering"
+)
+
+// IsChordal returns whether the undirected graph g is chordal, that is,
+// whether every cycle of length four or more in g has a chord. A graph is
+// chordal if and only if the reverse of a lexicographic breadth-first search
+// ordering of its nodes is a perfect elimination ordering.
+func IsChordal(g graph.Undirected) bool {
+	order := ordering.LexBFS(g)
+	ordered.Reverse(order)
+	return isPerfectElimination(g, order)
+}
+
+// isPerfectElimination returns whether order is a perfect elimination ordering
+// of g, an ordering in which the neighbors of each node that follow it in the
+// ordering form a clique.
+func isPerfectElimination(g graph.Undirected, order []graph.Node) bool {
+	pos := make(map[int64]int, len(order))
+	for i, n := range order {
+		pos[n.ID()] = i
+	}
+	for i, u := range order {
+		uid := u.ID()
+
+		// It is sufficient to check that the later neighbors
+		// of u are adjacent to the earliest of them, since
+		// that node's later neighbors are checked in turn.
+		var (
+			later []graph.Node
+			first graph.Node
+		)
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			if v.ID() == uid || pos[v.ID()] < i {
+				continue
+			}
+			later = append(later, v)
+			if first == nil || pos[v.ID()] < pos[first.ID()] {
+				first = v
+			}
+		}
+		for _, v := range later {
+			if v.ID() != first.ID() && !g.HasEdgeBetween(first.ID(), v.ID()) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// cycle returns the edges of a cycle on n nodes.
+func cycle(n int) []simple.Edge {
+	var edges []simple.Edge
+	for i := 0; i < n; i++ {
+		edges = append(edges, simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % n)})
+	}
+	return edges
+}
+
+var isChordalTests = []struct {
+	name  string
+	edges []simple.Edge
+	want  bool
+}{
+	{name: "empty", want: true},
+	{name: "triangle", edges: cycle(3), want: true},
+	{name: "C4", edges: cycle(4), want: false},
+	{name: "C5", edges: cycle(5), want: false},
+	{name: "C4 with chord", edges: append(cycle(4), simple.Edge{F: simple.Node(0), T: simple.Node(2)}), want: true},
+	{
+		name: "C6 with one chord",
+		edges: append(cycle(6),
+			simple.Edge{F: simple.Node(0), T: simple.Node(3)},
+		),
+		want: false,
+	},
+	{
+		name: "triangulated C6",
+		edges: append(cycle(6),
+			simple.Edge{F: simple.Node(0), T: simple.Node(2)},
+			simple.Edge{F: simple.Node(0), T: simple.Node(3)},
+			simple.Edge{F: simple.Node(0), T: simple.Node(4)},
+		),
+		want: true,
+	},
+	{
+		name: "tree",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(2), T: simple.Node(4)},
+		},
+		want: true,
+	},
+}
+
+func TestIsChordal(t *testing.T) {
+	for _, test := range isChordalTests {
+		g := simple.NewUndirectedGraph()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		if got := IsChordal(g); got != test.want {
+			t.Errorf("unexpected result for %s: got:%t want:%t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestIsChordalRandom(t *testing.T) {
+	for seed := uint64(1); seed <= 100; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 8, 0.3+0.005*float64(seed), rand.NewSource(seed))
+		if got, want := IsChordal(g), !hasChordlessCycle(g); got != want {
+			t.Errorf("unexpected result for seed %d: got:%t want:%t", seed, got, want)
+		}
+	}
+}
+
+// hasChordlessCycle returns whether g has an induced cycle of length
+// four or more, found by exhaustive search over simple paths.
+func hasChordlessCycle(g graph.Undirected) bool {
+	nodes := graph.NodesOf(g.Nodes())
+	var path []graph.Node
+	onPath := make(map[int64]bool)
+	var extend func() bool
+	extend = func() bool {
+		u := path[len(path)-1]
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if onPath[v.ID()] {
+				continue
+			}
+			// v must not be adjacent to any path node other than
+			// u, except the start which closes a cycle.
+			closes := false
+			chorded := false
+			for i, w := range path[:len(path)-1] {
+				if !g.HasEdgeBetween(v.ID(), w.ID()) {
+					continue
+				}
+				if i == 0 {
+					closes = true
+				} else {
+					chorded = true
+				}
+			}
+			if chorded {
+				continue
+			}
+			if closes {
+				if len(path) >= 3 {
+					return true
+				}
+				continue
+			}
+			path = append(path, v)
+			onPath[v.ID()] = true
+			if extend() {
+				return true
+			}
+			delete(onPath, v.ID())
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+	for _, s := range nodes {
+		path = []graph.Node{s}
+		onPath = map[int64]bool{s.ID(): true}
+		if extend() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,455 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/ordering"
+)
+
+// IntervalGraph returns whether the undirected graph g is an interval graph
+// and, if it is, an interval representation of g. Each node of g is mapped to
+// a closed interval [lo, hi] keyed by node ID such that two distinct nodes are
+// adjacent in g if and only if their intervals intersect.
+//
+// The test uses the characterization of Fulkerson and Gross: g is an interval
+// graph if and only if it is chordal and its maximal cliques can be ordered so
+// that the cliques holding each node are consecutive. The maximal cliques are
+// found from a perfect elimination ordering and the clique ordering is found
+// by a consecutive ones test based on the overlap components of the sets of
+// cliques holding each node. The interval of a node spans the positions of its
+// cliques in the ordering.
+func IntervalGraph(g graph.Undirected) (intervals map[int64][2]float64, ok bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return make(map[int64][2]float64), true
+	}
+	if !IsChordal(g) {
+		return nil, false
+	}
+	peo := ordering.LexBFS(g)
+	ordered.Reverse(peo)
+	cliques := maximalChordalCliques(g, peo)
+
+	// Find the cliques holding each node.
+	memberOf := make(map[int64][]int, len(nodes))
+	for c, clique := range cliques {
+		for _, n := range clique {
+			memberOf[n.ID()] = append(memberOf[n.ID()], c)
+		}
+	}
+	rows := make([][]int, 0, len(nodes))
+	for _, n := range nodes {
+		rows = append(rows, memberOf[n.ID()])
+	}
+
+	order, ok := consecutiveOnesOrder(len(cliques), rows)
+	if !ok {
+		return nil, false
+	}
+	pos := make([]int, len(cliques))
+	for i, c := range order {
+		pos[c] = i
+	}
+	intervals = make(map[int64][2]float64, len(nodes))
+	for _, n := range nodes {
+		lo, hi := len(cliques), -1
+		for _, c := range memberOf[n.ID()] {
+			if pos[c] < lo {
+				lo = pos[c]
+			}
+			if pos[c] > hi {
+				hi = pos[c]
+			}
+		}
+		intervals[n.ID()] = [2]float64{float64(lo), float64(hi)}
+	}
+	return intervals, true
+}
+
+// maximalChordalCliques returns the maximal cliques of the chordal graph g
+// given a perfect elimination ordering of its nodes. Each maximal clique is
+// a node and its neighbors that follow it in the ordering. The candidate
+// clique of a node p is not maximal exactly when some node u has p as its
+// first following neighbor and one more following neighbor than p.
+func maximalChordalCliques(g graph.Undirected, peo []graph.Node) [][]graph.Node {
+	pos := make(map[int64]int, len(peo))
+	for i, n := range peo {
+		pos[n.ID()] = i
+	}
+	later := make([][]graph.Node, len(peo))
+	for i, u := range peo {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if pos[v.ID()] > i {
+				later[i] = append(later[i], v)
+			}
+		}
+	}
+	maximal := make([]bool, len(peo))
+	for i := range maximal {
+		maximal[i] = true
+	}
+	for i := range peo {
+		if len(later[i]) == 0 {
+			continue
+		}
+		first := len(peo)
+		for _, v := range later[i] {
+			if p := pos[v.ID()]; p < first {
+				first = p
+			}
+		}
+		if len(later[i]) == len(later[first])+1 {
+			maximal[first] = false
+		}
+	}
+	var cliques [][]graph.Node
+	for i, u := range peo {
+		if maximal[i] {
+			cliques = append(cliques, append([]graph.Node{u}, later[i]...))
+		}
+	}
+	return cliques
+}
+
+// consecutiveOnesOrder returns an ordering of the integers in [0, n) in which
+// the elements of each row are consecutive, and whether such an ordering
+// exists. Rows must hold distinct elements.
+//
+// The rows are partitioned into overlap components, where two rows overlap if
+// they intersect and neither contains the other. The ordering of the union of
+// each overlap component is fixed up to reversal and is built by placing the
+// rows of the component in breadth-first order of the overlap relation. The
+// unions of the components form a laminar family in which the union of a
+// component lies within a single class of the ordered partition of each
+// component whose union contains it, and the component orderings are nested
+// accordingly.
+func consecutiveOnesOrder(n int, rows [][]int) (order []int, ok bool) {
+	// Remove duplicate and empty rows.
+	sets := make([][]int, 0, len(rows))
+	for _, r := range rows {
+		if len(r) == 0 {
+			continue
+		}
+		s := append([]int(nil), r...)
+		sort.Ints(s)
+		sets = append(sets, s)
+	}
+	sort.Sort(intSlices(sets))
+	uniq := sets[:0]
+	for i, s := range sets {
+		if i == 0 || !equalInts(s, sets[i-1]) {
+			uniq = append(uniq, s)
+		}
+	}
+	sets = uniq
+
+	// Find the overlap components.
+	comp := make([]int, len(sets))
+	for i := range comp {
+		comp[i] = -1
+	}
+	var components []*c1pComponent
+	for i := range sets {
+		if comp[i] >= 0 {
+			continue
+		}
+		c := &c1pComponent{}
+		id := len(components)
+		components = append(components, c)
+		comp[i] = id
+		queue := []int{i}
+		for len(queue) != 0 {
+			r := queue[0]
+			queue = queue[1:]
+			c.rows = append(c.rows, r)
+			for j := range sets {
+				if comp[j] < 0 && overlap(sets[r], sets[j]) {
+					comp[j] = id
+					queue = append(queue, j)
+				}
+			}
+		}
+		c.classes = [][]int{append([]int(nil), sets[c.rows[0]]...)}
+		for _, r := range c.rows[1:] {
+			c.classes, ok = placeRow(c.classes, sets[r])
+			if !ok {
+				return nil, false
+			}
+		}
+		c.union = make(map[int]bool)
+		for _, r := range c.rows {
+			for _, e := range sets[r] {
+				c.union[e] = true
+			}
+		}
+	}
+
+	// Nest the components. Components are considered in order
+	// of decreasing union size and those with equal unions in
+	// order of increasing number of rows, so that the parent of
+	// each component is considered before it.
+	sort.Stable(byUnionSize(components))
+	var roots []*c1pComponent
+	for i, c := range components {
+		var parent *c1pComponent
+		for j := i - 1; j >= 0; j-- {
+			if contains(components[j].union, c.union) {
+				parent = components[j]
+				break
+			}
+		}
+		if parent == nil {
+			roots = append(roots, c)
+			continue
+		}
+		var e int
+		for e = range c.union {
+			break
+		}
+		for k, class := range parent.classes {
+			if containsInt(class, e) {
+				if parent.children == nil {
+					parent.children = make(map[int][]*c1pComponent)
+				}
+				parent.children[k] = append(parent.children[k], c)
+				break
+			}
+		}
+	}
+
+	order = make([]int, 0, n)
+	seen := make([]bool, n)
+	for _, c := range roots {
+		order = c.emit(order, seen)
+	}
+	for e, ok := range seen {
+		if !ok {
+			order = append(order, e)
+		}
+	}
+
+	// Verify the ordering.
+	pos := make([]int, n)
+	for i, e := range order {
+		pos[e] = i
+	}
+	for _, s := range sets {
+		lo, hi := n, -1
+		for _, e := range s {
+			if pos[e] < lo {
+				lo = pos[e]
+			}
+			if pos[e] > hi {
+				hi = pos[e]
+			}
+		}
+		if hi-lo+1 != len(s) {
+			return nil, false
+		}
+	}
+	return order, true
+}
+
+// c1pComponent is an overlap component of a consecutive ones problem.
+type c1pComponent struct {
+	rows     []int
+	classes  [][]int
+	union    map[int]bool
+	children map[int][]*c1pComponent
+}
+
+// byUnionSize sorts components by decreasing union size and then by
+// increasing number of rows.
+type byUnionSize []*c1pComponent
+
+func (c byUnionSize) Len() int { return len(c) }
+func (c byUnionSize) Less(i, j int) bool {
+	if len(c[i].union) != len(c[j].union) {
+		return len(c[i].union) > len(c[j].union)
+	}
+	return len(c[i].rows) < len(c[j].rows)
+}
+func (c byUnionSize) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+// emit appends the ordering of the elements of the union of c to order,
+// marking them in seen, and returns the result.
+func (c *c1pComponent) emit(order []int, seen []bool) []int {
+	for k, class := range c.classes {
+		for _, child := range c.children[k] {
+			order = child.emit(order, seen)
+		}
+		for _, e := range class {
+			if !seen[e] {
+				seen[e] = true
+				order = append(order, e)
+			}
+		}
+	}
+	return order
+}
+
+// placeRow refines the ordered partition classes so that the elements of s
+// are consecutive, adding the elements of s not yet in the partition at one
+// end. The row s must overlap a row that is a union of consecutive classes.
+// placeRow returns false if no such refinement exists.
+func placeRow(classes [][]int, s []int) ([][]int, bool) {
+	in := make(map[int]bool, len(s))
+	for _, e := range s {
+		in[e] = true
+	}
+	placed := make(map[int]bool)
+	first, last := -1, -1
+	full := make([]bool, len(classes))
+	for k, class := range classes {
+		var n int
+		for _, e := range class {
+			placed[e] = true
+			if in[e] {
+				n++
+			}
+		}
+		if n != 0 {
+			if first < 0 {
+				first = k
+			}
+			last = k
+		}
+		full[k] = n == len(class)
+	}
+	var fresh []int
+	for _, e := range s {
+		if !placed[e] {
+			fresh = append(fresh, e)
+		}
+	}
+	if first < 0 {
+		return nil, false
+	}
+	for k := first + 1; k < last; k++ {
+		if !full[k] {
+			return nil, false
+		}
+	}
+
+	// split returns the class split into the parts in and not in s,
+	// with the part in s first if inFirst is true.
+	split := func(class []int, inFirst bool) [][]int {
+		var a, b []int
+		for _, e := range class {
+			if in[e] {
+				a = append(a, e)
+			} else {
+				b = append(b, e)
+			}
+		}
+		switch {
+		case len(b) == 0:
+			return [][]int{a}
+		case inFirst:
+			return [][]int{a, b}
+		default:
+			return [][]int{b, a}
+		}
+	}
+
+	var refined [][]int
+	switch {
+	case len(fresh) == 0:
+		refined = append(refined, classes[:first]...)
+		if first == last {
+			refined = append(refined, split(classes[first], false)...)
+		} else {
+			refined = append(refined, split(classes[first], false)...)
+			refined = append(refined, classes[first+1:last]...)
+			refined = append(refined, split(classes[last], true)...)
+		}
+		refined = append(refined, classes[last+1:]...)
+	case last == len(classes)-1 && (first == last || full[last]):
+		refined = append(refined, classes[:first]...)
+		refined = append(refined, split(classes[first], false)...)
+		refined = append(refined, classes[first+1:]...)
+		refined = append(refined, fresh)
+	case first == 0 && (first == last || full[first]):
+		refined = append(refined, fresh)
+		refined = append(refined, classes[:last]...)
+		refined = append(refined, split(classes[last], true)...)
+		refined = append(refined, classes[last+1:]...)
+	default:
+		return nil, false
+	}
+	return refined, true
+}
+
+// overlap returns whether the sorted sets a and b intersect with
+// neither containing the other.
+func overlap(a, b []int) bool {
+	var n int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return n != 0 && n != len(a) && n != len(b)
+}
+
+// contains returns whether a holds every element of b.
+func contains(a, b map[int]bool) bool {
+	if len(b) > len(a) {
+		return false
+	}
+	for e := range b {
+		if !a[e] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(s []int, e int) bool {
+	for _, v := range s {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// intSlices sorts sorted int slices lexicographically.
+type intSlices [][]int
+
+func (s intSlices) Len() int { return len(s) }
+func (s intSlices) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+func (s intSlices) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,216 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+var intervalGraphTests = []struct {
+	name  string
+	edges []simple.Edge
+	nodes []simple.Node
+	want  bool
+}{
+	{name: "empty", want: true},
+	{name: "isolated", nodes: []simple.Node{0, 1, 2}, want: true},
+	{
+		name: "path",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+		},
+		want: true,
+	},
+	{name: "C4", edges: cycle(4), want: false},
+	{
+		// The subdivided claw is chordal but has an asteroidal
+		// triple formed by its leaves.
+		name: "subdivided claw",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(0), T: simple.Node(5)},
+			{F: simple.Node(5), T: simple.Node(6)},
+		},
+		want: false,
+	},
+	{
+		name: "claw",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+		},
+		want: true,
+	},
+	{
+		// The tent is chordal but not an interval graph.
+		name: "tent",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+			{F: simple.Node(1), T: simple.Node(3)},
+			{F: simple.Node(1), T: simple.Node(4)},
+			{F: simple.Node(2), T: simple.Node(4)},
+			{F: simple.Node(2), T: simple.Node(5)},
+			{F: simple.Node(0), T: simple.Node(5)},
+		},
+		want: false,
+	},
+}
+
+func TestIntervalGraph(t *testing.T) {
+	for _, test := range intervalGraphTests {
+		g := simple.NewUndirectedGraph()
+		for _, n := range test.nodes {
+			g.AddNode(n)
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		intervals, ok := IntervalGraph(g)
+		if ok != test.want {
+			t.Errorf("unexpected result for %s: got:%t want:%t", test.name, ok, test.want)
+			continue
+		}
+		if ok {
+			checkIntervals(t, test.name, g, intervals)
+		}
+	}
+}
+
+func TestIntervalGraphFromIntervals(t *testing.T) {
+	for seed := uint64(1); seed <= 50; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		type interval struct{ lo, hi int }
+		var ivs []interval
+		for i := 0; i < 25; i++ {
+			lo := rnd.Intn(40)
+			ivs = append(ivs, interval{lo: lo, hi: lo + rnd.Intn(8)})
+		}
+		g := simple.NewUndirectedGraph()
+		for i, a := range ivs {
+			g.AddNode(simple.Node(i))
+			for j, b := range ivs[:i] {
+				if a.lo <= b.hi && b.lo <= a.hi {
+					g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+				}
+			}
+		}
+		intervals, ok := IntervalGraph(g)
+		if !ok {
+			t.Errorf("interval graph not recognized for seed %d", seed)
+			continue
+		}
+		checkIntervals(t, "random intervals", g, intervals)
+	}
+}
+
+func TestIntervalGraphRandom(t *testing.T) {
+	var n int
+	for seed := uint64(1); seed <= 200; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 9, 0.35, rand.NewSource(seed))
+		want := IsChordal(g) && !hasAsteroidalTriple(g)
+		if want {
+			n++
+		}
+		intervals, ok := IntervalGraph(g)
+		if ok != want {
+			t.Errorf("unexpected result for seed %d: got:%t want:%t", seed, ok, want)
+			continue
+		}
+		if ok {
+			checkIntervals(t, "random", g, intervals)
+		}
+	}
+	if n == 0 {
+		t.Error("no interval graphs generated")
+	}
+}
+
+// checkIntervals checks that intervals is an interval representation of g.
+func checkIntervals(t *testing.T, name string, g graph.Undirected, intervals map[int64][2]float64) {
+	t.Helper()
+	nodes := graph.NodesOf(g.Nodes())
+	if len(intervals) != len(nodes) {
+		t.Errorf("unexpected number of intervals for %s: got:%d want:%d", name, len(intervals), len(nodes))
+		return
+	}
+	for i, u := range nodes {
+		a := intervals[u.ID()]
+		if a[0] > a[1] {
+			t.Errorf("invalid interval for node %d in %s: %v", u.ID(), name, a)
+		}
+		for _, v := range nodes[i+1:] {
+			b := intervals[v.ID()]
+			intersect := a[0] <= b[1] && b[0] <= a[1]
+			if adjacent := g.HasEdgeBetween(u.ID(), v.ID()); intersect != adjacent {
+				t.Errorf("interval intersection of %d %v and %d %v does not match adjacency %t in %s",
+					u.ID(), a, v.ID(), b, adjacent, name)
+			}
+		}
+	}
+}
+
+// hasAsteroidalTriple returns whether g has three pairwise non-adjacent
+// nodes such that each pair is joined by a path avoiding the neighborhood
+// of the third.
+func hasAsteroidalTriple(g graph.Undirected) bool {
+	nodes := graph.NodesOf(g.Nodes())
+
+	// component[v][u] is the index of the component holding
+	// u in g with the closed neighborhood of v removed.
+	component := make(map[int64]map[int64]int)
+	for _, v := range nodes {
+		closed := set.Int64s{v.ID(): struct{}{}}
+		for _, w := range graph.NodesOf(g.From(v.ID())) {
+			closed.Add(w.ID())
+		}
+		h := view.NewFilteredUndirected(g, func(n graph.Node) bool { return !closed.Has(n.ID()) }, nil)
+		component[v.ID()] = make(map[int64]int)
+		for i, c := range ConnectedComponents(h) {
+			for _, u := range c {
+				component[v.ID()][u.ID()] = i
+			}
+		}
+	}
+	same := func(avoid, a, b int64) bool {
+		ca, okA := component[avoid][a]
+		cb, okB := component[avoid][b]
+		return okA && okB && ca == cb
+	}
+	for i, a := range nodes {
+		for j, b := range nodes[i+1:] {
+			if g.HasEdgeBetween(a.ID(), b.ID()) {
+				continue
+			}
+			for _, c := range nodes[i+j+2:] {
+				if g.HasEdgeBetween(a.ID(), c.ID()) || g.HasEdgeBetween(b.ID(), c.ID()) {
+					continue
+				}
+				if same(c.ID(), a.ID(), b.ID()) && same(b.ID(), a.ID(), c.ID()) && same(a.ID(), b.ID(), c.ID()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// StrongOrientation returns a strongly connected orientation of the undirected
+// graph g and true if one exists. By Robbins' theorem, g has a strongly
+// connected orientation if and only if it is connected and has no bridges,
+// that is, it is 2-edge-connected. If g is not 2-edge-connected,
+// StrongOrientation returns nil and false.
+//
+// The orientation is constructed by a depth-first search of g from the node
+// with the lowest ID, orienting tree edges away from the root and all other
+// edges towards the root. Self edges are not included in the orientation.
+func StrongOrientation(g graph.Undirected) (graph.Directed, bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	dst := simple.NewDirectedGraph()
+	if len(nodes) == 0 {
+		return dst, true
+	}
+	sort.Sort(ordered.ByID(nodes))
+
+	type frame struct {
+		node   graph.Node
+		parent int64
+		to     []graph.Node
+	}
+	neighbors := func(u graph.Node) []graph.Node {
+		to := graph.NodesOf(g.From(u.ID()))
+		sort.Sort(ordered.ByID(to))
+		return to
+	}
+
+	disc := make(map[int64]int, len(nodes))
+	low := make(map[int64]int, len(nodes))
+	root := nodes[0]
+	disc[root.ID()] = 0
+	low[root.ID()] = 0
+	dst.AddNode(root)
+	stack := []frame{{node: root, parent: root.ID(), to: neighbors(root)}}
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		u := f.node
+		uid := u.ID()
+		if len(f.to) == 0 {
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			pid := stack[len(stack)-1].node.ID()
+			if low[uid] > disc[pid] {
+				// The edge to u from its parent is a bridge.
+				return nil, false
+			}
+			if low[uid] < low[pid] {
+				low[pid] = low[uid]
+			}
+			continue
+		}
+		v := f.to[0]
+		f.to = f.to[1:]
+		vid := v.ID()
+		if vid == uid || vid == f.parent {
+			continue
+		}
+		if d, ok := disc[vid]; ok {
+			if d < disc[uid] {
+				// A back edge to an ancestor.
+				dst.SetEdge(simple.Edge{F: u, T: v})
+				if d < low[uid] {
+					low[uid] = d
+				}
+			}
+			continue
+		}
+		disc[vid] = len(disc)
+		low[vid] = disc[vid]
+		dst.SetEdge(simple.Edge{F: u, T: v})
+		stack = append(stack, frame{node: v, parent: uid, to: neighbors(v)})
+	}
+
+	if len(disc) != len(nodes) {
+		// g is not connected.
+		return nil, false
+	}
+	return dst, true
+}
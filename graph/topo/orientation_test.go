@@ -0,0 +1,143 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+var strongOrientationTests = []struct {
+	name  string
+	edges []simple.Edge
+	nodes []simple.Node
+	want  bool
+}{
+	{name: "empty", want: true},
+	{name: "single", nodes: []simple.Node{0}, want: true},
+	{name: "edge", edges: []simple.Edge{{F: simple.Node(0), T: simple.Node(1)}}, want: false},
+	{
+		name: "triangle",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+		},
+		want: true,
+	},
+	{
+		name: "bridged triangles",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(5)},
+			{F: simple.Node(5), T: simple.Node(3)},
+		},
+		want: false,
+	},
+	{
+		name: "bowtie",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(2)},
+		},
+		want: true,
+	},
+	{
+		name: "disconnected",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(0)},
+		},
+		nodes: []simple.Node{3},
+		want:  false,
+	},
+}
+
+func TestStrongOrientation(t *testing.T) {
+	for _, test := range strongOrientationTests {
+		g := simple.NewUndirectedGraph()
+		for _, n := range test.nodes {
+			g.AddNode(n)
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		d, ok := StrongOrientation(g)
+		if ok != test.want {
+			t.Errorf("unexpected result for %s: got:%t want:%t", test.name, ok, test.want)
+			continue
+		}
+		if ok {
+			checkOrientation(t, test.name, g, d)
+		}
+	}
+}
+
+func TestStrongOrientationRandom(t *testing.T) {
+	for seed := uint64(1); seed <= 50; seed++ {
+		g := simple.NewUndirectedGraph()
+		gen.Gnp(g, 15, 0.3, rand.NewSource(seed))
+		want := isTwoEdgeConnected(g)
+		d, ok := StrongOrientation(g)
+		if ok != want {
+			t.Errorf("unexpected result for seed %d: got:%t want:%t", seed, ok, want)
+			continue
+		}
+		if ok {
+			checkOrientation(t, "random", g, d)
+		}
+	}
+}
+
+// checkOrientation checks that d is a strongly connected orientation of g.
+func checkOrientation(t *testing.T, name string, g *simple.UndirectedGraph, d graph.Directed) {
+	t.Helper()
+	if d.Nodes().Len() != g.Nodes().Len() {
+		t.Errorf("unexpected number of nodes for %s: got:%d want:%d", name, d.Nodes().Len(), g.Nodes().Len())
+	}
+	for _, e := range graph.EdgesOf(g.Edges()) {
+		uid, vid := e.From().ID(), e.To().ID()
+		if d.HasEdgeFromTo(uid, vid) == d.HasEdgeFromTo(vid, uid) {
+			t.Errorf("edge %d--%d not oriented exactly once for %s", uid, vid, name)
+		}
+	}
+	if sccs := TarjanSCC(d); len(sccs) > 1 {
+		t.Errorf("orientation not strongly connected for %s: %d components", name, len(sccs))
+	}
+}
+
+// isTwoEdgeConnected returns whether g is connected and
+// remains connected after the removal of any single edge.
+func isTwoEdgeConnected(g *simple.UndirectedGraph) bool {
+	if len(ConnectedComponents(g)) > 1 {
+		return false
+	}
+	for _, e := range graph.EdgesOf(g.Edges()) {
+		uid, vid := e.From().ID(), e.To().ID()
+		without := view.NewFilteredUndirected(g, nil, func(e graph.Edge) bool {
+			f, t := e.From().ID(), e.To().ID()
+			return !(f == uid && t == vid) && !(f == vid && t == uid)
+		})
+		if len(ConnectedComponents(without)) > 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/bits"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DescendantCounts returns the number of nodes other than itself that each
+// node of g can reach, keyed by node ID.
+//
+// The counts are computed over the condensation of g, the DAG of its strongly
+// connected components, by forming the reachable set of each component as the
+// union of the reachable sets of its successors in reverse topological order.
+// Reachable sets are held as sparse bitsets that store only the non-zero
+// 64-bit words, so memory use scales with the number of reachable nodes
+// rather than with the square of the number of nodes.
+func DescendantCounts(g graph.Directed) map[int64]int {
+	return reachCounts(g, g.From, false)
+}
+
+// AncestorCounts returns the number of nodes other than itself that can
+// reach each node of g, keyed by node ID. The counts are computed in the
+// same way as for DescendantCounts.
+func AncestorCounts(g graph.Directed) map[int64]int {
+	return reachCounts(g, g.To, true)
+}
+
+// reachCounts returns the number of nodes reachable from each node of g
+// by following next. If reverse is true, next follows edges backwards
+// and the strongly connected components are processed sources first.
+func reachCounts(g graph.Directed, next func(id int64) graph.Nodes, reverse bool) map[int64]int {
+	// TarjanSCC returns the components in reverse
+	// topological order, so successors come first.
+	sccs := TarjanSCC(g)
+	if reverse {
+		for i, j := 0, len(sccs)-1; i < j; i, j = i+1, j-1 {
+			sccs[i], sccs[j] = sccs[j], sccs[i]
+		}
+	}
+
+	// Number the nodes so that the members of each
+	// component are contiguous.
+	compOf := make(map[int64]int)
+	indexOf := make(map[int64]int)
+	for c, scc := range sccs {
+		for _, n := range scc {
+			compOf[n.ID()] = c
+			indexOf[n.ID()] = len(indexOf)
+		}
+	}
+
+	counts := make(map[int64]int, len(indexOf))
+	reach := make([]sparseBits, len(sccs))
+	for c, scc := range sccs {
+		var r sparseBits
+		seen := make(map[int]bool)
+		for _, u := range scc {
+			to := next(u.ID())
+			for to.Next() {
+				d := compOf[to.Node().ID()]
+				if d == c || seen[d] {
+					continue
+				}
+				seen[d] = true
+				r = r.union(reach[d])
+				for _, v := range sccs[d] {
+					r = r.add(indexOf[v.ID()])
+				}
+			}
+		}
+		reach[c] = r
+		n := r.count() + len(scc) - 1
+		for _, u := range scc {
+			counts[u.ID()] = n
+		}
+	}
+	return counts
+}
+
+// sparseBits is a set of non-negative integers held as the sorted
+// non-zero 64-bit words of a bitset.
+type sparseBits []bitWord
+
+// bitWord is a word of a sparseBits with the index of the word.
+type bitWord struct {
+	key  int
+	bits uint64
+}
+
+// add returns the receiver with i added. The receiver may be modified.
+func (s sparseBits) add(i int) sparseBits {
+	key, bit := i/64, uint64(1)<<uint(i%64)
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if s[mid].key < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(s) && s[lo].key == key {
+		s[lo].bits |= bit
+		return s
+	}
+	s = append(s, bitWord{})
+	copy(s[lo+1:], s[lo:])
+	s[lo] = bitWord{key: key, bits: bit}
+	return s
+}
+
+// union returns a new set holding the union of the receiver and t.
+func (s sparseBits) union(t sparseBits) sparseBits {
+	u := make(sparseBits, 0, len(s)+len(t))
+	i, j := 0, 0
+	for i < len(s) && j < len(t) {
+		switch {
+		case s[i].key < t[j].key:
+			u = append(u, s[i])
+			i++
+		case s[i].key > t[j].key:
+			u = append(u, t[j])
+			j++
+		default:
+			u = append(u, bitWord{key: s[i].key, bits: s[i].bits | t[j].bits})
+			i++
+			j++
+		}
+	}
+	u = append(u, s[i:]...)
+	return append(u, t[j:]...)
+}
+
+// count returns the number of elements in the set.
+func (s sparseBits) count() int {
+	var n int
+	for _, w := range s {
+		n += bits.OnesCount64(w.bits)
+	}
+	return n
+}
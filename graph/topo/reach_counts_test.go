@@ -0,0 +1,123 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestReachCounts(t *testing.T) {
+	// 0 → 1 → 3, 0 → 2 → 3, 3 ⇄ 4, 5 isolated.
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {3, 4}, {4, 3}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g.AddNode(simple.Node(5))
+
+	wantDesc := map[int64]int{0: 4, 1: 2, 2: 2, 3: 1, 4: 1, 5: 0}
+	wantAnc := map[int64]int{0: 0, 1: 1, 2: 1, 3: 4, 4: 4, 5: 0}
+	checkCounts(t, "descendant", DescendantCounts(g), wantDesc)
+	checkCounts(t, "ancestor", AncestorCounts(g), wantAnc)
+}
+
+func TestReachCountsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 1 + rnd.Intn(200)
+		g := simple.NewDirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		p := 1.5 / float64(n)
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < p {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		wantDesc := make(map[int64]int)
+		wantAnc := make(map[int64]int)
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			wantDesc[u.ID()] = reachFrom(u, g.From)
+			wantAnc[u.ID()] = reachFrom(u, g.To)
+		}
+		checkCounts(t, "descendant", DescendantCounts(g), wantDesc)
+		checkCounts(t, "ancestor", AncestorCounts(g), wantAnc)
+	}
+}
+
+// reachFrom returns the number of nodes other than u reachable from u
+// by following next.
+func reachFrom(u graph.Node, next func(int64) graph.Nodes) int {
+	seen := map[int64]bool{u.ID(): true}
+	stack := []int64{u.ID()}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range graph.NodesOf(next(v)) {
+			if !seen[w.ID()] {
+				seen[w.ID()] = true
+				stack = append(stack, w.ID())
+			}
+		}
+	}
+	return len(seen) - 1
+}
+
+func checkCounts(t *testing.T, kind string, got, want map[int64]int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("unexpected number of %s counts: got:%d want:%d", kind, len(got), len(want))
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("unexpected %s count for node %d: got:%d want:%d", kind, id, got[id], w)
+		}
+	}
+}
+
+func TestSparseBits(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		var a, b sparseBits
+		want := make(map[int]bool)
+		for j := 0; j < 50; j++ {
+			x := rnd.Intn(1000)
+			a = a.add(x)
+			want[x] = true
+			y := rnd.Intn(1000)
+			b = b.add(y)
+			want[y] = true
+		}
+		u := a.union(b)
+		if u.count() != len(want) {
+			t.Errorf("unexpected union size: got:%d want:%d", u.count(), len(want))
+		}
+		for k := 1; k < len(u); k++ {
+			if u[k-1].key >= u[k].key {
+				t.Fatalf("union words not sorted: %v", u)
+			}
+		}
+		for x := range want {
+			var found bool
+			for _, w := range u {
+				if w.key == x/64 && w.bits&(1<<uint(x%64)) != 0 {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("missing element %d in union", x)
+			}
+		}
+	}
+}
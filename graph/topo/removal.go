@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/traverse"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+// ReachableAfterRemoval returns the IDs of the nodes of the directed graph g
+// that are reachable from root when the nodes in removed are deleted from g.
+// The root is reachable from itself unless it is removed or is not in g, in
+// which case the returned map is empty. The graph g is not altered; the
+// search is performed on a filtered view of g.
+func ReachableAfterRemoval(g graph.Directed, root graph.Node, removed []graph.Node) map[int64]bool {
+	drop := make(map[int64]bool, len(removed))
+	for _, n := range removed {
+		drop[n.ID()] = true
+	}
+	v := view.NewFilteredDirected(g, func(n graph.Node) bool { return !drop[n.ID()] }, nil)
+	return reachableFrom(v, root)
+}
+
+// AffectedByRemoval returns the nodes of the directed graph g that are
+// reachable from root in g but are not reachable from root when the nodes
+// in removed are deleted from g, ordered by ID. The removed nodes themselves
+// are not included. If root is removed, every other node reachable from root
+// in g is affected.
+func AffectedByRemoval(g graph.Directed, root graph.Node, removed []graph.Node) []graph.Node {
+	drop := make(map[int64]bool, len(removed))
+	for _, n := range removed {
+		drop[n.ID()] = true
+	}
+	after := ReachableAfterRemoval(g, root, removed)
+	var affected []graph.Node
+	for id := range reachableFrom(g, root) {
+		if !after[id] && !drop[id] {
+			affected = append(affected, g.Node(id))
+		}
+	}
+	sort.Sort(ordered.ByID(affected))
+	return affected
+}
+
+// reachableFrom returns the IDs of the nodes of g reachable from root.
+func reachableFrom(g graph.Directed, root graph.Node) map[int64]bool {
+	reached := make(map[int64]bool)
+	if g.Node(root.ID()) == nil {
+		return reached
+	}
+	var w traverse.BreadthFirst
+	w.Walk(g, root, func(n graph.Node, _ int) bool {
+		reached[n.ID()] = true
+		return false
+	})
+	return reached
+}
@@ -0,0 +1,77 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestReachableAfterRemoval(t *testing.T) {
+	// A service dependency graph rooted at 0:
+	//
+	//  0 → 1 → 3 → 5
+	//  0 → 2 → 3
+	//  2 → 4 → 6
+	//  6 → 4
+	//  7 → 0
+	g := simple.NewDirectedGraph()
+	for _, e := range [][2]int64{
+		{0, 1}, {1, 3}, {3, 5}, {0, 2}, {2, 3}, {2, 4}, {4, 6}, {6, 4}, {7, 0},
+	} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	edges := g.Edges().Len()
+
+	for _, test := range []struct {
+		removed      []int64
+		wantReach    []int64
+		wantAffected []int64
+	}{
+		{removed: nil, wantReach: []int64{0, 1, 2, 3, 4, 5, 6}, wantAffected: nil},
+		{removed: []int64{1}, wantReach: []int64{0, 2, 3, 4, 5, 6}, wantAffected: nil},
+		{removed: []int64{2}, wantReach: []int64{0, 1, 3, 5}, wantAffected: []int64{4, 6}},
+		{removed: []int64{1, 2}, wantReach: []int64{0}, wantAffected: []int64{3, 4, 5, 6}},
+		{removed: []int64{3, 4}, wantReach: []int64{0, 1, 2}, wantAffected: []int64{5, 6}},
+		{removed: []int64{0}, wantReach: nil, wantAffected: []int64{1, 2, 3, 4, 5, 6}},
+		{removed: []int64{7}, wantReach: []int64{0, 1, 2, 3, 4, 5, 6}, wantAffected: nil},
+	} {
+		var removed []graph.Node
+		for _, id := range test.removed {
+			removed = append(removed, simple.Node(id))
+		}
+
+		reach := ReachableAfterRemoval(g, simple.Node(0), removed)
+		var got []int64
+		for id, ok := range reach {
+			if ok {
+				got = append(got, id)
+			}
+		}
+		sort.Sort(ordered.Int64s(got))
+		if !reflect.DeepEqual(got, test.wantReach) {
+			t.Errorf("unexpected reachable nodes after removing %v: got:%v want:%v", test.removed, got, test.wantReach)
+		}
+
+		affected := AffectedByRemoval(g, simple.Node(0), removed)
+		if got := nodeIDs(affected); len(got) != 0 || len(test.wantAffected) != 0 {
+			if !reflect.DeepEqual(got, test.wantAffected) {
+				t.Errorf("unexpected affected nodes after removing %v: got:%v want:%v", test.removed, got, test.wantAffected)
+			}
+		}
+	}
+
+	if n := g.Nodes().Len(); n != 8 || g.Edges().Len() != edges {
+		t.Errorf("graph altered: got %d nodes and %d edges", n, g.Edges().Len())
+	}
+	if reach := ReachableAfterRemoval(g, simple.Node(100), nil); len(reach) != 0 {
+		t.Errorf("unexpected reachable nodes from absent root: %v", reach)
+	}
+}
@@ -57,6 +57,60 @@ func SortStabilized(g graph.Directed, order func([]graph.Node)) (sorted []graph.
 	return sortedFrom(sccs, order)
 }
 
+// GroupedSort performs a topological sort of the condensation of the directed
+// graph g, returning its strongly connected components in 'from' to 'to' order
+// with the members of each component sorted by ID. Each component is a group of
+// nodes that depend on each other and so must be scheduled together; for every
+// edge from u to v in different components, the component holding u precedes
+// the component holding v. Where there is no unambiguous ordering of the
+// components, they are ordered lexically by node ID as for SortStabilized.
+//
+// Unlike Sort, GroupedSort does not fail when g has cycles, so the returned
+// error is always nil. It is returned so that GroupedSort has the same form
+// as the other sort functions.
+func GroupedSort(g graph.Directed) ([][]graph.Node, error) {
+	sccs := tarjanSCCstabilized(g, lexical)
+	for i, j := 0, len(sccs)-1; i < j; i, j = i+1, j-1 {
+		sccs[i], sccs[j] = sccs[j], sccs[i]
+	}
+	for _, s := range sccs {
+		lexical(s)
+	}
+	return sccs, nil
+}
+
+// SCCMembership returns a mapping from the ID of each node of the directed
+// graph g to the index of its strongly connected component. Components are
+// indexed in the order they are returned by GroupedSort, so two nodes are
+// mutually reachable if and only if they map to the same index.
+func SCCMembership(g graph.Directed) map[int64]int {
+	sccs, _ := GroupedSort(g)
+	member := make(map[int64]int)
+	for i, s := range sccs {
+		for _, n := range s {
+			member[n.ID()] = i
+		}
+	}
+	return member
+}
+
+// MutuallyReachable returns whether u and v are in the same strongly connected
+// component of the directed graph g, that is whether each can be reached from
+// the other. A node in g is mutually reachable with itself.
+//
+// MutuallyReachable exists as a helper function and performs a reachability
+// search in each direction. If many queries are being performed, the result
+// of SCCMembership should be used instead.
+func MutuallyReachable(g graph.Directed, u, v graph.Node) bool {
+	if g.Node(u.ID()) == nil || g.Node(v.ID()) == nil {
+		return false
+	}
+	if u.ID() == v.ID() {
+		return true
+	}
+	return PathExistsIn(g, u, v) && PathExistsIn(g, v, u)
+}
+
 func sortedFrom(sccs [][]graph.Node, order func([]graph.Node)) ([]graph.Node, error) {
 	sorted := make([]graph.Node, 0, len(sccs))
 	var sc Unorderable
@@ -158,6 +158,57 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestGroupedSort(t *testing.T) {
+	for i, test := range tarjanTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		groups, err := GroupedSort(g)
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+		}
+
+		var got [][]int64
+		groupOf := make(map[int64]int)
+		for j, grp := range groups {
+			if !sort.IsSorted(ordered.ByID(grp)) {
+				t.Errorf("group %d not sorted by ID for test %d: %v", j, i, grp)
+			}
+			ids := make([]int64, len(grp))
+			for k, n := range grp {
+				ids[k] = n.ID()
+				groupOf[n.ID()] = j
+			}
+			got = append(got, ids)
+		}
+		want := make([][]int64, len(test.want))
+		for j, c := range test.want {
+			want[j] = append([]int64(nil), c...)
+			sort.Sort(ordered.Int64s(want[j]))
+		}
+		sort.Sort(ordered.BySliceValues(got))
+		sort.Sort(ordered.BySliceValues(want))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected groups for test %d:\ngot: %v\nwant:%v", i, got, want)
+		}
+
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			u, v := groupOf[e.From().ID()], groupOf[e.To().ID()]
+			if u > v {
+				t.Errorf("edge %d→%d out of order for test %d: groups %d > %d",
+					e.From().ID(), e.To().ID(), i, u, v)
+			}
+		}
+	}
+}
+
 func TestTarjanSCC(t *testing.T) {
 	for i, test := range tarjanTests {
 		g := simple.NewDirectedGraph()
@@ -306,3 +357,44 @@ func TestSortStabilized(t *testing.T) {
 		}
 	}
 }
+
+func TestMutuallyReachable(t *testing.T) {
+	for i, test := range tarjanTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		want := make(map[int64]int)
+		for j, c := range test.want {
+			for _, id := range c {
+				want[id] = j
+			}
+		}
+
+		member := SCCMembership(g)
+		if len(member) != len(want) {
+			t.Errorf("unexpected number of members for test %d: got:%d want:%d", i, len(member), len(want))
+		}
+		nodes := graph.NodesOf(g.Nodes())
+		for _, u := range nodes {
+			for _, v := range nodes {
+				same := want[u.ID()] == want[v.ID()]
+				if got := member[u.ID()] == member[v.ID()]; got != same {
+					t.Errorf("unexpected membership of %d and %d for test %d: got same:%t want:%t", u.ID(), v.ID(), i, got, same)
+				}
+				if got := MutuallyReachable(g, u, v); got != same {
+					t.Errorf("unexpected mutual reachability of %d and %d for test %d: got:%t want:%t", u.ID(), v.ID(), i, got, same)
+				}
+			}
+		}
+		if MutuallyReachable(g, simple.Node(-1), simple.Node(-1)) {
+			t.Errorf("unexpected mutual reachability of absent node for test %d", i)
+		}
+	}
+}
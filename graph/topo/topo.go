@@ -5,7 +5,10 @@
 package topo
 
 import (
+	"sort"
+
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/traverse"
 )
 
@@ -66,3 +69,23 @@ func ConnectedComponents(g graph.Undirected) [][]graph.Node {
 
 	return cc
 }
+
+// SourcesAndSinks returns the sources of the directed graph g, the nodes with
+// no incoming edges, and its sinks, the nodes with no outgoing edges. Isolated
+// nodes are both sources and sinks and so are included in both lists. A node
+// with a self loop is neither a source nor a sink. The nodes in each list are
+// ordered by ID.
+func SourcesAndSinks(g graph.Directed) (sources, sinks []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, n := range nodes {
+		id := n.ID()
+		if !g.To(id).Next() {
+			sources = append(sources, n)
+		}
+		if !g.From(id).Next() {
+			sinks = append(sinks, n)
+		}
+	}
+	return sources, sinks
+}
@@ -11,6 +11,7 @@ import (
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/multi"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -173,3 +174,47 @@ func TestConnectedComponents(t *testing.T) {
 		}
 	}
 }
+
+func TestSourcesAndSinks(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(3), T: simple.Node(1)},
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(1), T: simple.Node(5)},
+		{F: simple.Node(6), T: simple.Node(7)},
+		{F: simple.Node(7), T: simple.Node(6)},
+	} {
+		g.SetEdge(e)
+	}
+	g.AddNode(simple.Node(4))
+
+	sources, sinks := SourcesAndSinks(g)
+	if got, want := nodeIDs(sources), []int64{0, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sources: got:%v want:%v", got, want)
+	}
+	if got, want := nodeIDs(sinks), []int64{2, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sinks: got:%v want:%v", got, want)
+	}
+
+	// A node with a self loop is neither a source nor a sink.
+	m := multi.NewDirectedGraph()
+	m.SetLine(m.NewLine(multi.Node(0), multi.Node(0)))
+	m.AddNode(multi.Node(1))
+	sources, sinks = SourcesAndSinks(m)
+	if got, want := nodeIDs(sources), []int64{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sources with self loop: got:%v want:%v", got, want)
+	}
+	if got, want := nodeIDs(sinks), []int64{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sinks with self loop: got:%v want:%v", got, want)
+	}
+}
+
+// nodeIDs returns the IDs of nodes.
+func nodeIDs(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// IsTree returns whether g is a tree, a connected graph without cycles. If
+// g is not a tree, the returned reason describes why, either by giving a
+// cycle in g, for example "contains cycle 1-2-3-1", by giving the number of
+// connected components of g, for example "disconnected: 2 components", or
+// by noting that g has no nodes.
+func IsTree(g graph.Undirected) (ok bool, reason string) {
+	cycle, components := cycleIn(g)
+	switch {
+	case cycle != nil:
+		return false, cycleReason(cycle)
+	case components == 0:
+		return false, "graph has no nodes"
+	case components > 1:
+		return false, fmt.Sprintf("disconnected: %d components", components)
+	}
+	return true, ""
+}
+
+// IsForest returns whether g is a forest, a graph without cycles. If g is
+// not a forest, the returned reason gives a cycle in g, for example
+// "contains cycle 1-2-3-1". A graph with no nodes is a forest.
+func IsForest(g graph.Undirected) (ok bool, reason string) {
+	cycle, _ := cycleIn(g)
+	if cycle != nil {
+		return false, cycleReason(cycle)
+	}
+	return true, ""
+}
+
+// cycleReason returns a description of the closed cycle c.
+func cycleReason(c []graph.Node) string {
+	ids := make([]string, len(c))
+	for i, n := range c {
+		ids[i] = fmt.Sprint(n.ID())
+	}
+	return "contains cycle " + strings.Join(ids, "-")
+}
+
+// cycleIn returns a cycle in g beginning and ending with the same node, or
+// nil if g is acyclic, and the number of connected components found before
+// the search ended. Nodes and their neighbors are searched in ascending ID
+// order so that the cycle returned is deterministic.
+func cycleIn(g graph.Undirected) (cycle []graph.Node, components int) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	type frame struct {
+		node      graph.Node
+		neighbors []graph.Node
+	}
+	parent := make(map[int64]graph.Node, len(nodes))
+	for _, root := range nodes {
+		if _, seen := parent[root.ID()]; seen {
+			continue
+		}
+		components++
+		parent[root.ID()] = nil
+		var stack []frame
+		enter := func(n graph.Node) {
+			neighbors := graph.NodesOf(g.From(n.ID()))
+			sort.Sort(ordered.ByID(neighbors))
+			stack = append(stack, frame{node: n, neighbors: neighbors})
+		}
+		enter(root)
+		for len(stack) != 0 {
+			top := &stack[len(stack)-1]
+			if len(top.neighbors) == 0 {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			u := top.node
+			v := top.neighbors[0]
+			top.neighbors = top.neighbors[1:]
+			if v.ID() == u.ID() {
+				return []graph.Node{u, u}, components
+			}
+			if p := parent[u.ID()]; p != nil && p.ID() == v.ID() {
+				continue
+			}
+			if _, seen := parent[v.ID()]; !seen {
+				parent[v.ID()] = u
+				enter(v)
+				continue
+			}
+			// In a depth-first search of an undirected graph, an
+			// edge to a visited node other than the parent is an
+			// edge to an ancestor, so it closes a cycle.
+			cycle = []graph.Node{v}
+			for w := u; w.ID() != v.ID(); w = parent[w.ID()] {
+				cycle = append(cycle, w)
+			}
+			return append(cycle, v), components
+		}
+	}
+	return nil, components
+}
@@ -0,0 +1,147 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var treeTests = []struct {
+	name   string
+	nodes  []int64
+	edges  []simple.Edge
+	tree   bool
+	forest bool
+	reason string
+}{
+	{
+		name:   "empty",
+		forest: true,
+		reason: "graph has no nodes",
+	},
+	{
+		name:   "single node",
+		nodes:  []int64{3},
+		tree:   true,
+		forest: true,
+	},
+	{
+		name: "star",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(0), T: simple.Node(3)},
+		},
+		tree:   true,
+		forest: true,
+	},
+	{
+		name:   "cycle",
+		edges:  cycle(4),
+		reason: "contains cycle 0-3-2-1-0",
+	},
+	{
+		name: "forest",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(2), T: simple.Node(3)},
+		},
+		nodes:  []int64{4},
+		forest: true,
+		reason: "disconnected: 3 components",
+	},
+	{
+		name: "cycle in second component",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(2), T: simple.Node(3)},
+			{F: simple.Node(3), T: simple.Node(4)},
+			{F: simple.Node(4), T: simple.Node(2)},
+		},
+		reason: "contains cycle 2-4-3-2",
+	},
+}
+
+func TestIsTree(t *testing.T) {
+	for _, test := range treeTests {
+		g := simple.NewUndirectedGraph()
+		for _, id := range test.nodes {
+			g.AddNode(simple.Node(id))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		ok, reason := IsTree(g)
+		if ok != test.tree || reason != test.reason {
+			t.Errorf("unexpected IsTree result for %s: got:%t,%q want:%t,%q", test.name, ok, reason, test.tree, test.reason)
+		}
+		ok, reason = IsForest(g)
+		wantReason := test.reason
+		if test.forest {
+			wantReason = ""
+		}
+		if ok != test.forest || reason != wantReason {
+			t.Errorf("unexpected IsForest result for %s: got:%t,%q want:%t,%q", test.name, ok, reason, test.forest, wantReason)
+		}
+	}
+}
+
+func TestIsTreeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := 1 + rnd.Intn(10)
+		g := simple.NewUndirectedGraph()
+		for j := 0; j < n; j++ {
+			g.AddNode(simple.Node(j))
+		}
+		p := rnd.Float64() * 0.4
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < p {
+					g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			}
+		}
+
+		// A graph is a forest if and only if it has exactly
+		// as many edges as nodes less components.
+		edges := len(graph.EdgesOf(g.Edges()))
+		components := len(ConnectedComponents(g))
+		wantForest := edges == n-components
+		wantTree := wantForest && components == 1
+
+		gotTree, _ := IsTree(g)
+		if gotTree != wantTree {
+			t.Errorf("unexpected IsTree result for test %d: got:%t want:%t", i, gotTree, wantTree)
+		}
+		gotForest, _ := IsForest(g)
+		if gotForest != wantForest {
+			t.Errorf("unexpected IsForest result for test %d: got:%t want:%t", i, gotForest, wantForest)
+		}
+		c, _ := cycleIn(g)
+		if wantForest != (c == nil) {
+			t.Errorf("unexpected cycle for test %d: got:%v", i, c)
+			continue
+		}
+		if c == nil {
+			continue
+		}
+		seen := make(map[int64]bool)
+		for _, u := range c[:len(c)-1] {
+			if seen[u.ID()] {
+				t.Errorf("cycle is not simple for test %d: %v", i, c)
+			}
+			seen[u.ID()] = true
+		}
+		if len(c) < 4 || c[0].ID() != c[len(c)-1].ID() || !IsPathIn(g, c) {
+			t.Errorf("returned cycle is not a cycle in g for test %d: %v", i, c)
+		}
+	}
+}
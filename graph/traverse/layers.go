@@ -0,0 +1,51 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// LayerSizes returns the number of nodes at each breadth-first depth from
+// the node from in g. The element at index d of the returned slice is the
+// number of nodes at distance d from the node from, so the first element
+// is always 1.
+func LayerSizes(g Graph, from graph.Node) []int {
+	var sizes []int
+	WalkLayers(g, from, func(_ int, layer []graph.Node) {
+		sizes = append(sizes, len(layer))
+	})
+	return sizes
+}
+
+// WalkLayers performs a breadth-first traversal of the graph g starting from
+// the node from, calling fn once for each depth of the traversal with the depth
+// and the set of nodes at that depth. The order of nodes within a layer is the
+// order in which they were discovered.
+//
+// The layer slice passed to fn is reused between calls and must not be retained
+// or modified by fn. Only the current and next layers and the set of visited
+// node IDs are held during the walk.
+func WalkLayers(g Graph, from graph.Node, fn func(depth int, layer []graph.Node)) {
+	visited := set.Int64s{from.ID(): struct{}{}}
+	layer := []graph.Node{from}
+	var next []graph.Node
+	for depth := 0; len(layer) != 0; depth++ {
+		fn(depth, layer)
+		for _, u := range layer {
+			to := g.From(u.ID())
+			for to.Next() {
+				v := to.Node()
+				if visited.Has(v.ID()) {
+					continue
+				}
+				visited.Add(v.ID())
+				next = append(next, v)
+			}
+		}
+		layer, next = next, layer[:0]
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var walkLayersTests = []struct {
+	g    []intset
+	from graph.Node
+	want [][]int64
+}{
+	{
+		g:    wpBronKerboschGraph,
+		from: simple.Node(1),
+		want: [][]int64{
+			{1},
+			{0, 2, 4},
+			{3},
+			{5},
+		},
+	},
+	{
+		g:    batageljZaversnikGraph,
+		from: simple.Node(13),
+		want: [][]int64{
+			{13},
+			{14, 15},
+			{6, 7, 8, 16, 17},
+			{11, 12, 18, 19, 20},
+			{9, 10},
+		},
+	},
+	{
+		g:    batageljZaversnikGraph,
+		from: simple.Node(0),
+		want: [][]int64{
+			{0},
+		},
+	},
+}
+
+func TestWalkLayers(t *testing.T) {
+	for i, test := range walkLayersTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		var got [][]int64
+		WalkLayers(g, test.from, func(d int, layer []graph.Node) {
+			if d != len(got) {
+				t.Errorf("unexpected depth for test %d: got:%d want:%d", i, d, len(got))
+			}
+			var ids []int64
+			for _, n := range layer {
+				ids = append(ids, n.ID())
+			}
+			sort.Sort(ordered.Int64s(ids))
+			got = append(got, ids)
+		})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected layers for test %d:\ngot:  %v\nwant: %v", i, got, test.want)
+		}
+
+		sizes := LayerSizes(g, test.from)
+		wantSizes := make([]int, len(test.want))
+		for d, l := range test.want {
+			wantSizes[d] = len(l)
+		}
+		if !reflect.DeepEqual(sizes, wantSizes) {
+			t.Errorf("unexpected layer sizes for test %d: got:%v want:%v", i, sizes, wantSizes)
+		}
+	}
+}
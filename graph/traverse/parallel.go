@@ -0,0 +1,109 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ParallelBFS performs a level-synchronous breadth-first traversal of g from
+// s, expanding the nodes of each depth concurrently, and returns the depth of
+// each node reachable from s keyed by node ID. The returned distances are the
+// same as those found by a serial breadth-first traversal. If s is not in g,
+// ParallelBFS returns nil.
+//
+// The nodes of g are relabeled internally with dense indices so that nodes can
+// be marked as visited using atomic operations without locking; this requires
+// O(|V|) additional memory regardless of the number of nodes reachable from s.
+// At most concurrency goroutines are used to expand each depth. If concurrency
+// is not positive, runtime.GOMAXPROCS(0) goroutines are used.
+//
+// The From method of g is called concurrently, so g must be safe for concurrent
+// reads.
+func ParallelBFS(g graph.Graph, s graph.Node, concurrency int) (dist map[int64]int) {
+	if g.Node(s.ID()) == nil {
+		return nil
+	}
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	depth := make([]int32, len(nodes))
+	for i := range depth {
+		depth[i] = -1
+	}
+
+	// expand returns the indices of the unvisited nodes reachable
+	// from the nodes in layer, marking them with depth d.
+	expand := func(layer []int, d int32, next []int) []int {
+		for _, u := range layer {
+			to := g.From(nodes[u].ID())
+			for to.Next() {
+				v, ok := indexOf[to.Node().ID()]
+				if !ok {
+					continue
+				}
+				if atomic.LoadInt32(&depth[v]) == -1 && atomic.CompareAndSwapInt32(&depth[v], -1, d) {
+					next = append(next, v)
+				}
+			}
+		}
+		return next
+	}
+
+	si := indexOf[s.ID()]
+	depth[si] = 0
+	layer := []int{si}
+	parts := make([][]int, concurrency)
+	var wg sync.WaitGroup
+	for d := int32(1); len(layer) != 0; d++ {
+		workers := concurrency
+		if len(layer) < workers {
+			workers = len(layer)
+		}
+		if workers == 1 {
+			layer = expand(layer, d, nil)
+			continue
+		}
+
+		for w := 0; w < workers; w++ {
+			lo := w * len(layer) / workers
+			hi := (w + 1) * len(layer) / workers
+			wg.Add(1)
+			go func(w int, chunk []int) {
+				defer wg.Done()
+				parts[w] = expand(chunk, d, parts[w][:0])
+			}(w, layer[lo:hi])
+		}
+		wg.Wait()
+
+		var n int
+		for _, p := range parts[:workers] {
+			n += len(p)
+		}
+		next := make([]int, 0, n)
+		for _, p := range parts[:workers] {
+			next = append(next, p...)
+		}
+		layer = next
+	}
+
+	dist = make(map[int64]int)
+	for i, d := range depth {
+		if d >= 0 {
+			dist[nodes[i].ID()] = int(d)
+		}
+	}
+	return dist
+}
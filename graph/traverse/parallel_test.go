@@ -0,0 +1,104 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// serialBFS returns the breadth-first depths of nodes reachable from s in g.
+func serialBFS(g Graph, s graph.Node) map[int64]int {
+	dist := make(map[int64]int)
+	var bf BreadthFirst
+	bf.Walk(g, s, func(n graph.Node, d int) bool {
+		dist[n.ID()] = d
+		return false
+	})
+	return dist
+}
+
+func TestParallelBFS(t *testing.T) {
+	for seed := uint64(1); seed <= 10; seed++ {
+		for _, directed := range []bool{false, true} {
+			var g interface {
+				graph.Graph
+				graph.Builder
+			}
+			if directed {
+				g = simple.NewDirectedGraph()
+			} else {
+				g = simple.NewUndirectedGraph()
+			}
+			gen.Gnp(g, 500, 0.01, rand.NewSource(seed))
+			for _, s := range []int64{0, 7, 499} {
+				want := serialBFS(g, simple.Node(s))
+				for _, concurrency := range []int{0, 1, 2, 3, 8, 100} {
+					got := ParallelBFS(g, simple.Node(s), concurrency)
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("unexpected distances for seed %d directed=%t from %d with concurrency %d",
+							seed, directed, s, concurrency)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParallelBFSMissing(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	if got := ParallelBFS(g, simple.Node(2), 4); got != nil {
+		t.Errorf("unexpected distances from missing node: got:%v want:nil", got)
+	}
+	g.AddNode(simple.Node(2))
+	want := map[int64]int{2: 0}
+	if got := ParallelBFS(g, simple.Node(2), 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected distances from isolated node: got:%v want:%v", got, want)
+	}
+}
+
+// wideTree returns a rooted tree with the given branching factor and depth.
+func wideTree(branching, depth int) graph.Directed {
+	g := simple.NewDirectedGraph()
+	layer := []graph.Node{g.NewNode()}
+	g.AddNode(layer[0])
+	for d := 0; d < depth; d++ {
+		var next []graph.Node
+		for _, u := range layer {
+			for i := 0; i < branching; i++ {
+				v := g.NewNode()
+				g.SetEdge(simple.Edge{F: u, T: v})
+				next = append(next, v)
+			}
+		}
+		layer = next
+	}
+	return g
+}
+
+var wideTree_50_3 = wideTree(50, 3)
+
+func BenchmarkSerialBFSWideTree(b *testing.B) {
+	g := wideTree_50_3
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		serialBFS(g, simple.Node(0))
+	}
+}
+
+func BenchmarkParallelBFSWideTree(b *testing.B) {
+	g := wideTree_50_3
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParallelBFS(g, simple.Node(0), 0)
+	}
+}
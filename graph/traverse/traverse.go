@@ -8,6 +8,7 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/linear"
 	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
 )
 
 var _ Graph = graph.Graph(nil)
@@ -29,8 +30,16 @@ type Graph interface {
 type BreadthFirst struct {
 	EdgeFilter func(graph.Edge) bool
 	Visit      func(u, v graph.Node)
-	queue      linear.NodeQueue
-	visited    set.Int64s
+
+	// Order, if not nil, is called with the nodes
+	// reachable from each traversed node and may
+	// reorder them in place. The nodes are then
+	// considered for traversal in that order.
+	Order func(nodes []graph.Node)
+
+	queue   linear.NodeQueue
+	visited set.Int64s
+	buf     []graph.Node
 }
 
 // Walk performs a breadth-first traversal of the graph g starting from the given node,
@@ -56,7 +65,7 @@ func (b *BreadthFirst) Walk(g Graph, from graph.Node, until func(n graph.Node, d
 			return t
 		}
 		tid := t.ID()
-		to := g.From(tid)
+		to := reorder(g.From(tid), b.Order, &b.buf)
 		for to.Next() {
 			n := to.Node()
 			nid := n.ID()
@@ -83,6 +92,22 @@ func (b *BreadthFirst) Walk(g Graph, from graph.Node, until func(n graph.Node, d
 	return nil
 }
 
+// reorder returns the nodes of it in the order given by order. If order is
+// nil, it is returned unaltered. Otherwise the nodes are collected into buf
+// for reordering.
+func reorder(it graph.Nodes, order func([]graph.Node), buf *[]graph.Node) graph.Nodes {
+	if order == nil {
+		return it
+	}
+	nodes := (*buf)[:0]
+	for it.Next() {
+		nodes = append(nodes, it.Node())
+	}
+	order(nodes)
+	*buf = nodes
+	return iterator.NewOrderedNodes(nodes)
+}
+
 // WalkAll calls Walk for each unvisited node of the graph g using edges independent
 // of their direction. The functions before and after are called prior to commencing
 // and after completing each walk if they are non-nil respectively. The function
@@ -125,8 +150,18 @@ func (b *BreadthFirst) Reset() {
 type DepthFirst struct {
 	EdgeFilter func(graph.Edge) bool
 	Visit      func(u, v graph.Node)
-	stack      linear.NodeStack
-	visited    set.Int64s
+
+	// Order, if not nil, is called with the nodes
+	// reachable from each traversed node and may
+	// reorder them in place. The nodes are then
+	// considered for traversal in that order. Since
+	// considered nodes are held on a stack, the last
+	// node considered is the first to be traversed.
+	Order func(nodes []graph.Node)
+
+	stack   linear.NodeStack
+	visited set.Int64s
+	buf     []graph.Node
 }
 
 // Walk performs a depth-first traversal of the graph g starting from the given node,
@@ -147,7 +182,7 @@ func (d *DepthFirst) Walk(g Graph, from graph.Node, until func(graph.Node) bool)
 			return t
 		}
 		tid := t.ID()
-		to := g.From(tid)
+		to := reorder(g.From(tid), d.Order, &d.buf)
 		for to.Next() {
 			n := to.Node()
 			nid := n.ID()
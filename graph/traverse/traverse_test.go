@@ -252,6 +252,96 @@ func TestDepthFirst(t *testing.T) {
 	}
 }
 
+func TestTraverseOrder(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for u, e := range wpBronKerboschGraph {
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	ascending := func(nodes []graph.Node) { sort.Sort(ordered.ByID(nodes)) }
+	descending := func(nodes []graph.Node) { sort.Sort(sort.Reverse(ordered.ByID(nodes))) }
+
+	for _, test := range []struct {
+		name  string
+		order func([]graph.Node)
+		bfs   []int64
+		dfs   []int64
+	}{
+		{name: "ascending", order: ascending, bfs: []int64{1, 0, 2, 4, 3, 5}, dfs: []int64{1, 4, 3, 5, 2, 0}},
+		{name: "descending", order: descending, bfs: []int64{1, 4, 2, 0, 3, 5}, dfs: []int64{1, 0, 2, 3, 5, 4}},
+	} {
+		for i := 0; i < 5; i++ {
+			var got []int64
+			b := BreadthFirst{Order: test.order}
+			b.Walk(g, simple.Node(1), func(n graph.Node, _ int) bool {
+				got = append(got, n.ID())
+				return false
+			})
+			if !reflect.DeepEqual(got, test.bfs) {
+				t.Errorf("unexpected %s BFS order: got:%v want:%v", test.name, got, test.bfs)
+			}
+
+			got = got[:0]
+			d := DepthFirst{Order: test.order}
+			d.Walk(g, simple.Node(1), func(n graph.Node) bool {
+				got = append(got, n.ID())
+				return false
+			})
+			if !reflect.DeepEqual(got, test.dfs) {
+				t.Errorf("unexpected %s DFS order: got:%v want:%v", test.name, got, test.dfs)
+			}
+		}
+	}
+}
+
+func TestTraverseOrderVisitsSameNodes(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for u, e := range batageljZaversnikGraph {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	reverse := func(nodes []graph.Node) {
+		for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+			nodes[i], nodes[j] = nodes[j], nodes[i]
+		}
+	}
+	for _, order := range []func([]graph.Node){nil, reverse} {
+		for from := int64(0); from < int64(len(batageljZaversnikGraph)); from++ {
+			var want, got []int64
+			bfs := func(b *BreadthFirst) []int64 {
+				var ids []int64
+				b.Walk(g, simple.Node(from), func(n graph.Node, _ int) bool {
+					ids = append(ids, n.ID())
+					return false
+				})
+				sort.Sort(ordered.Int64s(ids))
+				return ids
+			}
+			want = bfs(&BreadthFirst{})
+			got = bfs(&BreadthFirst{Order: order})
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected BFS visited nodes from %d: got:%v want:%v", from, got, want)
+			}
+
+			got = got[:0]
+			d := DepthFirst{Order: order}
+			d.Walk(g, simple.Node(from), func(n graph.Node) bool {
+				got = append(got, n.ID())
+				return false
+			})
+			sort.Sort(ordered.Int64s(got))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected DFS visited nodes from %d: got:%v want:%v", from, got, want)
+			}
+		}
+	}
+}
+
 var walkAllTests = []struct {
 	g    []intset
 	edge func(graph.Edge) bool
@@ -0,0 +1,375 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+var (
+	_ graph.Undirected         = combinedUndirected{}
+	_ graph.Directed           = combinedDirected{}
+	_ graph.WeightedUndirected = combinedWeightedUndirected{}
+	_ graph.WeightedDirected   = combinedWeightedDirected{}
+)
+
+// setOp is a graph set operation.
+type setOp int
+
+const (
+	union setOp = iota
+	intersection
+	difference
+)
+
+// Union returns a read-only view of the union of a and b. The view holds
+// every node that is in either a or b and every edge that is in either a
+// or b, with nodes and edges matched by ID. Where a node or an edge is
+// present in both graphs, the one in a is returned.
+//
+// If a and b are both directed, the returned view is a graph.Directed. If
+// a and b are both weighted, the returned view is a graph.Weighted and the
+// weight of an edge held by both graphs is the weight in a; WeightedUnion
+// allows the weights of shared edges to be combined.
+//
+// Union panics if exactly one of a and b is directed.
+func Union(a, b graph.Graph) graph.Graph {
+	return newCombined(a, b, union, nil)
+}
+
+// WeightedUnion returns a read-only weighted view of the union of a and b
+// with the same semantics as Union. The weight of an edge held by both
+// graphs is combine(wa, wb) where wa and wb are the weights of the edge in
+// a and b. If combine is nil, the weight in a is used.
+func WeightedUnion(a, b graph.Weighted, combine func(wa, wb float64) float64) graph.Weighted {
+	return newCombined(a, b, union, combine).(graph.Weighted)
+}
+
+// Intersection returns a read-only view of the intersection of a and b.
+// The view holds the nodes that are in both a and b and the edges that are
+// in both a and b, with nodes and edges matched by ID. The nodes and edges
+// returned by the view are those of a.
+//
+// If a and b are both directed, the returned view is a graph.Directed. If
+// a and b are both weighted, the returned view is a graph.Weighted and the
+// weight of each edge is its weight in a; WeightedIntersection allows the
+// weights of the edges to be combined.
+//
+// Intersection panics if exactly one of a and b is directed.
+func Intersection(a, b graph.Graph) graph.Graph {
+	return newCombined(a, b, intersection, nil)
+}
+
+// WeightedIntersection returns a read-only weighted view of the intersection
+// of a and b with the same semantics as Intersection. The weight of each edge
+// is combine(wa, wb) where wa and wb are the weights of the edge in a and b.
+// If combine is nil, the weight in a is used.
+func WeightedIntersection(a, b graph.Weighted, combine func(wa, wb float64) float64) graph.Weighted {
+	return newCombined(a, b, intersection, combine).(graph.Weighted)
+}
+
+// Difference returns a read-only view of the difference of a and b. The view
+// holds every node in a and the edges in a that are not in b, with edges
+// matched by the IDs of their end points.
+//
+// If a and b are both directed, the returned view is a graph.Directed. If a is
+// weighted, the returned view is a graph.Weighted holding the weights of a.
+//
+// Difference panics if exactly one of a and b is directed.
+func Difference(a, b graph.Graph) graph.Graph {
+	return newCombined(a, b, difference, nil)
+}
+
+// newCombined returns a view of the result of applying op to a and b with
+// the graph interfaces that are appropriate for the operation and inputs.
+func newCombined(a, b graph.Graph, op setOp, combine func(wa, wb float64) float64) graph.Graph {
+	_, aDirected := a.(graph.Directed)
+	_, bDirected := b.(graph.Directed)
+	if aDirected != bDirected {
+		panic("view: mismatched graph directedness")
+	}
+	_, aWeighted := a.(graph.Weighted)
+	_, bWeighted := b.(graph.Weighted)
+	weighted := aWeighted && (bWeighted || op == difference)
+
+	c := &combined{a: a, b: b, op: op, combine: combine}
+	switch {
+	case aDirected && weighted:
+		return combinedWeightedDirected{combinedWeighted{c}}
+	case aDirected:
+		return combinedDirected{c}
+	case weighted:
+		return combinedWeightedUndirected{combinedWeighted{c}}
+	default:
+		return combinedUndirected{c}
+	}
+}
+
+// combined is a read-only view of a set operation on two graphs.
+type combined struct {
+	a, b    graph.Graph
+	op      setOp
+	combine func(wa, wb float64) float64
+}
+
+// hasEdge returns whether the edge from u to v is in the view.
+func (c *combined) hasEdge(uid, vid int64) bool {
+	switch c.op {
+	case union:
+		return c.a.Edge(uid, vid) != nil || c.b.Edge(uid, vid) != nil
+	case intersection:
+		return c.a.Edge(uid, vid) != nil && c.b.Edge(uid, vid) != nil
+	case difference:
+		return c.a.Edge(uid, vid) != nil && c.b.Edge(uid, vid) == nil
+	default:
+		panic("view: invalid set operation")
+	}
+}
+
+// Node returns the node with the given ID if it exists in the view,
+// and nil otherwise.
+func (c *combined) Node(id int64) graph.Node {
+	n := c.a.Node(id)
+	switch c.op {
+	case union:
+		if n == nil {
+			return c.b.Node(id)
+		}
+	case intersection:
+		if c.b.Node(id) == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Nodes returns all the nodes in the view.
+func (c *combined) Nodes() graph.Nodes {
+	switch c.op {
+	case union:
+		nodes := graph.NodesOf(c.a.Nodes())
+		it := c.b.Nodes()
+		for it.Next() {
+			n := it.Node()
+			if c.a.Node(n.ID()) == nil {
+				nodes = append(nodes, n)
+			}
+		}
+		if len(nodes) == 0 {
+			return graph.Empty
+		}
+		return iterator.NewOrderedNodes(nodes)
+	case intersection:
+		return filterNodes(c.a.Nodes(), func(n graph.Node) bool {
+			return c.b.Node(n.ID()) != nil
+		})
+	default:
+		return c.a.Nodes()
+	}
+}
+
+// From returns all nodes in the view that can be reached directly from
+// the node with the given ID.
+func (c *combined) From(id int64) graph.Nodes {
+	return c.adjacent(id, func(g graph.Graph) graph.Nodes {
+		return g.From(id)
+	}, func(vid int64) bool {
+		return c.hasEdge(id, vid)
+	})
+}
+
+// to returns all nodes in the view that can reach directly to the node
+// with the given ID. It must only be called when a and b are directed.
+func (c *combined) to(id int64) graph.Nodes {
+	return c.adjacent(id, func(g graph.Graph) graph.Nodes {
+		return g.(graph.Directed).To(id)
+	}, func(uid int64) bool {
+		return c.hasEdge(uid, id)
+	})
+}
+
+// adjacent returns the nodes adjacent to the node with the given ID as
+// reported by next for each of the input graphs and accepted by ok.
+func (c *combined) adjacent(id int64, next func(graph.Graph) graph.Nodes, ok func(int64) bool) graph.Nodes {
+	if c.Node(id) == nil {
+		return graph.Empty
+	}
+	var nodes []graph.Node
+	seen := make(set.Int64s)
+	if c.a.Node(id) != nil {
+		it := next(c.a)
+		for it.Next() {
+			n := it.Node()
+			if ok(n.ID()) {
+				seen.Add(n.ID())
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	if c.op == union && c.b.Node(id) != nil {
+		it := next(c.b)
+		for it.Next() {
+			n := it.Node()
+			if seen.Has(n.ID()) {
+				continue
+			}
+			if an := c.a.Node(n.ID()); an != nil {
+				n = an
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+// HasEdgeBetween returns whether an edge exists in the view between nodes
+// with IDs xid and yid without considering direction.
+func (c *combined) HasEdgeBetween(xid, yid int64) bool {
+	return c.hasEdge(xid, yid) || c.hasEdge(yid, xid)
+}
+
+// Edge returns the edge from u to v, with IDs uid and vid, if such an edge
+// exists in the view and nil otherwise.
+func (c *combined) Edge(uid, vid int64) graph.Edge {
+	if !c.hasEdge(uid, vid) {
+		return nil
+	}
+	e := c.a.Edge(uid, vid)
+	if e == nil {
+		return c.b.Edge(uid, vid)
+	}
+	return e
+}
+
+// combinedUndirected is a read-only view of a set operation on two
+// undirected graphs.
+type combinedUndirected struct {
+	*combined
+}
+
+// EdgeBetween returns the edge between nodes with IDs xid and yid if such
+// an edge exists in the view and nil otherwise.
+func (c combinedUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	return c.Edge(xid, yid)
+}
+
+// combinedDirected is a read-only view of a set operation on two directed
+// graphs.
+type combinedDirected struct {
+	*combined
+}
+
+// HasEdgeFromTo returns whether an edge exists in the view from u to v
+// with IDs uid and vid.
+func (c combinedDirected) HasEdgeFromTo(uid, vid int64) bool {
+	return c.hasEdge(uid, vid)
+}
+
+// To returns all nodes in the view that can reach directly to the node
+// with the given ID.
+func (c combinedDirected) To(id int64) graph.Nodes {
+	return c.to(id)
+}
+
+// combinedWeighted is a read-only view of a set operation on two weighted
+// graphs.
+type combinedWeighted struct {
+	*combined
+}
+
+// WeightedEdge returns the weighted edge from u to v with IDs uid and vid
+// if such an edge exists in the view and nil otherwise.
+func (c combinedWeighted) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	if !c.hasEdge(uid, vid) {
+		return nil
+	}
+	w, _ := c.Weight(uid, vid)
+	return weightedEdge{Edge: c.Edge(uid, vid), W: w}
+}
+
+// Weight returns the weight for the edge between x and y with IDs xid and
+// yid if the edge is in the view. If the edge is held by both input graphs,
+// the weight is combined as described by the function that constructed the
+// view. If xid and yid are equal and the node is in the view, the self weight
+// of the first input graph holding the node is returned. Otherwise Weight
+// returns +Inf and false.
+func (c combinedWeighted) Weight(xid, yid int64) (w float64, ok bool) {
+	a := c.a.(graph.Weighted)
+	if xid == yid {
+		switch {
+		case c.Node(xid) == nil:
+			return math.Inf(1), false
+		case c.a.Node(xid) == nil:
+			return c.b.(graph.Weighted).Weight(xid, yid)
+		default:
+			return a.Weight(xid, yid)
+		}
+	}
+	if !c.hasEdge(xid, yid) {
+		return math.Inf(1), false
+	}
+	if c.a.Edge(xid, yid) == nil {
+		return c.b.(graph.Weighted).Weight(xid, yid)
+	}
+	w, ok = a.Weight(xid, yid)
+	if c.op == difference || c.combine == nil || c.b.Edge(xid, yid) == nil {
+		return w, ok
+	}
+	wb, _ := c.b.(graph.Weighted).Weight(xid, yid)
+	return c.combine(w, wb), true
+}
+
+// combinedWeightedUndirected is a read-only view of a set operation on two
+// weighted undirected graphs.
+type combinedWeightedUndirected struct {
+	combinedWeighted
+}
+
+// EdgeBetween returns the edge between nodes with IDs xid and yid if such
+// an edge exists in the view and nil otherwise.
+func (c combinedWeightedUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	return c.Edge(xid, yid)
+}
+
+// WeightedEdgeBetween returns the weighted edge between nodes with IDs xid
+// and yid if such an edge exists in the view and nil otherwise.
+func (c combinedWeightedUndirected) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	return c.WeightedEdge(xid, yid)
+}
+
+// combinedWeightedDirected is a read-only view of a set operation on two
+// weighted directed graphs.
+type combinedWeightedDirected struct {
+	combinedWeighted
+}
+
+// HasEdgeFromTo returns whether an edge exists in the view from u to v
+// with IDs uid and vid.
+func (c combinedWeightedDirected) HasEdgeFromTo(uid, vid int64) bool {
+	return c.hasEdge(uid, vid)
+}
+
+// To returns all nodes in the view that can reach directly to the node
+// with the given ID.
+func (c combinedWeightedDirected) To(id int64) graph.Nodes {
+	return c.to(id)
+}
+
+// weightedEdge is an edge of a weighted view holding the weight
+// reported by the view.
+type weightedEdge struct {
+	graph.Edge
+	W float64
+}
+
+// Weight returns the weight of the edge.
+func (e weightedEdge) Weight() float64 { return e.W }
@@ -0,0 +1,274 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view_test
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/testgraph"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+type weightedGraphBuilder interface {
+	graph.Graph
+	graph.NodeAdder
+	SetWeightedEdge(graph.WeightedEdge)
+}
+
+// combinedBuilder returns a testgraph.Builder that constructs a view of
+// two graphs combined with the named set operation such that the view
+// holds the requested nodes and edges. Nodes and edges are placed in the
+// input graphs so that each kind of membership is exercised, and decoy
+// nodes and edges are added that must be excluded from the view.
+func combinedBuilder(op string, directed, weighted bool) testgraph.Builder {
+	return func(nodes []graph.Node, edges []graph.WeightedLine, self, absent float64) (g graph.Graph, n []graph.Node, e []graph.Edge, s, a float64, ok bool) {
+		if !weighted {
+			self = math.NaN()
+		}
+		var ga, gb weightedGraphBuilder
+		if directed {
+			ga = simple.NewWeightedDirectedGraph(self, math.Inf(1))
+			gb = simple.NewWeightedDirectedGraph(self, math.Inf(1))
+		} else {
+			ga = simple.NewWeightedUndirectedGraph(self, math.Inf(1))
+			gb = simple.NewWeightedUndirectedGraph(self, math.Inf(1))
+		}
+
+		seen := make(set.Nodes)
+		for i, n := range nodes {
+			seen.Add(n)
+			switch {
+			case op != "union" || i%2 == 0:
+				ga.AddNode(n)
+				if op == "intersection" {
+					gb.AddNode(n)
+				}
+			default:
+				gb.AddNode(n)
+			}
+		}
+		for i, edge := range edges {
+			if edge.From().ID() == edge.To().ID() {
+				continue
+			}
+			f, t := edge.From(), edge.To()
+			for _, g := range []graph.Graph{ga, gb} {
+				if gn := g.Node(f.ID()); gn != nil {
+					f = gn
+				}
+				if gn := g.Node(t.ID()); gn != nil {
+					t = gn
+				}
+			}
+			ce := simple.WeightedEdge{F: f, T: t, W: edge.Weight()}
+			if !weighted {
+				ce.W = 1
+			}
+			seen.Add(ce.F)
+			seen.Add(ce.T)
+			if weighted {
+				e = append(e, ce)
+			} else {
+				e = append(e, simple.Edge{F: f, T: t})
+			}
+			switch op {
+			case "union":
+				switch i % 3 {
+				case 0:
+					ga.SetWeightedEdge(ce)
+				case 1:
+					gb.SetWeightedEdge(ce)
+				case 2:
+					ga.SetWeightedEdge(ce)
+					gb.SetWeightedEdge(ce)
+				}
+			case "intersection":
+				ga.SetWeightedEdge(ce)
+				gb.SetWeightedEdge(ce)
+			case "difference":
+				ga.SetWeightedEdge(ce)
+			}
+		}
+		if len(e) == 0 && len(edges) != 0 {
+			return nil, nil, nil, math.NaN(), math.NaN(), false
+		}
+
+		var real []graph.Node
+		if len(seen) != 0 {
+			real = make([]graph.Node, 0, len(seen))
+		}
+		for _, sn := range seen {
+			real = append(real, sn)
+		}
+
+		// Add decoy nodes and edges that are not part of the result.
+		if op != "union" {
+			next := int64(-1)
+			for _, u := range real {
+				if u.ID() <= next {
+					next = u.ID() - 1
+				}
+			}
+			for _, u := range real {
+				if op == "intersection" {
+					ga.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node(next), W: 1})
+					next--
+				}
+				gb.SetWeightedEdge(simple.WeightedEdge{F: u, T: simple.Node(next), W: 1})
+				next--
+			}
+			for i, u := range real {
+				for _, v := range real[i+1:] {
+					if ga.Edge(u.ID(), v.ID()) != nil || ga.Edge(v.ID(), u.ID()) != nil {
+						continue
+					}
+					if (u.ID()+v.ID())%2 == 0 {
+						ce := simple.WeightedEdge{F: u, T: v, W: 1}
+						ga.SetWeightedEdge(ce)
+						if op == "difference" {
+							gb.SetWeightedEdge(ce)
+						}
+					}
+				}
+			}
+		}
+
+		switch op {
+		case "union":
+			g = view.Union(ga, gb)
+		case "intersection":
+			g = view.Intersection(ga, gb)
+		case "difference":
+			g = view.Difference(ga, gb)
+		}
+		return g, real, e, self, math.Inf(1), true
+	}
+}
+
+func TestCombined(t *testing.T) {
+	for _, op := range []string{"union", "intersection", "difference"} {
+		for _, test := range []struct {
+			name     string
+			directed bool
+			weighted bool
+		}{
+			{name: "Undirected"},
+			{name: "Directed", directed: true},
+			{name: "WeightedUndirected", weighted: true},
+			{name: "WeightedDirected", directed: true, weighted: true},
+		} {
+			b := combinedBuilder(op, test.directed, test.weighted)
+			t.Run(op+"/"+test.name, func(t *testing.T) {
+				t.Run("EdgeExistence", func(t *testing.T) {
+					testgraph.EdgeExistence(t, b)
+				})
+				t.Run("NodeExistence", func(t *testing.T) {
+					testgraph.NodeExistence(t, b)
+				})
+				t.Run("ReturnAdjacentNodes", func(t *testing.T) {
+					testgraph.ReturnAdjacentNodes(t, b, true)
+				})
+				t.Run("ReturnAllNodes", func(t *testing.T) {
+					testgraph.ReturnAllNodes(t, b, true)
+				})
+				t.Run("ReturnNodeSlice", func(t *testing.T) {
+					testgraph.ReturnNodeSlice(t, b, true)
+				})
+				if test.weighted {
+					t.Run("Weight", func(t *testing.T) {
+						testgraph.Weight(t, b)
+					})
+				}
+			})
+		}
+	}
+}
+
+func TestCombinedWeights(t *testing.T) {
+	a := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	a.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	a.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 2})
+	b := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	b.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 10})
+	b.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 20})
+
+	sum := func(wa, wb float64) float64 { return wa + wb }
+	for _, test := range []struct {
+		name string
+		g    graph.Weighted
+		want map[[2]int64]float64
+	}{
+		{
+			name: "union",
+			g:    view.Union(a, b).(graph.Weighted),
+			want: map[[2]int64]float64{{0, 1}: 1, {1, 2}: 2, {2, 3}: 20},
+		},
+		{
+			name: "weighted union",
+			g:    view.WeightedUnion(a, b, sum),
+			want: map[[2]int64]float64{{0, 1}: 11, {1, 2}: 2, {2, 3}: 20},
+		},
+		{
+			name: "intersection",
+			g:    view.Intersection(a, b).(graph.Weighted),
+			want: map[[2]int64]float64{{0, 1}: 1},
+		},
+		{
+			name: "weighted intersection",
+			g:    view.WeightedIntersection(a, b, sum),
+			want: map[[2]int64]float64{{0, 1}: 11},
+		},
+		{
+			name: "difference",
+			g:    view.Difference(a, b).(graph.Weighted),
+			want: map[[2]int64]float64{{1, 2}: 2},
+		},
+	} {
+		for u := int64(0); u < 4; u++ {
+			for v := int64(0); v < 4; v++ {
+				if u == v {
+					continue
+				}
+				key := [2]int64{u, v}
+				if u > v {
+					key = [2]int64{v, u}
+				}
+				want, ok := test.want[key]
+				if !ok {
+					want = math.Inf(1)
+				}
+				got, gotOK := test.g.Weight(u, v)
+				if got != want || gotOK != ok {
+					t.Errorf("unexpected weight for %s edge %d--%d: got:%v,%t want:%v,%t",
+						test.name, u, v, got, gotOK, want, ok)
+				}
+				e := test.g.WeightedEdge(u, v)
+				if (e != nil) != ok {
+					t.Errorf("unexpected edge existence for %s edge %d--%d: got:%t want:%t",
+						test.name, u, v, e != nil, ok)
+					continue
+				}
+				if ok && e.Weight() != want {
+					t.Errorf("unexpected edge weight for %s edge %d--%d: got:%v want:%v",
+						test.name, u, v, e.Weight(), want)
+				}
+			}
+		}
+	}
+}
+
+func TestCombinedDirectednessMismatch(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Error("expected panic for mismatched directedness")
+		}
+	}()
+	view.Union(simple.NewDirectedGraph(), simple.NewUndirectedGraph())
+}
@@ -0,0 +1,12 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package view provides read-only views of graphs.
+//
+// Views present a modified form of an underlying graph without copying
+// it. The nodes and edges of a view are computed from the underlying
+// graph when they are queried, so a view reflects the current state of
+// the graph it wraps. Mutating the underlying graph invalidates any
+// iterators or other results previously obtained from the view.
+package view // import "gonum.org/v1/gonum/graph/view"
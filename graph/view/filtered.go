@@ -0,0 +1,317 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/weights"
+)
+
+var (
+	_ graph.Graph              = (*Filtered)(nil)
+	_ graph.Undirected         = (*FilteredUndirected)(nil)
+	_ graph.Directed           = (*FilteredDirected)(nil)
+	_ graph.Weighted           = (*FilteredWeighted)(nil)
+	_ graph.WeightedUndirected = (*FilteredWeightedUndirected)(nil)
+	_ graph.WeightedDirected   = (*FilteredWeightedDirected)(nil)
+)
+
+// Filtered is a read-only view of a graph presenting only the nodes and
+// edges of the underlying graph that are accepted by filter functions.
+type Filtered struct {
+	g      graph.Graph
+	nodeOK func(graph.Node) bool
+	edgeOK func(graph.Edge) bool
+}
+
+// NewFiltered returns a view of g holding only the nodes n for which
+// nodeOK(n) is true and the edges e between those nodes for which edgeOK(e)
+// is true. If nodeOK or edgeOK is nil, all nodes or edges are accepted
+// respectively. The edges passed to edgeOK are the edges returned by the
+// Edge method of g.
+func NewFiltered(g graph.Graph, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *Filtered {
+	return &Filtered{g: g, nodeOK: nodeOK, edgeOK: edgeOK}
+}
+
+// hasNode returns whether the node with the given ID is in the view.
+func (f *Filtered) hasNode(id int64) bool {
+	n := f.g.Node(id)
+	return n != nil && (f.nodeOK == nil || f.nodeOK(n))
+}
+
+// hasEdge returns whether the edge from u to v is in the view.
+func (f *Filtered) hasEdge(uid, vid int64) bool {
+	if !f.hasNode(uid) || !f.hasNode(vid) {
+		return false
+	}
+	e := f.g.Edge(uid, vid)
+	return e != nil && (f.edgeOK == nil || f.edgeOK(e))
+}
+
+// Node returns the node with the given ID if it exists in the view,
+// and nil otherwise.
+func (f *Filtered) Node(id int64) graph.Node {
+	n := f.g.Node(id)
+	if n == nil || (f.nodeOK != nil && !f.nodeOK(n)) {
+		return nil
+	}
+	return n
+}
+
+// Nodes returns all the nodes in the view.
+func (f *Filtered) Nodes() graph.Nodes {
+	return filterNodes(f.g.Nodes(), f.nodeOK)
+}
+
+// From returns all nodes in the view that can be reached directly from
+// the node with the given ID.
+func (f *Filtered) From(id int64) graph.Nodes {
+	if !f.hasNode(id) {
+		return graph.Empty
+	}
+	return filterNodes(f.g.From(id), func(v graph.Node) bool {
+		return f.hasEdge(id, v.ID())
+	})
+}
+
+// HasEdgeBetween returns whether an edge exists in the view between nodes
+// with IDs xid and yid without considering direction.
+func (f *Filtered) HasEdgeBetween(xid, yid int64) bool {
+	return f.hasEdge(xid, yid) || f.hasEdge(yid, xid)
+}
+
+// Edge returns the edge from u to v, with IDs uid and vid, if such an edge
+// exists in the view and nil otherwise.
+func (f *Filtered) Edge(uid, vid int64) graph.Edge {
+	if !f.hasEdge(uid, vid) {
+		return nil
+	}
+	return f.g.Edge(uid, vid)
+}
+
+// FilteredUndirected is a read-only filtered view of an undirected graph.
+type FilteredUndirected struct {
+	*Filtered
+}
+
+// NewFilteredUndirected returns an undirected view of g with the same
+// semantics as NewFiltered.
+func NewFilteredUndirected(g graph.Undirected, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *FilteredUndirected {
+	return &FilteredUndirected{Filtered: NewFiltered(g, nodeOK, edgeOK)}
+}
+
+// EdgeBetween returns the edge between nodes with IDs xid and yid if such
+// an edge exists in the view and nil otherwise.
+func (f *FilteredUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	return f.Edge(xid, yid)
+}
+
+// FilteredDirected is a read-only filtered view of a directed graph.
+type FilteredDirected struct {
+	*Filtered
+	g graph.Directed
+}
+
+// NewFilteredDirected returns a directed view of g with the same semantics
+// as NewFiltered.
+func NewFilteredDirected(g graph.Directed, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *FilteredDirected {
+	return &FilteredDirected{Filtered: NewFiltered(g, nodeOK, edgeOK), g: g}
+}
+
+// HasEdgeFromTo returns whether an edge exists in the view from u to v
+// with IDs uid and vid.
+func (f *FilteredDirected) HasEdgeFromTo(uid, vid int64) bool {
+	return f.hasEdge(uid, vid)
+}
+
+// To returns all nodes in the view that can reach directly to the node
+// with the given ID.
+func (f *FilteredDirected) To(id int64) graph.Nodes {
+	if !f.hasNode(id) {
+		return graph.Empty
+	}
+	return filterNodes(f.g.To(id), func(u graph.Node) bool {
+		return f.hasEdge(u.ID(), id)
+	})
+}
+
+// FilteredWeighted is a read-only filtered view of a weighted graph.
+type FilteredWeighted struct {
+	*Filtered
+	g      graph.Weighted
+	absent float64
+}
+
+// NewFilteredWeighted returns a weighted view of g with the same semantics
+// as NewFiltered. The absent weight of the view is the absent weight of g
+// when the view is created.
+func NewFilteredWeighted(g graph.Weighted, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *FilteredWeighted {
+	absent := math.Inf(1)
+	if it := g.Nodes(); it.Next() {
+		_, absent = weights.Params(g, []graph.Node{it.Node()})
+	}
+	return &FilteredWeighted{Filtered: NewFiltered(g, nodeOK, edgeOK), g: g, absent: absent}
+}
+
+// WeightedEdge returns the weighted edge from u to v with IDs uid and vid
+// if such an edge exists in the view and nil otherwise.
+func (f *FilteredWeighted) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	if !f.hasEdge(uid, vid) {
+		return nil
+	}
+	return f.g.WeightedEdge(uid, vid)
+}
+
+// Weight returns the weight for the edge between x and y with IDs xid and
+// yid if the edge is in the view, as reported by the underlying graph.
+// If xid and yid are equal and the node is in the view, the underlying
+// graph's self weight is returned. Otherwise Weight returns the absent
+// weight of the underlying graph and false.
+func (f *FilteredWeighted) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid && f.hasNode(xid) {
+		return f.g.Weight(xid, yid)
+	}
+	if !f.hasEdge(xid, yid) {
+		return f.absent, false
+	}
+	return f.g.Weight(xid, yid)
+}
+
+// FilteredWeightedUndirected is a read-only filtered view of a weighted
+// undirected graph.
+type FilteredWeightedUndirected struct {
+	*FilteredWeighted
+}
+
+// NewFilteredWeightedUndirected returns a weighted undirected view of g
+// with the same semantics as NewFiltered.
+func NewFilteredWeightedUndirected(g graph.WeightedUndirected, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *FilteredWeightedUndirected {
+	return &FilteredWeightedUndirected{FilteredWeighted: NewFilteredWeighted(g, nodeOK, edgeOK)}
+}
+
+// EdgeBetween returns the edge between nodes with IDs xid and yid if such
+// an edge exists in the view and nil otherwise.
+func (f *FilteredWeightedUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	return f.Edge(xid, yid)
+}
+
+// WeightedEdgeBetween returns the weighted edge between nodes with IDs xid
+// and yid if such an edge exists in the view and nil otherwise.
+func (f *FilteredWeightedUndirected) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	return f.WeightedEdge(xid, yid)
+}
+
+// FilteredWeightedDirected is a read-only filtered view of a weighted
+// directed graph.
+type FilteredWeightedDirected struct {
+	*FilteredWeighted
+	g graph.WeightedDirected
+}
+
+// NewFilteredWeightedDirected returns a weighted directed view of g with
+// the same semantics as NewFiltered.
+func NewFilteredWeightedDirected(g graph.WeightedDirected, nodeOK func(graph.Node) bool, edgeOK func(graph.Edge) bool) *FilteredWeightedDirected {
+	return &FilteredWeightedDirected{FilteredWeighted: NewFilteredWeighted(g, nodeOK, edgeOK), g: g}
+}
+
+// HasEdgeFromTo returns whether an edge exists in the view from u to v
+// with IDs uid and vid.
+func (f *FilteredWeightedDirected) HasEdgeFromTo(uid, vid int64) bool {
+	return f.hasEdge(uid, vid)
+}
+
+// To returns all nodes in the view that can reach directly to the node
+// with the given ID.
+func (f *FilteredWeightedDirected) To(id int64) graph.Nodes {
+	if !f.hasNode(id) {
+		return graph.Empty
+	}
+	return filterNodes(f.g.To(id), func(u graph.Node) bool {
+		return f.hasEdge(u.ID(), id)
+	})
+}
+
+// filterNodes returns an iterator over the nodes of it for which keep
+// returns true, or graph.Empty if there are no such nodes. If keep is nil,
+// it is returned.
+func filterNodes(it graph.Nodes, keep func(graph.Node) bool) graph.Nodes {
+	if keep == nil {
+		return it
+	}
+	f := &filteredNodes{it: it, keep: keep, len: -1}
+	if !f.Next() {
+		return graph.Empty
+	}
+	f.Reset()
+	return f
+}
+
+// filteredNodes is a node iterator that lazily skips the nodes of an
+// underlying iterator that are rejected by keep.
+type filteredNodes struct {
+	it   graph.Nodes
+	keep func(graph.Node) bool
+	curr graph.Node
+
+	// seen is the number of nodes returned since
+	// the last reset and len is the total number
+	// of kept nodes, or -1 if not yet counted.
+	seen int
+	len  int
+}
+
+func (f *filteredNodes) Next() bool {
+	for f.it.Next() {
+		if n := f.it.Node(); f.keep(n) {
+			f.curr = n
+			f.seen++
+			return true
+		}
+	}
+	f.curr = nil
+	return false
+}
+
+func (f *filteredNodes) Node() graph.Node { return f.curr }
+
+// Len returns the number of kept nodes remaining in the iterator. The
+// first call counts the kept nodes by iterating over the underlying
+// iterator and then returns it to its current position.
+func (f *filteredNodes) Len() int {
+	if f.len < 0 {
+		f.it.Reset()
+		f.len = 0
+		for f.it.Next() {
+			if f.keep(f.it.Node()) {
+				f.len++
+			}
+		}
+		f.it.Reset()
+		for seen := 0; seen < f.seen && f.it.Next(); {
+			if f.keep(f.it.Node()) {
+				seen++
+			}
+		}
+	}
+	return f.len - f.seen
+}
+
+// NodeSlice returns all the remaining kept nodes in the iterator and
+// advances the iterator.
+func (f *filteredNodes) NodeSlice() []graph.Node {
+	var nodes []graph.Node
+	for f.Next() {
+		nodes = append(nodes, f.curr)
+	}
+	return nodes
+}
+
+func (f *filteredNodes) Reset() {
+	f.it.Reset()
+	f.curr = nil
+	f.seen = 0
+}
@@ -0,0 +1,241 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view_test
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/testgraph"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+// decoyEdge is an edge that is added to the underlying graph
+// and must be removed by the view's edge filter.
+type decoyEdge struct{ simple.WeightedEdge }
+
+// filteredBuilder returns a testgraph.Builder that constructs a view
+// of a graph holding the requested nodes and edges along with decoy
+// nodes and edges that are rejected by the view's filters.
+func filteredBuilder(directed, weighted bool) testgraph.Builder {
+	return func(nodes []graph.Node, edges []graph.WeightedLine, self, absent float64) (g graph.Graph, n []graph.Node, e []graph.Edge, s, a float64, ok bool) {
+		if !weighted {
+			self = math.NaN()
+		}
+		var dst interface {
+			graph.Graph
+			graph.NodeAdder
+			NewNode() graph.Node
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		switch {
+		case directed:
+			dst = simple.NewWeightedDirectedGraph(self, math.Inf(1))
+		default:
+			dst = simple.NewWeightedUndirectedGraph(self, math.Inf(1))
+		}
+
+		seen := make(set.Nodes)
+		for _, n := range nodes {
+			seen.Add(n)
+			dst.AddNode(n)
+		}
+		for _, edge := range edges {
+			if edge.From().ID() == edge.To().ID() {
+				continue
+			}
+			f := dst.Node(edge.From().ID())
+			if f == nil {
+				f = edge.From()
+			}
+			t := dst.Node(edge.To().ID())
+			if t == nil {
+				t = edge.To()
+			}
+			ce := simple.WeightedEdge{F: f, T: t, W: edge.Weight()}
+			if !weighted {
+				ce.W = 1
+			}
+			seen.Add(ce.F)
+			seen.Add(ce.T)
+			if weighted {
+				e = append(e, ce)
+			} else {
+				e = append(e, simple.Edge{F: f, T: t})
+			}
+			dst.SetWeightedEdge(ce)
+		}
+		if len(e) == 0 && len(edges) != 0 {
+			return nil, nil, nil, math.NaN(), math.NaN(), false
+		}
+
+		// Add decoy nodes joined to each real node and decoy
+		// edges between pairs of real nodes that are not joined.
+		var real []graph.Node
+		if len(seen) != 0 {
+			real = make([]graph.Node, 0, len(seen))
+		}
+		for _, sn := range seen {
+			real = append(real, sn)
+		}
+		decoys := make(set.Int64s)
+		for _, u := range real {
+			d := dst.NewNode()
+			decoys.Add(d.ID())
+			dst.SetWeightedEdge(simple.WeightedEdge{F: u, T: d, W: 1})
+			dst.SetWeightedEdge(simple.WeightedEdge{F: d, T: u, W: 1})
+		}
+		for i, u := range real {
+			for _, v := range real[i+1:] {
+				if dst.Edge(u.ID(), v.ID()) != nil || dst.Edge(v.ID(), u.ID()) != nil {
+					continue
+				}
+				if (u.ID()+v.ID())%2 == 0 {
+					dst.SetWeightedEdge(decoyEdge{simple.WeightedEdge{F: u, T: v, W: 1}})
+				}
+			}
+		}
+
+		nodeOK := func(n graph.Node) bool { return !decoys.Has(n.ID()) }
+		edgeOK := func(e graph.Edge) bool {
+			_, isDecoy := e.(decoyEdge)
+			return !isDecoy
+		}
+		switch {
+		case directed && weighted:
+			g = view.NewFilteredWeightedDirected(dst.(graph.WeightedDirected), nodeOK, edgeOK)
+		case directed:
+			g = view.NewFilteredDirected(dst.(graph.Directed), nodeOK, edgeOK)
+		case weighted:
+			g = view.NewFilteredWeightedUndirected(dst.(graph.WeightedUndirected), nodeOK, edgeOK)
+		default:
+			g = view.NewFilteredUndirected(dst.(graph.Undirected), nodeOK, edgeOK)
+		}
+		return g, real, e, self, math.Inf(1), true
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		directed bool
+		weighted bool
+	}{
+		{name: "Undirected"},
+		{name: "Directed", directed: true},
+		{name: "WeightedUndirected", weighted: true},
+		{name: "WeightedDirected", directed: true, weighted: true},
+	} {
+		b := filteredBuilder(test.directed, test.weighted)
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("EdgeExistence", func(t *testing.T) {
+				testgraph.EdgeExistence(t, b)
+			})
+			t.Run("NodeExistence", func(t *testing.T) {
+				testgraph.NodeExistence(t, b)
+			})
+			t.Run("ReturnAdjacentNodes", func(t *testing.T) {
+				testgraph.ReturnAdjacentNodes(t, b, true)
+			})
+			t.Run("ReturnAllNodes", func(t *testing.T) {
+				testgraph.ReturnAllNodes(t, b, true)
+			})
+			t.Run("ReturnNodeSlice", func(t *testing.T) {
+				testgraph.ReturnNodeSlice(t, b, true)
+			})
+			if test.weighted {
+				t.Run("Weight", func(t *testing.T) {
+					testgraph.Weight(t, b)
+				})
+			}
+		})
+	}
+}
+
+func TestFilteredAlgorithm(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for _, e := range []simple.Edge{
+		{F: simple.Node(0), T: simple.Node(1)},
+		{F: simple.Node(1), T: simple.Node(2)},
+		{F: simple.Node(2), T: simple.Node(3)},
+		{F: simple.Node(3), T: simple.Node(4)},
+	} {
+		g.SetEdge(e)
+	}
+
+	// Removing node 2 splits the path into two components.
+	v := view.NewFilteredUndirected(g, func(n graph.Node) bool { return n.ID() != 2 }, nil)
+	if got := len(topo.ConnectedComponents(v)); got != 2 {
+		t.Errorf("unexpected number of components: got:%d want:2", got)
+	}
+
+	// Removing the edge between 0 and 1 isolates 0.
+	v = view.NewFilteredUndirected(g, nil, func(e graph.Edge) bool {
+		return e.From().ID()+e.To().ID() != 1
+	})
+	if got := len(topo.ConnectedComponents(v)); got != 2 {
+		t.Errorf("unexpected number of components: got:%d want:2", got)
+	}
+
+	// The view reflects changes to the underlying graph.
+	g.RemoveEdge(3, 4)
+	if got := len(topo.ConnectedComponents(v)); got != 3 {
+		t.Errorf("unexpected number of components after mutation: got:%d want:3", got)
+	}
+}
+
+func TestFilteredIterator(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for v := int64(1); v < 10; v++ {
+		g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(v)})
+	}
+	v := view.NewFilteredDirected(g, func(n graph.Node) bool { return n.ID()%2 == 0 }, nil)
+
+	it := v.From(0)
+	if got := it.Len(); got != 4 {
+		t.Errorf("unexpected length: got:%d want:4", got)
+	}
+	var seen int
+	for it.Next() {
+		if it.Node().ID()%2 != 0 {
+			t.Errorf("unexpected node: %d", it.Node().ID())
+		}
+		seen++
+		if got, want := it.Len(), 4-seen; got != want {
+			t.Errorf("unexpected remaining length after %d nodes: got:%d want:%d", seen, got, want)
+		}
+	}
+	if seen != 4 {
+		t.Errorf("unexpected number of nodes: got:%d want:4", seen)
+	}
+	it.Reset()
+	if got := len(graph.NodesOf(it)); got != 4 {
+		t.Errorf("unexpected number of nodes after reset: got:%d want:4", got)
+	}
+
+	if it := v.From(1); it != graph.Empty {
+		t.Errorf("unexpected iterator for filtered node: got:%T want:graph.Empty", it)
+	}
+}
+
+func TestFilteredWeightedAbsent(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})
+	v := view.NewFilteredWeightedUndirected(g, func(n graph.Node) bool { return n.ID() != 2 }, nil)
+
+	if w, ok := v.Weight(0, 1); w != 2 || !ok {
+		t.Errorf("unexpected weight for kept edge: got:%v,%t want:2,true", w, ok)
+	}
+	for _, e := range [][2]int64{{1, 2}, {0, 2}, {2, 2}} {
+		if w, ok := v.Weight(e[0], e[1]); w != 0 || ok {
+			t.Errorf("unexpected weight for %d-%d: got:%v,%t want:0,false", e[0], e[1], w, ok)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view
+
+import "gonum.org/v1/gonum/graph"
+
+var (
+	_ graph.Directed         = (*Reversed)(nil)
+	_ graph.WeightedDirected = (*ReversedWeighted)(nil)
+)
+
+// Reversed is a read-only view of the transpose of a directed graph.
+type Reversed struct {
+	g graph.Directed
+}
+
+// Reverse returns a view of the transpose of g, the graph with the
+// direction of every edge in g reversed.
+func Reverse(g graph.Directed) *Reversed {
+	return &Reversed{g: g}
+}
+
+// Node returns the node with the given ID if it exists in the graph,
+// and nil otherwise.
+func (r *Reversed) Node(id int64) graph.Node { return r.g.Node(id) }
+
+// Nodes returns all the nodes in the graph.
+func (r *Reversed) Nodes() graph.Nodes { return r.g.Nodes() }
+
+// From returns all nodes that can be reached directly from the node
+// with the given ID. These are the nodes that can reach directly to
+// the node in the underlying graph.
+func (r *Reversed) From(id int64) graph.Nodes { return r.g.To(id) }
+
+// To returns all nodes that can reach directly to the node with the
+// given ID. These are the nodes that can be reached directly from the
+// node in the underlying graph.
+func (r *Reversed) To(id int64) graph.Nodes { return r.g.From(id) }
+
+// HasEdgeBetween returns whether an edge exists between nodes with IDs
+// xid and yid without considering direction.
+func (r *Reversed) HasEdgeBetween(xid, yid int64) bool { return r.g.HasEdgeBetween(xid, yid) }
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v
+// with IDs uid and vid.
+func (r *Reversed) HasEdgeFromTo(uid, vid int64) bool { return r.g.HasEdgeFromTo(vid, uid) }
+
+// Edge returns the edge from u to v, with IDs uid and vid, if such an
+// edge exists and nil otherwise. The returned edge is the reversal of
+// the edge from v to u in the underlying graph.
+func (r *Reversed) Edge(uid, vid int64) graph.Edge {
+	e := r.g.Edge(vid, uid)
+	if e == nil {
+		return nil
+	}
+	return ReversedEdge{Edge: e}
+}
+
+// ReversedWeighted is a read-only view of the transpose of a weighted
+// directed graph. Edge weights are preserved under reversal.
+type ReversedWeighted struct {
+	*Reversed
+	g graph.WeightedDirected
+}
+
+// ReverseWeighted returns a view of the transpose of g, the graph with
+// the direction of every edge in g reversed.
+func ReverseWeighted(g graph.WeightedDirected) *ReversedWeighted {
+	return &ReversedWeighted{Reversed: Reverse(g), g: g}
+}
+
+// Edge returns the edge from u to v, with IDs uid and vid, if such an
+// edge exists and nil otherwise. The returned edge is the reversal of
+// the edge from v to u in the underlying graph.
+func (r *ReversedWeighted) Edge(uid, vid int64) graph.Edge {
+	return r.WeightedEdge(uid, vid)
+}
+
+// WeightedEdge returns the weighted edge from u to v, with IDs uid and
+// vid, if such an edge exists and nil otherwise. The returned edge is
+// the reversal of the edge from v to u in the underlying graph.
+func (r *ReversedWeighted) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	e := r.g.WeightedEdge(vid, uid)
+	if e == nil {
+		return nil
+	}
+	return ReversedWeightedEdge{WeightedEdge: e}
+}
+
+// Weight returns the weight for the edge from x to y with IDs xid and
+// yid, which is the weight of the edge from y to x in the underlying
+// graph.
+func (r *ReversedWeighted) Weight(xid, yid int64) (w float64, ok bool) {
+	return r.g.Weight(yid, xid)
+}
+
+// ReversedEdge is a graph.Edge with the direction of the held edge
+// reversed.
+type ReversedEdge struct {
+	graph.Edge
+}
+
+// From returns the to-node of the held edge.
+func (e ReversedEdge) From() graph.Node { return e.Edge.To() }
+
+// To returns the from-node of the held edge.
+func (e ReversedEdge) To() graph.Node { return e.Edge.From() }
+
+// ReversedWeightedEdge is a graph.WeightedEdge with the direction of the
+// held edge reversed.
+type ReversedWeightedEdge struct {
+	graph.WeightedEdge
+}
+
+// From returns the to-node of the held edge.
+func (e ReversedWeightedEdge) From() graph.Node { return e.WeightedEdge.To() }
+
+// To returns the from-node of the held edge.
+func (e ReversedWeightedEdge) To() graph.Node { return e.WeightedEdge.From() }
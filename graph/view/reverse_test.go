@@ -0,0 +1,116 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package view_test
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/testgraph"
+	"gonum.org/v1/gonum/graph/view"
+)
+
+// reversedBuilder returns a testgraph.Builder that constructs the
+// reversed view of a graph holding the reversal of the requested edges.
+func reversedBuilder(weighted bool) testgraph.Builder {
+	return func(nodes []graph.Node, edges []graph.WeightedLine, self, absent float64) (g graph.Graph, n []graph.Node, e []graph.Edge, s, a float64, ok bool) {
+		seen := make(set.Nodes)
+		dg := simple.NewWeightedDirectedGraph(self, absent)
+		for _, n := range nodes {
+			seen.Add(n)
+			dg.AddNode(n)
+		}
+		for _, edge := range edges {
+			if edge.From().ID() == edge.To().ID() {
+				continue
+			}
+			f := dg.Node(edge.From().ID())
+			if f == nil {
+				f = edge.From()
+			}
+			t := dg.Node(edge.To().ID())
+			if t == nil {
+				t = edge.To()
+			}
+			seen.Add(f)
+			seen.Add(t)
+			e = append(e, simple.WeightedEdge{F: f, T: t, W: edge.Weight()})
+			dg.SetWeightedEdge(simple.WeightedEdge{F: t, T: f, W: edge.Weight()})
+		}
+		if len(e) == 0 && len(edges) != 0 {
+			return nil, nil, nil, math.NaN(), math.NaN(), false
+		}
+		if len(seen) != 0 {
+			n = make([]graph.Node, 0, len(seen))
+		}
+		for _, sn := range seen {
+			n = append(n, sn)
+		}
+		if weighted {
+			return view.ReverseWeighted(dg), n, e, self, absent, true
+		}
+		return view.Reverse(dg), n, e, self, absent, true
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		weighted bool
+	}{
+		{name: "Directed"},
+		{name: "WeightedDirected", weighted: true},
+	} {
+		b := reversedBuilder(test.weighted)
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("EdgeExistence", func(t *testing.T) {
+				testgraph.EdgeExistence(t, b)
+			})
+			t.Run("NodeExistence", func(t *testing.T) {
+				testgraph.NodeExistence(t, b)
+			})
+			t.Run("ReturnAdjacentNodes", func(t *testing.T) {
+				testgraph.ReturnAdjacentNodes(t, b, true)
+			})
+			t.Run("ReturnAllNodes", func(t *testing.T) {
+				testgraph.ReturnAllNodes(t, b, true)
+			})
+			t.Run("ReturnNodeSlice", func(t *testing.T) {
+				testgraph.ReturnNodeSlice(t, b, true)
+			})
+			if test.weighted {
+				t.Run("Weight", func(t *testing.T) {
+					testgraph.Weight(t, b)
+				})
+			}
+		})
+	}
+}
+
+func TestReversedEdge(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})
+
+	r := view.ReverseWeighted(g)
+	if r.Edge(1, 2) != nil {
+		t.Error("unexpected forward edge in reversed view")
+	}
+	e := r.WeightedEdge(2, 1)
+	if e == nil {
+		t.Fatal("missing reversed edge")
+	}
+	if e.From().ID() != 2 || e.To().ID() != 1 {
+		t.Errorf("unexpected reversed edge direction: got:%d->%d want:2->1", e.From().ID(), e.To().ID())
+	}
+	if e.Weight() != 3 {
+		t.Errorf("unexpected reversed edge weight: got:%v want:3", e.Weight())
+	}
+	if view.Reverse(r).Edge(1, 2) == nil {
+		t.Error("missing edge in doubly reversed view")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/mat"
+)
+
+// AbsorbingProbabilities returns the probability that a random walk on g
+// starting at each transient node, a node not in absorbing, is absorbed at
+// each of the absorbing nodes. The returned map is keyed by the ID of the
+// starting transient node and then by the ID of the absorbing node, and holds
+// an entry for every pair of transient and absorbing nodes.
+//
+// Each step of the walk follows an edge from the current node chosen
+// uniformly, or with probability proportional to the edge weight if g is a
+// graph.WeightedDirected, and the walk stops when it reaches an absorbing
+// node. A walk reaching a transient node with no out edges stops without being
+// absorbed, and a walk that can no longer reach an absorbing node is never
+// absorbed, so the probabilities for a starting node may sum to less than one.
+// Absorbing nodes that are not in g are ignored.
+//
+// The probabilities B are found from the fundamental matrix of the chain as
+// the solution of (I - Q) B = R, where Q holds the transition probabilities
+// between the transient nodes that can reach an absorbing node and R holds
+// the transition probabilities from those nodes to the absorbing nodes.
+//
+// AbsorbingProbabilities will panic if g is a graph.WeightedDirected with a
+// negative edge weight.
+func AbsorbingProbabilities(g graph.Directed, absorbing []graph.Node) map[int64]map[int64]float64 {
+	isAbsorbing := make(set.Int64s, len(absorbing))
+	sinks := make([]graph.Node, 0, len(absorbing))
+	for _, u := range absorbing {
+		uid := u.ID()
+		if g.Node(uid) == nil || isAbsorbing.Has(uid) {
+			continue
+		}
+		isAbsorbing.Add(uid)
+		sinks = append(sinks, u)
+	}
+	sort.Sort(ordered.ByID(sinks))
+	sinkIndex := make(map[int64]int, len(sinks))
+	for i, u := range sinks {
+		sinkIndex[u.ID()] = i
+	}
+
+	wg, weighted := g.(graph.WeightedDirected)
+	weight := func(uid, vid int64) float64 {
+		if !weighted {
+			return 1
+		}
+		w, ok := wg.Weight(uid, vid)
+		if !ok {
+			panic("walk: unexpected invalid weight")
+		}
+		if w < 0 {
+			panic("walk: negative edge weight")
+		}
+		return w
+	}
+
+	// Find the transient nodes that can reach an absorbing
+	// node by a search back from the absorbing nodes along
+	// edges that may be taken.
+	live := make(set.Int64s)
+	stack := append([]graph.Node(nil), sinks...)
+	for len(stack) != 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		to := g.To(u.ID())
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if isAbsorbing.Has(vid) || live.Has(vid) || weight(vid, u.ID()) == 0 {
+				continue
+			}
+			live.Add(vid)
+			stack = append(stack, v)
+		}
+	}
+
+	prob := make(map[int64]map[int64]float64)
+	var transient []graph.Node
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		uid := u.ID()
+		if isAbsorbing.Has(uid) {
+			continue
+		}
+		prob[uid] = make(map[int64]float64, len(sinks))
+		for _, s := range sinks {
+			prob[uid][s.ID()] = 0
+		}
+		if live.Has(uid) {
+			transient = append(transient, u)
+		}
+	}
+	if len(transient) == 0 || len(sinks) == 0 {
+		return prob
+	}
+	sort.Sort(ordered.ByID(transient))
+	indexOf := make(map[int64]int, len(transient))
+	for i, u := range transient {
+		indexOf[u.ID()] = i
+	}
+
+	n := len(transient)
+	a := mat.NewDense(n, n, nil)
+	r := mat.NewDense(n, len(sinks), nil)
+	for i, u := range transient {
+		uid := u.ID()
+		a.Set(i, i, 1)
+		var total float64
+		to := graph.NodesOf(g.From(uid))
+		for _, v := range to {
+			total += weight(uid, v.ID())
+		}
+		for _, v := range to {
+			vid := v.ID()
+			p := weight(uid, vid) / total
+			if j, ok := sinkIndex[vid]; ok {
+				r.Set(i, j, r.At(i, j)+p)
+			} else if j, ok := indexOf[vid]; ok {
+				a.Set(i, j, a.At(i, j)-p)
+			}
+		}
+	}
+
+	var b mat.Dense
+	err := b.Solve(a, r)
+	if err != nil {
+		if _, ok := err.(mat.Condition); !ok {
+			panic(err)
+		}
+	}
+	for i, u := range transient {
+		for j, s := range sinks {
+			prob[u.ID()][s.ID()] = b.At(i, j)
+		}
+	}
+	return prob
+}
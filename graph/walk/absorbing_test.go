@@ -0,0 +1,150 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAbsorbingProbabilities(t *testing.T) {
+	// Gambler's ruin on the path 0-1-2-3-4 with absorbing
+	// ends, and a dead end 5 reachable from 2.
+	g := simple.NewDirectedGraph()
+	for u := 0; u < 4; u++ {
+		g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(u + 1)})
+		g.SetEdge(simple.Edge{F: simple.Node(u + 1), T: simple.Node(u)})
+	}
+	g.AddNode(simple.Node(5))
+
+	got := AbsorbingProbabilities(g, []graph.Node{simple.Node(0), simple.Node(4)})
+	want := map[int64]map[int64]float64{
+		1: {0: 0.75, 4: 0.25},
+		2: {0: 0.5, 4: 0.5},
+		3: {0: 0.25, 4: 0.75},
+		5: {0: 0, 4: 0},
+	}
+	if !equalProbabilities(got, want, 1e-12) {
+		t.Errorf("unexpected probabilities: got:%v want:%v", got, want)
+	}
+
+	// Weighting the edges towards 4 biases the walk.
+	w := simple.NewWeightedDirectedGraph(0, 0)
+	for u := 0; u < 2; u++ {
+		w.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(u + 1), W: 3})
+		w.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u + 1), T: simple.Node(u), W: 1})
+	}
+	got = AbsorbingProbabilities(w, []graph.Node{simple.Node(0), simple.Node(2)})
+	want = map[int64]map[int64]float64{
+		1: {0: 0.25, 2: 0.75},
+	}
+	if !equalProbabilities(got, want, 1e-12) {
+		t.Errorf("unexpected weighted probabilities: got:%v want:%v", got, want)
+	}
+}
+
+func TestAbsorbingProbabilitiesRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 100; test++ {
+		n := 2 + rnd.Intn(10)
+		g := simple.NewWeightedDirectedGraph(0, 0)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rnd.Float64() < 0.3 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(4))})
+				}
+			}
+		}
+		var absorbing []graph.Node
+		for u := 0; u < n; u++ {
+			if rnd.Float64() < 0.3 {
+				absorbing = append(absorbing, simple.Node(u))
+			}
+		}
+
+		got := AbsorbingProbabilities(g, absorbing)
+		want := iterateAbsorbing(g, absorbing, 2000)
+		if !equalProbabilities(got, want, 1e-6) {
+			t.Errorf("unexpected probabilities for test %d: got:%v want:%v", test, got, want)
+		}
+	}
+}
+
+// iterateAbsorbing returns the absorption probabilities of walks on g
+// of at most the given number of steps.
+func iterateAbsorbing(g graph.WeightedDirected, absorbing []graph.Node, steps int) map[int64]map[int64]float64 {
+	isAbsorbing := make(map[int64]bool)
+	for _, s := range absorbing {
+		isAbsorbing[s.ID()] = true
+	}
+	nodes := graph.NodesOf(g.Nodes())
+	prob := make(map[int64]map[int64]float64)
+	for _, s := range absorbing {
+		p := make(map[int64]float64)
+		p[s.ID()] = 1
+		for i := 0; i < steps; i++ {
+			next := map[int64]float64{s.ID(): 1}
+			for _, u := range nodes {
+				uid := u.ID()
+				if isAbsorbing[uid] {
+					continue
+				}
+				var sum, total float64
+				for _, v := range graph.NodesOf(g.From(uid)) {
+					w, _ := g.Weight(uid, v.ID())
+					sum += w * p[v.ID()]
+					total += w
+				}
+				if total > 0 {
+					next[uid] = sum / total
+				}
+			}
+			p = next
+		}
+		for _, u := range nodes {
+			uid := u.ID()
+			if isAbsorbing[uid] {
+				continue
+			}
+			if prob[uid] == nil {
+				prob[uid] = make(map[int64]float64)
+			}
+			prob[uid][s.ID()] = p[uid]
+		}
+	}
+	for _, u := range nodes {
+		if !isAbsorbing[u.ID()] && prob[u.ID()] == nil {
+			prob[u.ID()] = make(map[int64]float64)
+		}
+	}
+	return prob
+}
+
+func equalProbabilities(a, b map[int64]map[int64]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for u, pa := range a {
+		pb, ok := b[u]
+		if !ok || len(pa) != len(pb) {
+			return false
+		}
+		for v, x := range pa {
+			y, ok := pb[v]
+			if !ok || math.Abs(x-y) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
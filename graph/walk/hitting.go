@@ -0,0 +1,100 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/spectral"
+	"gonum.org/v1/gonum/mat"
+)
+
+// HittingTimes returns the expected hitting times of a random walk on g and
+// the nodes of g in the order of the rows and columns of the returned matrix,
+// which is ordered by node ID. The element at (i, j) is the expected number of
+// steps taken by a walk starting at node i to first reach node j, where each
+// step moves from a node to a neighbor with probability proportional to the
+// weight of the edge joining them. Self edges and edges with zero weight are
+// ignored. Nodes in different connected components have an infinite hitting
+// time.
+//
+// The hitting times are found from the effective resistances of g, returned
+// by spectral.ResistanceMatrix, using the formula of Tetali
+// doi:10.1007/BF01046999
+//
+//	H(i, j) = 1/2 ∑_k d(k) (R(i, j) + R(j, k) - R(i, k))
+//
+// where d(k) is the weighted degree of node k and the sum is over the nodes
+// in the connected component of i and j.
+//
+// HittingTimes will panic if g has a negative edge weight.
+func HittingTimes(g graph.WeightedUndirected) (mat.Matrix, []graph.Node) {
+	r, nodes := spectral.ResistanceMatrix(g)
+	n := len(nodes)
+	if n == 0 {
+		return &mat.Dense{}, nil
+	}
+	deg := make([]float64, n)
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			w, _ := g.Weight(uid, vid)
+			deg[i] += w
+		}
+	}
+
+	h := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			rij := r.At(i, j)
+			if i == j || math.IsInf(rij, 1) {
+				h.Set(i, j, rij)
+				continue
+			}
+			var sum float64
+			for k, d := range deg {
+				rik := r.At(i, k)
+				if math.IsInf(rik, 1) {
+					continue
+				}
+				sum += d * (rij + r.At(j, k) - rik)
+			}
+			h.Set(i, j, sum/2)
+		}
+	}
+	return h, nodes
+}
+
+// CommuteTime returns the expected commute times of a random walk on g and
+// the nodes of g in the order of the rows and columns of the returned matrix,
+// which is ordered by node ID. The commute time between nodes i and j is the
+// expected number of steps taken by a walk starting at i to reach j and then
+// return to i, H(i, j) + H(j, i) where H is the hitting time matrix returned
+// by HittingTimes. For nodes in the same connected component, the commute time
+// is vol·R(i, j) where vol is the total weighted degree of the component and R
+// is the effective resistance between the nodes, so for a connected graph it
+// is 2m·R(i, j) where m is the total weight of the edges of g.
+//
+// CommuteTime will panic if g has a negative edge weight.
+func CommuteTime(g graph.WeightedUndirected) (mat.Symmetric, []graph.Node) {
+	h, nodes := HittingTimes(g)
+	n := len(nodes)
+	if n == 0 {
+		return &mat.SymDense{}, nil
+	}
+	c := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			c.SetSym(i, j, h.At(i, j)+h.At(j, i))
+		}
+	}
+	return c, nodes
+}
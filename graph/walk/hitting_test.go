@@ -0,0 +1,156 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/spectral"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestHittingTimes(t *testing.T) {
+	// A unit path 0-1-2 and an isolated node 3. From an end
+	// of the path the walk takes 4 steps on average to reach
+	// the other end, and from the middle it takes 3 steps.
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 1})
+	g.AddNode(simple.Node(3))
+
+	h, nodes := HittingTimes(g)
+	if len(nodes) != 4 {
+		t.Fatalf("unexpected number of nodes: got:%d want:4", len(nodes))
+	}
+	inf := math.Inf(1)
+	want := mat.NewDense(4, 4, []float64{
+		0, 1, 4, inf,
+		3, 0, 3, inf,
+		4, 1, 0, inf,
+		inf, inf, inf, 0,
+	})
+	if !equalApprox(h, want, 1e-12) {
+		t.Errorf("unexpected hitting times:\ngot: %v\nwant:%v", mat.Formatted(h), mat.Formatted(want))
+	}
+
+	c, _ := CommuteTime(g)
+	if got := c.At(0, 2); math.Abs(got-8) > 1e-12 {
+		t.Errorf("unexpected commute time: got:%v want:8", got)
+	}
+}
+
+func TestHittingTimesRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for test := 0; test < 50; test++ {
+		n := 1 + rnd.Intn(10)
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for u := 0; u < n; u++ {
+			g.AddNode(simple.Node(u))
+		}
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				if rnd.Float64() < 0.4 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 0.1 + rnd.Float64()})
+				}
+			}
+		}
+
+		h, nodes := HittingTimes(g)
+		for j := range nodes {
+			want := solveHitting(g, nodes, j)
+			for i, w := range want {
+				if got := h.At(i, j); !floats.EqualWithinAbsOrRel(got, w, 1e-8, 1e-8) && got != w {
+					t.Errorf("unexpected hitting time from %d to %d for test %d: got:%v want:%v", i, j, test, got, w)
+				}
+			}
+		}
+
+		r, _ := spectral.ResistanceMatrix(g)
+		c, _ := CommuteTime(g)
+		for i, u := range nodes {
+			for j, v := range nodes {
+				if i == j || !topo.PathExistsIn(g, u, v) {
+					continue
+				}
+				var vol float64
+				for _, k := range nodes {
+					if !topo.PathExistsIn(g, u, k) {
+						continue
+					}
+					for _, x := range graph.NodesOf(g.From(k.ID())) {
+						w, _ := g.Weight(k.ID(), x.ID())
+						vol += w
+					}
+				}
+				if got, want := c.At(i, j), vol*r.At(i, j); !floats.EqualWithinAbsOrRel(got, want, 1e-8, 1e-8) {
+					t.Errorf("unexpected commute time between %d and %d for test %d: got:%v want:%v", i, j, test, got, want)
+				}
+			}
+		}
+	}
+}
+
+// solveHitting returns the hitting times to nodes[j] from each node found
+// by solving h(i) = 1 + ∑_k P(i, k) h(k) with h(j) = 0 over the nodes
+// connected to nodes[j].
+func solveHitting(g graph.WeightedUndirected, nodes []graph.Node, j int) []float64 {
+	n := len(nodes)
+	a := mat.NewDense(n, n, nil)
+	b := mat.NewVecDense(n, nil)
+	connected := make([]bool, n)
+	for i, u := range nodes {
+		a.Set(i, i, 1)
+		connected[i] = i == j || topo.PathExistsIn(g, u, nodes[j])
+		if i == j || !connected[i] {
+			continue
+		}
+		b.SetVec(i, 1)
+		var deg float64
+		for _, v := range nodes {
+			if w, ok := g.Weight(u.ID(), v.ID()); ok && u.ID() != v.ID() {
+				deg += w
+			}
+		}
+		for k, v := range nodes {
+			if w, ok := g.Weight(u.ID(), v.ID()); ok && u.ID() != v.ID() {
+				a.Set(i, k, a.At(i, k)-w/deg)
+			}
+		}
+	}
+	var x mat.VecDense
+	err := x.SolveVec(a, b)
+	if err != nil {
+		panic(err)
+	}
+	h := make([]float64, n)
+	for i := range h {
+		if connected[i] {
+			h[i] = x.AtVec(i)
+		} else {
+			h[i] = math.Inf(1)
+		}
+	}
+	return h
+}
+
+func equalApprox(a, b mat.Matrix, tol float64) bool {
+	r, c := a.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			x, y := a.At(i, j), b.At(i, j)
+			if x != y && math.Abs(x-y) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}